@@ -24,6 +24,56 @@ const _ = grpc.SupportPackageIsVersion7
 type BacktesterServiceClient interface {
 	ExecuteStrategyFromFile(ctx context.Context, in *ExecuteStrategyFromFileRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error)
 	ExecuteStrategyFromConfig(ctx context.Context, in *ExecuteStrategyFromConfigRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error)
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	GetRunProgress(ctx context.Context, in *GetRunProgressRequest, opts ...grpc.CallOption) (*GetRunProgressResponse, error)
+	StreamEquityCurve(ctx context.Context, in *StreamEquityCurveRequest, opts ...grpc.CallOption) (BacktesterService_StreamEquityCurveClient, error)
+	SubscribeRunEvents(ctx context.Context, in *SubscribeRunEventsRequest, opts ...grpc.CallOption) (BacktesterService_SubscribeRunEventsClient, error)
+	ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (*ExportResultsResponse, error)
+	GetDefaultConfig(ctx context.Context, in *GetDefaultConfigRequest, opts ...grpc.CallOption) (*GetDefaultConfigResponse, error)
+	GetRecentRuns(ctx context.Context, in *GetRecentRunsRequest, opts ...grpc.CallOption) (*GetRecentRunsResponse, error)
+	InteractiveStrategy(ctx context.Context, opts ...grpc.CallOption) (BacktesterService_InteractiveStrategyClient, error)
+	CheckExchangeConnectivity(ctx context.Context, in *CheckExchangeConnectivityRequest, opts ...grpc.CallOption) (*CheckExchangeConnectivityResponse, error)
+	SetServerPaused(ctx context.Context, in *SetServerPausedRequest, opts ...grpc.CallOption) (*SetServerPausedResponse, error)
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	RegisterCompletionWebhook(ctx context.Context, in *RegisterCompletionWebhookRequest, opts ...grpc.CallOption) (*RegisterCompletionWebhookResponse, error)
+	GetStrategyLogs(ctx context.Context, in *GetStrategyLogsRequest, opts ...grpc.CallOption) (*GetStrategyLogsResponse, error)
+	CanonicalizeConfig(ctx context.Context, in *CanonicalizeConfigRequest, opts ...grpc.CallOption) (*CanonicalizeConfigResponse, error)
+	ExportRegistry(ctx context.Context, in *ExportRegistryRequest, opts ...grpc.CallOption) (*ExportRegistryResponse, error)
+	ImportRegistry(ctx context.Context, in *ImportRegistryRequest, opts ...grpc.CallOption) (*ImportRegistryResponse, error)
+	PreviewStrategy(ctx context.Context, in *PreviewStrategyRequest, opts ...grpc.CallOption) (*PreviewStrategyResponse, error)
+	UpdateServerConfig(ctx context.Context, in *UpdateServerConfigRequest, opts ...grpc.CallOption) (*UpdateServerConfigResponse, error)
+	GetDataAvailability(ctx context.Context, in *GetDataAvailabilityRequest, opts ...grpc.CallOption) (*GetDataAvailabilityResponse, error)
+	AnalyzeDateRange(ctx context.Context, in *AnalyzeDateRangeRequest, opts ...grpc.CallOption) (*AnalyzeDateRangeResponse, error)
+	RestartWithParams(ctx context.Context, in *RestartWithParamsRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error)
+	StreamStrategyProgress(ctx context.Context, in *StreamStrategyProgressRequest, opts ...grpc.CallOption) (BacktesterService_StreamStrategyProgressClient, error)
+	PreviewPositionSizing(ctx context.Context, in *PreviewPositionSizingRequest, opts ...grpc.CallOption) (*PreviewPositionSizingResponse, error)
+	StreamRunResourceUsage(ctx context.Context, in *StreamRunResourceUsageRequest, opts ...grpc.CallOption) (BacktesterService_StreamRunResourceUsageClient, error)
+	GetStrategyTrades(ctx context.Context, in *GetStrategyTradesRequest, opts ...grpc.CallOption) (*GetStrategyTradesResponse, error)
+	SaveConfigAsTemplate(ctx context.Context, in *SaveConfigAsTemplateRequest, opts ...grpc.CallOption) (*SaveConfigAsTemplateResponse, error)
+	ExecuteStrategyFromTemplate(ctx context.Context, in *ExecuteStrategyFromTemplateRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error)
+	ExecuteWalkForward(ctx context.Context, in *ExecuteWalkForwardRequest, opts ...grpc.CallOption) (*ExecuteWalkForwardResponse, error)
+	GetStrategyParameters(ctx context.Context, in *GetStrategyParametersRequest, opts ...grpc.CallOption) (*GetStrategyParametersResponse, error)
+	RunMonteCarlo(ctx context.Context, in *RunMonteCarloRequest, opts ...grpc.CallOption) (*RunMonteCarloResponse, error)
+	StreamSweepLeaderboard(ctx context.Context, in *StreamSweepLeaderboardRequest, opts ...grpc.CallOption) (BacktesterService_StreamSweepLeaderboardClient, error)
+	BenchmarkAgainstHold(ctx context.Context, in *BenchmarkAgainstHoldRequest, opts ...grpc.CallOption) (*BenchmarkAgainstHoldResponse, error)
+	EstimateDataDownload(ctx context.Context, in *EstimateDataDownloadRequest, opts ...grpc.CallOption) (*EstimateDataDownloadResponse, error)
+	VerifyDeterminism(ctx context.Context, in *VerifyDeterminismRequest, opts ...grpc.CallOption) (*VerifyDeterminismResponse, error)
+	PauseStrategy(ctx context.Context, in *PauseStrategyRequest, opts ...grpc.CallOption) (*PauseStrategyResponse, error)
+	ResumeStrategy(ctx context.Context, in *ResumeStrategyRequest, opts ...grpc.CallOption) (*ResumeStrategyResponse, error)
+	StreamDrawdownPeriods(ctx context.Context, in *StreamDrawdownPeriodsRequest, opts ...grpc.CallOption) (BacktesterService_StreamDrawdownPeriodsClient, error)
+	ExecuteStrategyAcrossPairs(ctx context.Context, in *ExecuteStrategyAcrossPairsRequest, opts ...grpc.CallOption) (*ExecuteStrategyAcrossPairsResponse, error)
+	DescribeStrategy(ctx context.Context, in *DescribeStrategyRequest, opts ...grpc.CallOption) (*DescribeStrategyResponse, error)
+	GetRollingMetrics(ctx context.Context, in *GetRollingMetricsRequest, opts ...grpc.CallOption) (*GetRollingMetricsResponse, error)
+	SetBaselineRun(ctx context.Context, in *SetBaselineRunRequest, opts ...grpc.CallOption) (*SetBaselineRunResponse, error)
+	CompareRuns(ctx context.Context, in *CompareRunsRequest, opts ...grpc.CallOption) (*CompareRunsResponse, error)
+	StreamRunCandles(ctx context.Context, in *StreamRunCandlesRequest, opts ...grpc.CallOption) (BacktesterService_StreamRunCandlesClient, error)
+	ComputeRunCorrelation(ctx context.Context, in *ComputeRunCorrelationRequest, opts ...grpc.CallOption) (*ComputeRunCorrelationResponse, error)
+	GetRunFeeSchedule(ctx context.Context, in *GetRunFeeScheduleRequest, opts ...grpc.CallOption) (*GetRunFeeScheduleResponse, error)
+	ScheduleStrategy(ctx context.Context, in *ScheduleStrategyRequest, opts ...grpc.CallOption) (*ScheduleStrategyResponse, error)
+	CancelStrategy(ctx context.Context, in *CancelStrategyRequest, opts ...grpc.CallOption) (*CancelStrategyResponse, error)
+	RecordSession(ctx context.Context, in *RecordSessionRequest, opts ...grpc.CallOption) (*RecordSessionResponse, error)
+	ReplaySession(ctx context.Context, in *ReplaySessionRequest, opts ...grpc.CallOption) (*ReplaySessionResponse, error)
+	GetRunProvenance(ctx context.Context, in *GetRunProvenanceRequest, opts ...grpc.CallOption) (*GetRunProvenanceResponse, error)
 }
 
 type backtesterServiceClient struct {
@@ -52,70 +102,1832 @@ func (c *backtesterServiceClient) ExecuteStrategyFromConfig(ctx context.Context,
 	return out, nil
 }
 
+func (c *backtesterServiceClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ListRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetRunProgress(ctx context.Context, in *GetRunProgressRequest, opts ...grpc.CallOption) (*GetRunProgressResponse, error) {
+	out := new(GetRunProgressResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetRunProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamEquityCurve(ctx context.Context, in *StreamEquityCurveRequest, opts ...grpc.CallOption) (BacktesterService_StreamEquityCurveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[0], "/btrpc.BacktesterService/StreamEquityCurve", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamEquityCurveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamEquityCurveClient interface {
+	Recv() (*EquityPoint, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamEquityCurveClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamEquityCurveClient) Recv() (*EquityPoint, error) {
+	m := new(EquityPoint)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) SubscribeRunEvents(ctx context.Context, in *SubscribeRunEventsRequest, opts ...grpc.CallOption) (BacktesterService_SubscribeRunEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[1], "/btrpc.BacktesterService/SubscribeRunEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceSubscribeRunEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_SubscribeRunEventsClient interface {
+	Recv() (*RunEvent, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceSubscribeRunEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceSubscribeRunEventsClient) Recv() (*RunEvent, error) {
+	m := new(RunEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (*ExportResultsResponse, error) {
+	out := new(ExportResultsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ExportResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetDefaultConfig(ctx context.Context, in *GetDefaultConfigRequest, opts ...grpc.CallOption) (*GetDefaultConfigResponse, error) {
+	out := new(GetDefaultConfigResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetDefaultConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetRecentRuns(ctx context.Context, in *GetRecentRunsRequest, opts ...grpc.CallOption) (*GetRecentRunsResponse, error) {
+	out := new(GetRecentRunsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetRecentRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) InteractiveStrategy(ctx context.Context, opts ...grpc.CallOption) (BacktesterService_InteractiveStrategyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[2], "/btrpc.BacktesterService/InteractiveStrategy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceInteractiveStrategyClient{stream}
+	return x, nil
+}
+
+type BacktesterService_InteractiveStrategyClient interface {
+	Send(*InteractiveStrategyRequest) error
+	Recv() (*InteractiveStrategyResponse, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceInteractiveStrategyClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceInteractiveStrategyClient) Send(m *InteractiveStrategyRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backtesterServiceInteractiveStrategyClient) Recv() (*InteractiveStrategyResponse, error) {
+	m := new(InteractiveStrategyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) CheckExchangeConnectivity(ctx context.Context, in *CheckExchangeConnectivityRequest, opts ...grpc.CallOption) (*CheckExchangeConnectivityResponse, error) {
+	out := new(CheckExchangeConnectivityResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/CheckExchangeConnectivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) SetServerPaused(ctx context.Context, in *SetServerPausedRequest, opts ...grpc.CallOption) (*SetServerPausedResponse, error) {
+	out := new(SetServerPausedResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/SetServerPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) RegisterCompletionWebhook(ctx context.Context, in *RegisterCompletionWebhookRequest, opts ...grpc.CallOption) (*RegisterCompletionWebhookResponse, error) {
+	out := new(RegisterCompletionWebhookResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/RegisterCompletionWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetStrategyLogs(ctx context.Context, in *GetStrategyLogsRequest, opts ...grpc.CallOption) (*GetStrategyLogsResponse, error) {
+	out := new(GetStrategyLogsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetStrategyLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) CanonicalizeConfig(ctx context.Context, in *CanonicalizeConfigRequest, opts ...grpc.CallOption) (*CanonicalizeConfigResponse, error) {
+	out := new(CanonicalizeConfigResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/CanonicalizeConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ExportRegistry(ctx context.Context, in *ExportRegistryRequest, opts ...grpc.CallOption) (*ExportRegistryResponse, error) {
+	out := new(ExportRegistryResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ExportRegistry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ImportRegistry(ctx context.Context, in *ImportRegistryRequest, opts ...grpc.CallOption) (*ImportRegistryResponse, error) {
+	out := new(ImportRegistryResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ImportRegistry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) PreviewStrategy(ctx context.Context, in *PreviewStrategyRequest, opts ...grpc.CallOption) (*PreviewStrategyResponse, error) {
+	out := new(PreviewStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/PreviewStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) UpdateServerConfig(ctx context.Context, in *UpdateServerConfigRequest, opts ...grpc.CallOption) (*UpdateServerConfigResponse, error) {
+	out := new(UpdateServerConfigResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/UpdateServerConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetDataAvailability(ctx context.Context, in *GetDataAvailabilityRequest, opts ...grpc.CallOption) (*GetDataAvailabilityResponse, error) {
+	out := new(GetDataAvailabilityResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetDataAvailability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) AnalyzeDateRange(ctx context.Context, in *AnalyzeDateRangeRequest, opts ...grpc.CallOption) (*AnalyzeDateRangeResponse, error) {
+	out := new(AnalyzeDateRangeResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/AnalyzeDateRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) RestartWithParams(ctx context.Context, in *RestartWithParamsRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error) {
+	out := new(ExecuteStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/RestartWithParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamStrategyProgress(ctx context.Context, in *StreamStrategyProgressRequest, opts ...grpc.CallOption) (BacktesterService_StreamStrategyProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[3], "/btrpc.BacktesterService/StreamStrategyProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamStrategyProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamStrategyProgressClient interface {
+	Recv() (*GetRunProgressResponse, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamStrategyProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamStrategyProgressClient) Recv() (*GetRunProgressResponse, error) {
+	m := new(GetRunProgressResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) PreviewPositionSizing(ctx context.Context, in *PreviewPositionSizingRequest, opts ...grpc.CallOption) (*PreviewPositionSizingResponse, error) {
+	out := new(PreviewPositionSizingResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/PreviewPositionSizing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamRunResourceUsage(ctx context.Context, in *StreamRunResourceUsageRequest, opts ...grpc.CallOption) (BacktesterService_StreamRunResourceUsageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[4], "/btrpc.BacktesterService/StreamRunResourceUsage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamRunResourceUsageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamRunResourceUsageClient interface {
+	Recv() (*ResourceUsageSample, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamRunResourceUsageClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamRunResourceUsageClient) Recv() (*ResourceUsageSample, error) {
+	m := new(ResourceUsageSample)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) GetStrategyTrades(ctx context.Context, in *GetStrategyTradesRequest, opts ...grpc.CallOption) (*GetStrategyTradesResponse, error) {
+	out := new(GetStrategyTradesResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetStrategyTrades", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) SaveConfigAsTemplate(ctx context.Context, in *SaveConfigAsTemplateRequest, opts ...grpc.CallOption) (*SaveConfigAsTemplateResponse, error) {
+	out := new(SaveConfigAsTemplateResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/SaveConfigAsTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ExecuteStrategyFromTemplate(ctx context.Context, in *ExecuteStrategyFromTemplateRequest, opts ...grpc.CallOption) (*ExecuteStrategyResponse, error) {
+	out := new(ExecuteStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ExecuteStrategyFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ExecuteWalkForward(ctx context.Context, in *ExecuteWalkForwardRequest, opts ...grpc.CallOption) (*ExecuteWalkForwardResponse, error) {
+	out := new(ExecuteWalkForwardResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ExecuteWalkForward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetStrategyParameters(ctx context.Context, in *GetStrategyParametersRequest, opts ...grpc.CallOption) (*GetStrategyParametersResponse, error) {
+	out := new(GetStrategyParametersResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetStrategyParameters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) RunMonteCarlo(ctx context.Context, in *RunMonteCarloRequest, opts ...grpc.CallOption) (*RunMonteCarloResponse, error) {
+	out := new(RunMonteCarloResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/RunMonteCarlo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamSweepLeaderboard(ctx context.Context, in *StreamSweepLeaderboardRequest, opts ...grpc.CallOption) (BacktesterService_StreamSweepLeaderboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[5], "/btrpc.BacktesterService/StreamSweepLeaderboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamSweepLeaderboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamSweepLeaderboardClient interface {
+	Recv() (*SweepLeaderboardUpdate, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamSweepLeaderboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamSweepLeaderboardClient) Recv() (*SweepLeaderboardUpdate, error) {
+	m := new(SweepLeaderboardUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) BenchmarkAgainstHold(ctx context.Context, in *BenchmarkAgainstHoldRequest, opts ...grpc.CallOption) (*BenchmarkAgainstHoldResponse, error) {
+	out := new(BenchmarkAgainstHoldResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/BenchmarkAgainstHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) EstimateDataDownload(ctx context.Context, in *EstimateDataDownloadRequest, opts ...grpc.CallOption) (*EstimateDataDownloadResponse, error) {
+	out := new(EstimateDataDownloadResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/EstimateDataDownload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) VerifyDeterminism(ctx context.Context, in *VerifyDeterminismRequest, opts ...grpc.CallOption) (*VerifyDeterminismResponse, error) {
+	out := new(VerifyDeterminismResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/VerifyDeterminism", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) PauseStrategy(ctx context.Context, in *PauseStrategyRequest, opts ...grpc.CallOption) (*PauseStrategyResponse, error) {
+	out := new(PauseStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/PauseStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ResumeStrategy(ctx context.Context, in *ResumeStrategyRequest, opts ...grpc.CallOption) (*ResumeStrategyResponse, error) {
+	out := new(ResumeStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ResumeStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamDrawdownPeriods(ctx context.Context, in *StreamDrawdownPeriodsRequest, opts ...grpc.CallOption) (BacktesterService_StreamDrawdownPeriodsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[6], "/btrpc.BacktesterService/StreamDrawdownPeriods", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamDrawdownPeriodsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamDrawdownPeriodsClient interface {
+	Recv() (*DrawdownPeriod, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamDrawdownPeriodsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamDrawdownPeriodsClient) Recv() (*DrawdownPeriod, error) {
+	m := new(DrawdownPeriod)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) ExecuteStrategyAcrossPairs(ctx context.Context, in *ExecuteStrategyAcrossPairsRequest, opts ...grpc.CallOption) (*ExecuteStrategyAcrossPairsResponse, error) {
+	out := new(ExecuteStrategyAcrossPairsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ExecuteStrategyAcrossPairs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) DescribeStrategy(ctx context.Context, in *DescribeStrategyRequest, opts ...grpc.CallOption) (*DescribeStrategyResponse, error) {
+	out := new(DescribeStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/DescribeStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetRollingMetrics(ctx context.Context, in *GetRollingMetricsRequest, opts ...grpc.CallOption) (*GetRollingMetricsResponse, error) {
+	out := new(GetRollingMetricsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetRollingMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) SetBaselineRun(ctx context.Context, in *SetBaselineRunRequest, opts ...grpc.CallOption) (*SetBaselineRunResponse, error) {
+	out := new(SetBaselineRunResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/SetBaselineRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) CompareRuns(ctx context.Context, in *CompareRunsRequest, opts ...grpc.CallOption) (*CompareRunsResponse, error) {
+	out := new(CompareRunsResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/CompareRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) StreamRunCandles(ctx context.Context, in *StreamRunCandlesRequest, opts ...grpc.CallOption) (BacktesterService_StreamRunCandlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[7], "/btrpc.BacktesterService/StreamRunCandles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamRunCandlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamRunCandlesClient interface {
+	Recv() (*RunCandle, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamRunCandlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamRunCandlesClient) Recv() (*RunCandle, error) {
+	m := new(RunCandle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backtesterServiceClient) ComputeRunCorrelation(ctx context.Context, in *ComputeRunCorrelationRequest, opts ...grpc.CallOption) (*ComputeRunCorrelationResponse, error) {
+	out := new(ComputeRunCorrelationResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ComputeRunCorrelation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetRunFeeSchedule(ctx context.Context, in *GetRunFeeScheduleRequest, opts ...grpc.CallOption) (*GetRunFeeScheduleResponse, error) {
+	out := new(GetRunFeeScheduleResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetRunFeeSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ScheduleStrategy(ctx context.Context, in *ScheduleStrategyRequest, opts ...grpc.CallOption) (*ScheduleStrategyResponse, error) {
+	out := new(ScheduleStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ScheduleStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) CancelStrategy(ctx context.Context, in *CancelStrategyRequest, opts ...grpc.CallOption) (*CancelStrategyResponse, error) {
+	out := new(CancelStrategyResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/CancelStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) RecordSession(ctx context.Context, in *RecordSessionRequest, opts ...grpc.CallOption) (*RecordSessionResponse, error) {
+	out := new(RecordSessionResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/RecordSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) ReplaySession(ctx context.Context, in *ReplaySessionRequest, opts ...grpc.CallOption) (*ReplaySessionResponse, error) {
+	out := new(ReplaySessionResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/ReplaySession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backtesterServiceClient) GetRunProvenance(ctx context.Context, in *GetRunProvenanceRequest, opts ...grpc.CallOption) (*GetRunProvenanceResponse, error) {
+	out := new(GetRunProvenanceResponse)
+	err := c.cc.Invoke(ctx, "/btrpc.BacktesterService/GetRunProvenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BacktesterServiceServer is the server API for BacktesterService service.
 // All implementations must embed UnimplementedBacktesterServiceServer
 // for forward compatibility
 type BacktesterServiceServer interface {
 	ExecuteStrategyFromFile(context.Context, *ExecuteStrategyFromFileRequest) (*ExecuteStrategyResponse, error)
 	ExecuteStrategyFromConfig(context.Context, *ExecuteStrategyFromConfigRequest) (*ExecuteStrategyResponse, error)
+	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
+	GetRunProgress(context.Context, *GetRunProgressRequest) (*GetRunProgressResponse, error)
+	StreamEquityCurve(*StreamEquityCurveRequest, BacktesterService_StreamEquityCurveServer) error
+	SubscribeRunEvents(*SubscribeRunEventsRequest, BacktesterService_SubscribeRunEventsServer) error
+	ExportResults(context.Context, *ExportResultsRequest) (*ExportResultsResponse, error)
+	GetDefaultConfig(context.Context, *GetDefaultConfigRequest) (*GetDefaultConfigResponse, error)
+	GetRecentRuns(context.Context, *GetRecentRunsRequest) (*GetRecentRunsResponse, error)
+	InteractiveStrategy(BacktesterService_InteractiveStrategyServer) error
+	CheckExchangeConnectivity(context.Context, *CheckExchangeConnectivityRequest) (*CheckExchangeConnectivityResponse, error)
+	SetServerPaused(context.Context, *SetServerPausedRequest) (*SetServerPausedResponse, error)
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	RegisterCompletionWebhook(context.Context, *RegisterCompletionWebhookRequest) (*RegisterCompletionWebhookResponse, error)
+	GetStrategyLogs(context.Context, *GetStrategyLogsRequest) (*GetStrategyLogsResponse, error)
+	CanonicalizeConfig(context.Context, *CanonicalizeConfigRequest) (*CanonicalizeConfigResponse, error)
+	ExportRegistry(context.Context, *ExportRegistryRequest) (*ExportRegistryResponse, error)
+	ImportRegistry(context.Context, *ImportRegistryRequest) (*ImportRegistryResponse, error)
+	PreviewStrategy(context.Context, *PreviewStrategyRequest) (*PreviewStrategyResponse, error)
+	UpdateServerConfig(context.Context, *UpdateServerConfigRequest) (*UpdateServerConfigResponse, error)
+	GetDataAvailability(context.Context, *GetDataAvailabilityRequest) (*GetDataAvailabilityResponse, error)
+	AnalyzeDateRange(context.Context, *AnalyzeDateRangeRequest) (*AnalyzeDateRangeResponse, error)
+	RestartWithParams(context.Context, *RestartWithParamsRequest) (*ExecuteStrategyResponse, error)
+	StreamStrategyProgress(*StreamStrategyProgressRequest, BacktesterService_StreamStrategyProgressServer) error
+	PreviewPositionSizing(context.Context, *PreviewPositionSizingRequest) (*PreviewPositionSizingResponse, error)
+	StreamRunResourceUsage(*StreamRunResourceUsageRequest, BacktesterService_StreamRunResourceUsageServer) error
+	GetStrategyTrades(context.Context, *GetStrategyTradesRequest) (*GetStrategyTradesResponse, error)
+	SaveConfigAsTemplate(context.Context, *SaveConfigAsTemplateRequest) (*SaveConfigAsTemplateResponse, error)
+	ExecuteStrategyFromTemplate(context.Context, *ExecuteStrategyFromTemplateRequest) (*ExecuteStrategyResponse, error)
+	ExecuteWalkForward(context.Context, *ExecuteWalkForwardRequest) (*ExecuteWalkForwardResponse, error)
+	GetStrategyParameters(context.Context, *GetStrategyParametersRequest) (*GetStrategyParametersResponse, error)
+	RunMonteCarlo(context.Context, *RunMonteCarloRequest) (*RunMonteCarloResponse, error)
+	StreamSweepLeaderboard(*StreamSweepLeaderboardRequest, BacktesterService_StreamSweepLeaderboardServer) error
+	BenchmarkAgainstHold(context.Context, *BenchmarkAgainstHoldRequest) (*BenchmarkAgainstHoldResponse, error)
+	EstimateDataDownload(context.Context, *EstimateDataDownloadRequest) (*EstimateDataDownloadResponse, error)
+	VerifyDeterminism(context.Context, *VerifyDeterminismRequest) (*VerifyDeterminismResponse, error)
+	PauseStrategy(context.Context, *PauseStrategyRequest) (*PauseStrategyResponse, error)
+	ResumeStrategy(context.Context, *ResumeStrategyRequest) (*ResumeStrategyResponse, error)
+	StreamDrawdownPeriods(*StreamDrawdownPeriodsRequest, BacktesterService_StreamDrawdownPeriodsServer) error
+	ExecuteStrategyAcrossPairs(context.Context, *ExecuteStrategyAcrossPairsRequest) (*ExecuteStrategyAcrossPairsResponse, error)
+	DescribeStrategy(context.Context, *DescribeStrategyRequest) (*DescribeStrategyResponse, error)
+	GetRollingMetrics(context.Context, *GetRollingMetricsRequest) (*GetRollingMetricsResponse, error)
+	SetBaselineRun(context.Context, *SetBaselineRunRequest) (*SetBaselineRunResponse, error)
+	CompareRuns(context.Context, *CompareRunsRequest) (*CompareRunsResponse, error)
+	StreamRunCandles(*StreamRunCandlesRequest, BacktesterService_StreamRunCandlesServer) error
+	ComputeRunCorrelation(context.Context, *ComputeRunCorrelationRequest) (*ComputeRunCorrelationResponse, error)
+	GetRunFeeSchedule(context.Context, *GetRunFeeScheduleRequest) (*GetRunFeeScheduleResponse, error)
+	ScheduleStrategy(context.Context, *ScheduleStrategyRequest) (*ScheduleStrategyResponse, error)
+	CancelStrategy(context.Context, *CancelStrategyRequest) (*CancelStrategyResponse, error)
+	RecordSession(context.Context, *RecordSessionRequest) (*RecordSessionResponse, error)
+	ReplaySession(context.Context, *ReplaySessionRequest) (*ReplaySessionResponse, error)
+	GetRunProvenance(context.Context, *GetRunProvenanceRequest) (*GetRunProvenanceResponse, error)
+	mustEmbedUnimplementedBacktesterServiceServer()
+}
+
+// UnimplementedBacktesterServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBacktesterServiceServer struct {
+}
+
+func (UnimplementedBacktesterServiceServer) ExecuteStrategyFromFile(context.Context, *ExecuteStrategyFromFileRequest) (*ExecuteStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyFromFile not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExecuteStrategyFromConfig(context.Context, *ExecuteStrategyFromConfigRequest) (*ExecuteStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyFromConfig not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetRunProgress(context.Context, *GetRunProgressRequest) (*GetRunProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunProgress not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamEquityCurve(*StreamEquityCurveRequest, BacktesterService_StreamEquityCurveServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEquityCurve not implemented")
+}
+func (UnimplementedBacktesterServiceServer) SubscribeRunEvents(*SubscribeRunEventsRequest, BacktesterService_SubscribeRunEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeRunEvents not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExportResults(context.Context, *ExportResultsRequest) (*ExportResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportResults not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetDefaultConfig(context.Context, *GetDefaultConfigRequest) (*GetDefaultConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefaultConfig not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetRecentRuns(context.Context, *GetRecentRunsRequest) (*GetRecentRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentRuns not implemented")
+}
+func (UnimplementedBacktesterServiceServer) InteractiveStrategy(BacktesterService_InteractiveStrategyServer) error {
+	return status.Errorf(codes.Unimplemented, "method InteractiveStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) CheckExchangeConnectivity(context.Context, *CheckExchangeConnectivityRequest) (*CheckExchangeConnectivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckExchangeConnectivity not implemented")
+}
+func (UnimplementedBacktesterServiceServer) SetServerPaused(context.Context, *SetServerPausedRequest) (*SetServerPausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServerPaused not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedBacktesterServiceServer) RegisterCompletionWebhook(context.Context, *RegisterCompletionWebhookRequest) (*RegisterCompletionWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterCompletionWebhook not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetStrategyLogs(context.Context, *GetStrategyLogsRequest) (*GetStrategyLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategyLogs not implemented")
+}
+func (UnimplementedBacktesterServiceServer) CanonicalizeConfig(context.Context, *CanonicalizeConfigRequest) (*CanonicalizeConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanonicalizeConfig not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExportRegistry(context.Context, *ExportRegistryRequest) (*ExportRegistryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRegistry not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ImportRegistry(context.Context, *ImportRegistryRequest) (*ImportRegistryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRegistry not implemented")
+}
+func (UnimplementedBacktesterServiceServer) PreviewStrategy(context.Context, *PreviewStrategyRequest) (*PreviewStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) UpdateServerConfig(context.Context, *UpdateServerConfigRequest) (*UpdateServerConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServerConfig not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetDataAvailability(context.Context, *GetDataAvailabilityRequest) (*GetDataAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataAvailability not implemented")
+}
+func (UnimplementedBacktesterServiceServer) AnalyzeDateRange(context.Context, *AnalyzeDateRangeRequest) (*AnalyzeDateRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeDateRange not implemented")
+}
+func (UnimplementedBacktesterServiceServer) RestartWithParams(context.Context, *RestartWithParamsRequest) (*ExecuteStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartWithParams not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamStrategyProgress(*StreamStrategyProgressRequest, BacktesterService_StreamStrategyProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStrategyProgress not implemented")
+}
+func (UnimplementedBacktesterServiceServer) PreviewPositionSizing(context.Context, *PreviewPositionSizingRequest) (*PreviewPositionSizingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewPositionSizing not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamRunResourceUsage(*StreamRunResourceUsageRequest, BacktesterService_StreamRunResourceUsageServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRunResourceUsage not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetStrategyTrades(context.Context, *GetStrategyTradesRequest) (*GetStrategyTradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategyTrades not implemented")
+}
+func (UnimplementedBacktesterServiceServer) SaveConfigAsTemplate(context.Context, *SaveConfigAsTemplateRequest) (*SaveConfigAsTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveConfigAsTemplate not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExecuteStrategyFromTemplate(context.Context, *ExecuteStrategyFromTemplateRequest) (*ExecuteStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyFromTemplate not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExecuteWalkForward(context.Context, *ExecuteWalkForwardRequest) (*ExecuteWalkForwardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteWalkForward not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetStrategyParameters(context.Context, *GetStrategyParametersRequest) (*GetStrategyParametersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategyParameters not implemented")
+}
+func (UnimplementedBacktesterServiceServer) RunMonteCarlo(context.Context, *RunMonteCarloRequest) (*RunMonteCarloResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunMonteCarlo not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamSweepLeaderboard(*StreamSweepLeaderboardRequest, BacktesterService_StreamSweepLeaderboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSweepLeaderboard not implemented")
+}
+func (UnimplementedBacktesterServiceServer) BenchmarkAgainstHold(context.Context, *BenchmarkAgainstHoldRequest) (*BenchmarkAgainstHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BenchmarkAgainstHold not implemented")
+}
+func (UnimplementedBacktesterServiceServer) EstimateDataDownload(context.Context, *EstimateDataDownloadRequest) (*EstimateDataDownloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateDataDownload not implemented")
+}
+func (UnimplementedBacktesterServiceServer) VerifyDeterminism(context.Context, *VerifyDeterminismRequest) (*VerifyDeterminismResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDeterminism not implemented")
+}
+func (UnimplementedBacktesterServiceServer) PauseStrategy(context.Context, *PauseStrategyRequest) (*PauseStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ResumeStrategy(context.Context, *ResumeStrategyRequest) (*ResumeStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamDrawdownPeriods(*StreamDrawdownPeriodsRequest, BacktesterService_StreamDrawdownPeriodsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDrawdownPeriods not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ExecuteStrategyAcrossPairs(context.Context, *ExecuteStrategyAcrossPairsRequest) (*ExecuteStrategyAcrossPairsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyAcrossPairs not implemented")
+}
+func (UnimplementedBacktesterServiceServer) DescribeStrategy(context.Context, *DescribeStrategyRequest) (*DescribeStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetRollingMetrics(context.Context, *GetRollingMetricsRequest) (*GetRollingMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRollingMetrics not implemented")
+}
+func (UnimplementedBacktesterServiceServer) SetBaselineRun(context.Context, *SetBaselineRunRequest) (*SetBaselineRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBaselineRun not implemented")
+}
+func (UnimplementedBacktesterServiceServer) CompareRuns(context.Context, *CompareRunsRequest) (*CompareRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareRuns not implemented")
+}
+func (UnimplementedBacktesterServiceServer) StreamRunCandles(*StreamRunCandlesRequest, BacktesterService_StreamRunCandlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRunCandles not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ComputeRunCorrelation(context.Context, *ComputeRunCorrelationRequest) (*ComputeRunCorrelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeRunCorrelation not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetRunFeeSchedule(context.Context, *GetRunFeeScheduleRequest) (*GetRunFeeScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunFeeSchedule not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ScheduleStrategy(context.Context, *ScheduleStrategyRequest) (*ScheduleStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) CancelStrategy(context.Context, *CancelStrategyRequest) (*CancelStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelStrategy not implemented")
+}
+func (UnimplementedBacktesterServiceServer) RecordSession(context.Context, *RecordSessionRequest) (*RecordSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSession not implemented")
+}
+func (UnimplementedBacktesterServiceServer) ReplaySession(context.Context, *ReplaySessionRequest) (*ReplaySessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaySession not implemented")
+}
+func (UnimplementedBacktesterServiceServer) GetRunProvenance(context.Context, *GetRunProvenanceRequest) (*GetRunProvenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunProvenance not implemented")
+}
+func (UnimplementedBacktesterServiceServer) mustEmbedUnimplementedBacktesterServiceServer() {}
+
+// UnsafeBacktesterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BacktesterServiceServer will
+// result in compilation errors.
+type UnsafeBacktesterServiceServer interface {
 	mustEmbedUnimplementedBacktesterServiceServer()
 }
 
-// UnimplementedBacktesterServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedBacktesterServiceServer struct {
+func RegisterBacktesterServiceServer(s grpc.ServiceRegistrar, srv BacktesterServiceServer) {
+	s.RegisterService(&BacktesterService_ServiceDesc, srv)
+}
+
+func _BacktesterService_ExecuteStrategyFromFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStrategyFromFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromFile(ctx, req.(*ExecuteStrategyFromFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ExecuteStrategyFromConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStrategyFromConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromConfig(ctx, req.(*ExecuteStrategyFromConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ListRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ListRuns(ctx, req.(*ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetRunProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetRunProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetRunProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetRunProgress(ctx, req.(*GetRunProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_StreamEquityCurve_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEquityCurveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamEquityCurve(m, &backtesterServiceStreamEquityCurveServer{stream})
+}
+
+type BacktesterService_StreamEquityCurveServer interface {
+	Send(*EquityPoint) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamEquityCurveServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamEquityCurveServer) Send(m *EquityPoint) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_SubscribeRunEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRunEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).SubscribeRunEvents(m, &backtesterServiceSubscribeRunEventsServer{stream})
+}
+
+type BacktesterService_SubscribeRunEventsServer interface {
+	Send(*RunEvent) error
+	grpc.ServerStream
+}
+
+type backtesterServiceSubscribeRunEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceSubscribeRunEventsServer) Send(m *RunEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_ExportResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExportResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExportResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExportResults(ctx, req.(*ExportResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetDefaultConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDefaultConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetDefaultConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetDefaultConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetDefaultConfig(ctx, req.(*GetDefaultConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetRecentRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetRecentRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetRecentRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetRecentRuns(ctx, req.(*GetRecentRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_InteractiveStrategy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BacktesterServiceServer).InteractiveStrategy(&backtesterServiceInteractiveStrategyServer{stream})
+}
+
+type BacktesterService_InteractiveStrategyServer interface {
+	Send(*InteractiveStrategyResponse) error
+	Recv() (*InteractiveStrategyRequest, error)
+	grpc.ServerStream
+}
+
+type backtesterServiceInteractiveStrategyServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceInteractiveStrategyServer) Send(m *InteractiveStrategyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *backtesterServiceInteractiveStrategyServer) Recv() (*InteractiveStrategyRequest, error) {
+	m := new(InteractiveStrategyRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BacktesterService_CheckExchangeConnectivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckExchangeConnectivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).CheckExchangeConnectivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/CheckExchangeConnectivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).CheckExchangeConnectivity(ctx, req.(*CheckExchangeConnectivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_SetServerPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetServerPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).SetServerPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/SetServerPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).SetServerPaused(ctx, req.(*SetServerPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_RegisterCompletionWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterCompletionWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).RegisterCompletionWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/RegisterCompletionWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).RegisterCompletionWebhook(ctx, req.(*RegisterCompletionWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetStrategyLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStrategyLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetStrategyLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetStrategyLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetStrategyLogs(ctx, req.(*GetStrategyLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_CanonicalizeConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanonicalizeConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).CanonicalizeConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/CanonicalizeConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).CanonicalizeConfig(ctx, req.(*CanonicalizeConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ExportRegistry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRegistryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExportRegistry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExportRegistry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExportRegistry(ctx, req.(*ExportRegistryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ImportRegistry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRegistryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ImportRegistry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ImportRegistry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ImportRegistry(ctx, req.(*ImportRegistryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_PreviewStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).PreviewStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/PreviewStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).PreviewStrategy(ctx, req.(*PreviewStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_UpdateServerConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServerConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).UpdateServerConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/UpdateServerConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).UpdateServerConfig(ctx, req.(*UpdateServerConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetDataAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetDataAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetDataAvailability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetDataAvailability(ctx, req.(*GetDataAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_AnalyzeDateRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeDateRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).AnalyzeDateRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/AnalyzeDateRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).AnalyzeDateRange(ctx, req.(*AnalyzeDateRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_RestartWithParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartWithParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).RestartWithParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/RestartWithParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).RestartWithParams(ctx, req.(*RestartWithParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_StreamStrategyProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStrategyProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamStrategyProgress(m, &backtesterServiceStreamStrategyProgressServer{stream})
+}
+
+type BacktesterService_StreamStrategyProgressServer interface {
+	Send(*GetRunProgressResponse) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamStrategyProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamStrategyProgressServer) Send(m *GetRunProgressResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_PreviewPositionSizing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewPositionSizingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).PreviewPositionSizing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/PreviewPositionSizing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).PreviewPositionSizing(ctx, req.(*PreviewPositionSizingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_StreamRunResourceUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRunResourceUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamRunResourceUsage(m, &backtesterServiceStreamRunResourceUsageServer{stream})
+}
+
+type BacktesterService_StreamRunResourceUsageServer interface {
+	Send(*ResourceUsageSample) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamRunResourceUsageServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamRunResourceUsageServer) Send(m *ResourceUsageSample) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_GetStrategyTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStrategyTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetStrategyTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetStrategyTrades",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetStrategyTrades(ctx, req.(*GetStrategyTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_SaveConfigAsTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveConfigAsTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).SaveConfigAsTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/SaveConfigAsTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).SaveConfigAsTemplate(ctx, req.(*SaveConfigAsTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ExecuteStrategyFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStrategyFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExecuteStrategyFromTemplate(ctx, req.(*ExecuteStrategyFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ExecuteWalkForward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteWalkForwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExecuteWalkForward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExecuteWalkForward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExecuteWalkForward(ctx, req.(*ExecuteWalkForwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetStrategyParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStrategyParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetStrategyParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetStrategyParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetStrategyParameters(ctx, req.(*GetStrategyParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_RunMonteCarlo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunMonteCarloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).RunMonteCarlo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/RunMonteCarlo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).RunMonteCarlo(ctx, req.(*RunMonteCarloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_StreamSweepLeaderboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSweepLeaderboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamSweepLeaderboard(m, &backtesterServiceStreamSweepLeaderboardServer{stream})
+}
+
+type BacktesterService_StreamSweepLeaderboardServer interface {
+	Send(*SweepLeaderboardUpdate) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamSweepLeaderboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamSweepLeaderboardServer) Send(m *SweepLeaderboardUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_BenchmarkAgainstHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BenchmarkAgainstHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).BenchmarkAgainstHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/BenchmarkAgainstHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).BenchmarkAgainstHold(ctx, req.(*BenchmarkAgainstHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_EstimateDataDownload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateDataDownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).EstimateDataDownload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/EstimateDataDownload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).EstimateDataDownload(ctx, req.(*EstimateDataDownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_VerifyDeterminism_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDeterminismRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).VerifyDeterminism(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/VerifyDeterminism",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).VerifyDeterminism(ctx, req.(*VerifyDeterminismRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_PauseStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).PauseStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/PauseStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).PauseStrategy(ctx, req.(*PauseStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ResumeStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ResumeStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ResumeStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ResumeStrategy(ctx, req.(*ResumeStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_StreamDrawdownPeriods_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDrawdownPeriodsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamDrawdownPeriods(m, &backtesterServiceStreamDrawdownPeriodsServer{stream})
+}
+
+type BacktesterService_StreamDrawdownPeriodsServer interface {
+	Send(*DrawdownPeriod) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamDrawdownPeriodsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamDrawdownPeriodsServer) Send(m *DrawdownPeriod) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_ExecuteStrategyAcrossPairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteStrategyAcrossPairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ExecuteStrategyAcrossPairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyAcrossPairs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ExecuteStrategyAcrossPairs(ctx, req.(*ExecuteStrategyAcrossPairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedBacktesterServiceServer) ExecuteStrategyFromFile(context.Context, *ExecuteStrategyFromFileRequest) (*ExecuteStrategyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyFromFile not implemented")
+func _BacktesterService_DescribeStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).DescribeStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/DescribeStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).DescribeStrategy(ctx, req.(*DescribeStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBacktesterServiceServer) ExecuteStrategyFromConfig(context.Context, *ExecuteStrategyFromConfigRequest) (*ExecuteStrategyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecuteStrategyFromConfig not implemented")
+
+func _BacktesterService_GetRollingMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRollingMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetRollingMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetRollingMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetRollingMetrics(ctx, req.(*GetRollingMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBacktesterServiceServer) mustEmbedUnimplementedBacktesterServiceServer() {}
 
-// UnsafeBacktesterServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BacktesterServiceServer will
-// result in compilation errors.
-type UnsafeBacktesterServiceServer interface {
-	mustEmbedUnimplementedBacktesterServiceServer()
+func _BacktesterService_SetBaselineRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBaselineRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).SetBaselineRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/SetBaselineRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).SetBaselineRun(ctx, req.(*SetBaselineRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterBacktesterServiceServer(s grpc.ServiceRegistrar, srv BacktesterServiceServer) {
-	s.RegisterService(&BacktesterService_ServiceDesc, srv)
+func _BacktesterService_CompareRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).CompareRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/CompareRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).CompareRuns(ctx, req.(*CompareRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BacktesterService_ExecuteStrategyFromFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExecuteStrategyFromFileRequest)
+func _BacktesterService_StreamRunCandles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRunCandlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamRunCandles(m, &backtesterServiceStreamRunCandlesServer{stream})
+}
+
+type BacktesterService_StreamRunCandlesServer interface {
+	Send(*RunCandle) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamRunCandlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamRunCandlesServer) Send(m *RunCandle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BacktesterService_ComputeRunCorrelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComputeRunCorrelationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BacktesterServiceServer).ExecuteStrategyFromFile(ctx, in)
+		return srv.(BacktesterServiceServer).ComputeRunCorrelation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromFile",
+		FullMethod: "/btrpc.BacktesterService/ComputeRunCorrelation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BacktesterServiceServer).ExecuteStrategyFromFile(ctx, req.(*ExecuteStrategyFromFileRequest))
+		return srv.(BacktesterServiceServer).ComputeRunCorrelation(ctx, req.(*ComputeRunCorrelationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BacktesterService_ExecuteStrategyFromConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExecuteStrategyFromConfigRequest)
+func _BacktesterService_GetRunFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunFeeScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BacktesterServiceServer).ExecuteStrategyFromConfig(ctx, in)
+		return srv.(BacktesterServiceServer).GetRunFeeSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromConfig",
+		FullMethod: "/btrpc.BacktesterService/GetRunFeeSchedule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BacktesterServiceServer).ExecuteStrategyFromConfig(ctx, req.(*ExecuteStrategyFromConfigRequest))
+		return srv.(BacktesterServiceServer).GetRunFeeSchedule(ctx, req.(*GetRunFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ScheduleStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ScheduleStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ScheduleStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ScheduleStrategy(ctx, req.(*ScheduleStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_CancelStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).CancelStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/CancelStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).CancelStrategy(ctx, req.(*CancelStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_RecordSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).RecordSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/RecordSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).RecordSession(ctx, req.(*RecordSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_ReplaySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaySessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).ReplaySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/ReplaySession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).ReplaySession(ctx, req.(*ReplaySessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BacktesterService_GetRunProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BacktesterServiceServer).GetRunProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btrpc.BacktesterService/GetRunProvenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BacktesterServiceServer).GetRunProvenance(ctx, req.(*GetRunProvenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -135,7 +1947,217 @@ var BacktesterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteStrategyFromConfig",
 			Handler:    _BacktesterService_ExecuteStrategyFromConfig_Handler,
 		},
+		{
+			MethodName: "ListRuns",
+			Handler:    _BacktesterService_ListRuns_Handler,
+		},
+		{
+			MethodName: "GetRunProgress",
+			Handler:    _BacktesterService_GetRunProgress_Handler,
+		},
+		{
+			MethodName: "ExportResults",
+			Handler:    _BacktesterService_ExportResults_Handler,
+		},
+		{
+			MethodName: "GetDefaultConfig",
+			Handler:    _BacktesterService_GetDefaultConfig_Handler,
+		},
+		{
+			MethodName: "GetRecentRuns",
+			Handler:    _BacktesterService_GetRecentRuns_Handler,
+		},
+		{
+			MethodName: "CheckExchangeConnectivity",
+			Handler:    _BacktesterService_CheckExchangeConnectivity_Handler,
+		},
+		{
+			MethodName: "SetServerPaused",
+			Handler:    _BacktesterService_SetServerPaused_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _BacktesterService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "RegisterCompletionWebhook",
+			Handler:    _BacktesterService_RegisterCompletionWebhook_Handler,
+		},
+		{
+			MethodName: "GetStrategyLogs",
+			Handler:    _BacktesterService_GetStrategyLogs_Handler,
+		},
+		{
+			MethodName: "CanonicalizeConfig",
+			Handler:    _BacktesterService_CanonicalizeConfig_Handler,
+		},
+		{
+			MethodName: "ExportRegistry",
+			Handler:    _BacktesterService_ExportRegistry_Handler,
+		},
+		{
+			MethodName: "ImportRegistry",
+			Handler:    _BacktesterService_ImportRegistry_Handler,
+		},
+		{
+			MethodName: "PreviewStrategy",
+			Handler:    _BacktesterService_PreviewStrategy_Handler,
+		},
+		{
+			MethodName: "UpdateServerConfig",
+			Handler:    _BacktesterService_UpdateServerConfig_Handler,
+		},
+		{
+			MethodName: "GetDataAvailability",
+			Handler:    _BacktesterService_GetDataAvailability_Handler,
+		},
+		{
+			MethodName: "AnalyzeDateRange",
+			Handler:    _BacktesterService_AnalyzeDateRange_Handler,
+		},
+		{
+			MethodName: "RestartWithParams",
+			Handler:    _BacktesterService_RestartWithParams_Handler,
+		},
+		{
+			MethodName: "PreviewPositionSizing",
+			Handler:    _BacktesterService_PreviewPositionSizing_Handler,
+		},
+		{
+			MethodName: "GetStrategyTrades",
+			Handler:    _BacktesterService_GetStrategyTrades_Handler,
+		},
+		{
+			MethodName: "SaveConfigAsTemplate",
+			Handler:    _BacktesterService_SaveConfigAsTemplate_Handler,
+		},
+		{
+			MethodName: "ExecuteStrategyFromTemplate",
+			Handler:    _BacktesterService_ExecuteStrategyFromTemplate_Handler,
+		},
+		{
+			MethodName: "ExecuteWalkForward",
+			Handler:    _BacktesterService_ExecuteWalkForward_Handler,
+		},
+		{
+			MethodName: "GetStrategyParameters",
+			Handler:    _BacktesterService_GetStrategyParameters_Handler,
+		},
+		{
+			MethodName: "RunMonteCarlo",
+			Handler:    _BacktesterService_RunMonteCarlo_Handler,
+		},
+		{
+			MethodName: "BenchmarkAgainstHold",
+			Handler:    _BacktesterService_BenchmarkAgainstHold_Handler,
+		},
+		{
+			MethodName: "EstimateDataDownload",
+			Handler:    _BacktesterService_EstimateDataDownload_Handler,
+		},
+		{
+			MethodName: "VerifyDeterminism",
+			Handler:    _BacktesterService_VerifyDeterminism_Handler,
+		},
+		{
+			MethodName: "PauseStrategy",
+			Handler:    _BacktesterService_PauseStrategy_Handler,
+		},
+		{
+			MethodName: "ResumeStrategy",
+			Handler:    _BacktesterService_ResumeStrategy_Handler,
+		},
+		{
+			MethodName: "ExecuteStrategyAcrossPairs",
+			Handler:    _BacktesterService_ExecuteStrategyAcrossPairs_Handler,
+		},
+		{
+			MethodName: "DescribeStrategy",
+			Handler:    _BacktesterService_DescribeStrategy_Handler,
+		},
+		{
+			MethodName: "GetRollingMetrics",
+			Handler:    _BacktesterService_GetRollingMetrics_Handler,
+		},
+		{
+			MethodName: "SetBaselineRun",
+			Handler:    _BacktesterService_SetBaselineRun_Handler,
+		},
+		{
+			MethodName: "CompareRuns",
+			Handler:    _BacktesterService_CompareRuns_Handler,
+		},
+		{
+			MethodName: "ComputeRunCorrelation",
+			Handler:    _BacktesterService_ComputeRunCorrelation_Handler,
+		},
+		{
+			MethodName: "GetRunFeeSchedule",
+			Handler:    _BacktesterService_GetRunFeeSchedule_Handler,
+		},
+		{
+			MethodName: "ScheduleStrategy",
+			Handler:    _BacktesterService_ScheduleStrategy_Handler,
+		},
+		{
+			MethodName: "CancelStrategy",
+			Handler:    _BacktesterService_CancelStrategy_Handler,
+		},
+		{
+			MethodName: "RecordSession",
+			Handler:    _BacktesterService_RecordSession_Handler,
+		},
+		{
+			MethodName: "ReplaySession",
+			Handler:    _BacktesterService_ReplaySession_Handler,
+		},
+		{
+			MethodName: "GetRunProvenance",
+			Handler:    _BacktesterService_GetRunProvenance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEquityCurve",
+			Handler:       _BacktesterService_StreamEquityCurve_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeRunEvents",
+			Handler:       _BacktesterService_SubscribeRunEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "InteractiveStrategy",
+			Handler:       _BacktesterService_InteractiveStrategy_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamStrategyProgress",
+			Handler:       _BacktesterService_StreamStrategyProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRunResourceUsage",
+			Handler:       _BacktesterService_StreamRunResourceUsage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSweepLeaderboard",
+			Handler:       _BacktesterService_StreamSweepLeaderboard_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamDrawdownPeriods",
+			Handler:       _BacktesterService_StreamDrawdownPeriods_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRunCandles",
+			Handler:       _BacktesterService_StreamRunCandles_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "btrpc.proto",
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/shopspring/decimal"
@@ -12,10 +13,73 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
+// GetExampleConfig returns the contents of the example config for a strategy
+// so it can be used as a template for a new config
+func GetExampleConfig(strategyName string) ([]byte, error) {
+	fileName, ok := exampleConfigFiles[strings.ToLower(strategyName)]
+	if !ok {
+		return nil, fmt.Errorf("%w '%v'", ErrExampleConfigNotFound, strategyName)
+	}
+	return strategyExamples.ReadFile("strategyexamples/" + fileName)
+}
+
+// Canonicalize parses config data and re-serialises it in a stable form so
+// that equivalent configs produce identical output regardless of field order,
+// formatting, casing or the order of currency and funding settings
+func Canonicalize(data []byte) ([]byte, error) {
+	var cfg Config
+	err := json.Unmarshal(data, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", ErrInvalidConfigData, err)
+	}
+	cfg.canonicalize()
+	return json.MarshalIndent(&cfg, "", " ")
+}
+
+// canonicalize normalises names and currencies and sorts all settings lists
+func (c *Config) canonicalize() {
+	c.StrategySettings.Name = strings.ToLower(c.StrategySettings.Name)
+	for i := range c.CurrencySettings {
+		c.CurrencySettings[i].ExchangeName = strings.ToLower(c.CurrencySettings[i].ExchangeName)
+		pair := currency.NewPair(c.CurrencySettings[i].Base, c.CurrencySettings[i].Quote).NormalizeUnicode().Upper()
+		c.CurrencySettings[i].Base = pair.Base
+		c.CurrencySettings[i].Quote = pair.Quote
+	}
+	sort.SliceStable(c.CurrencySettings, func(i, j int) bool {
+		a, b := &c.CurrencySettings[i], &c.CurrencySettings[j]
+		if a.ExchangeName != b.ExchangeName {
+			return a.ExchangeName < b.ExchangeName
+		}
+		if a.Asset != b.Asset {
+			return a.Asset.String() < b.Asset.String()
+		}
+		if !a.Base.Equal(b.Base) {
+			return a.Base.String() < b.Base.String()
+		}
+		return a.Quote.String() < b.Quote.String()
+	})
+	for i := range c.FundingSettings.ExchangeLevelFunding {
+		f := &c.FundingSettings.ExchangeLevelFunding[i]
+		f.ExchangeName = strings.ToLower(f.ExchangeName)
+		f.Currency = f.Currency.NormalizeUnicode().Upper()
+	}
+	sort.SliceStable(c.FundingSettings.ExchangeLevelFunding, func(i, j int) bool {
+		a, b := &c.FundingSettings.ExchangeLevelFunding[i], &c.FundingSettings.ExchangeLevelFunding[j]
+		if a.ExchangeName != b.ExchangeName {
+			return a.ExchangeName < b.ExchangeName
+		}
+		if a.Asset != b.Asset {
+			return a.Asset.String() < b.Asset.String()
+		}
+		return a.Currency.String() < b.Currency.String()
+	})
+}
+
 // ReadStrategyConfigFromFile will take a config from a path
 func ReadStrategyConfigFromFile(path string) (*Config, error) {
 	if !file.Exists(path) {
@@ -54,6 +54,81 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestGetExampleConfig(t *testing.T) {
+	t.Parallel()
+	_, err := GetExampleConfig("definitely-not-a-strategy")
+	if !errors.Is(err, ErrExampleConfigNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, ErrExampleConfigNotFound)
+	}
+	for name := range exampleConfigFiles {
+		data, err := GetExampleConfig(name)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		var cfg Config
+		err = json.Unmarshal(data, &cfg)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		if cfg.StrategySettings.Name != name {
+			t.Errorf("received '%v' expecting '%v'", cfg.StrategySettings.Name, name)
+		}
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+	_, err := Canonicalize([]byte("{"))
+	if !errors.Is(err, ErrInvalidConfigData) {
+		t.Errorf("received '%v' expecting '%v'", err, ErrInvalidConfigData)
+	}
+
+	a := []byte(`{
+ "nickname": "canon",
+ "strategy-settings": {"name": "RSI", "custom-settings": {"rsi-period": 14, "rsi-high": 70}},
+ "funding-settings": {"use-exchange-level-funding": true, "exchange-level-funding": [
+  {"exchange-name": "Binance", "asset": "spot", "currency": "usdt", "initial-funds": "1000.0"},
+  {"exchange-name": "binance", "asset": "spot", "currency": "BTC", "initial-funds": "1"}
+ ]},
+ "currency-settings": [
+  {"exchange-name": "Binance", "asset": "spot", "base": "eth", "quote": "usdt"},
+  {"exchange-name": "binance", "asset": "spot", "base": "BTC", "quote": "USDT"}
+ ]
+}`)
+	b := []byte(`{"currency-settings":[{"quote":"USDT","base":"BTC","asset":"spot","exchange-name":"BINANCE"},{"quote":"USDT","base":"ETH","asset":"spot","exchange-name":"binance"}],` +
+		`"funding-settings":{"exchange-level-funding":[{"currency":"BTC","asset":"spot","exchange-name":"binance","initial-funds":"1.00"},{"currency":"USDT","asset":"spot","exchange-name":"binance","initial-funds":"1000"}],"use-exchange-level-funding":true},` +
+		`"strategy-settings":{"custom-settings":{"rsi-high":70,"rsi-period":14},"name":"rsi"},"nickname":"canon"}`)
+
+	canonA, err := Canonicalize(a)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	canonB, err := Canonicalize(b)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if string(canonA) != string(canonB) {
+		t.Errorf("received '%s' expecting '%s'", canonB, canonA)
+	}
+	// canonical output is stable when canonicalized again
+	again, err := Canonicalize(canonA)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if string(again) != string(canonA) {
+		t.Errorf("received '%s' expecting '%s'", again, canonA)
+	}
+
+	var cfg Config
+	err = json.Unmarshal(canonA, &cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if cfg.CurrencySettings[0].Base.String() != "BTC" || cfg.CurrencySettings[1].Base.String() != "ETH" {
+		t.Errorf("received '%v' expecting '%v'", cfg.CurrencySettings, "BTC then ETH")
+	}
+}
+
 func TestValidateDate(t *testing.T) {
 	t.Parallel()
 	c := Config{}
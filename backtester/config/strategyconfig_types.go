@@ -1,10 +1,15 @@
 package config
 
 import (
+	"embed"
 	"errors"
 	"time"
 
 	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/dollarcostaverage"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/ftxcashandcarry"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/rsi"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/top2bottom2"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -25,6 +30,24 @@ var (
 	errMinMaxEqual                      = errors.New("minimum and maximum limits cannot be equal")
 	errPerpetualsUnsupported            = errors.New("perpetual futures not yet supported")
 	errFeatureIncompatible              = errors.New("feature is not compatible")
+
+	// ErrExampleConfigNotFound is returned when there is no example config
+	// for a strategy
+	ErrExampleConfigNotFound = errors.New("example config not found for strategy")
+	// ErrInvalidConfigData is returned when config data cannot be parsed
+	ErrInvalidConfigData = errors.New("invalid config data")
+
+	//go:embed strategyexamples/*.strat
+	strategyExamples embed.FS
+
+	// exampleConfigFiles maps strategy names to the example config which best
+	// serves as a starting point for a new config
+	exampleConfigFiles = map[string]string{
+		dollarcostaverage.Name: "dca-api-candles.strat",
+		rsi.Name:               "rsi-api-candles.strat",
+		top2bottom2.Name:       "t2b2-api-candles-exchange-funding.strat",
+		ftxcashandcarry.Name:   "ftx-cash-carry.strat",
+	}
 )
 
 // Config defines what is in an individual strategy config
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
@@ -50,9 +51,17 @@ func (bt *BackTest) Reset() {
 // save them and then handle the event based on its type
 func (bt *BackTest) Run() {
 	log.Info(common.Backtester, "Running backtester against pre-defined data")
+	var processed int64
+	total := bt.countDataEvents()
 dataLoadingIssue:
 	for ev := bt.EventQueue.NextEvent(); ; ev = bt.EventQueue.NextEvent() {
+		bt.waitWhilePaused()
 		if ev == nil {
+			if bt.isCancelled() {
+				log.Info(common.Backtester, "Backtest cancelled")
+				break
+			}
+			bt.applySettingsUpdates()
 			dataHandlerMap := bt.Datas.GetAllData()
 			var hasProcessedData bool
 			for exchangeName, exchangeMap := range dataHandlerMap {
@@ -65,6 +74,9 @@ dataLoadingIssue:
 							}
 							break dataLoadingIssue
 						}
+						processed++
+						bt.reportCandle(d)
+						bt.reportProgress(processed, total)
 						if bt.Strategy.UsingSimultaneousProcessing() && hasProcessedData {
 							// only append one event, as simultaneous processing
 							// will retrieve all relevant events to process under
@@ -81,6 +93,9 @@ dataLoadingIssue:
 			if err != nil {
 				log.Error(common.Backtester, err)
 			}
+			if !bt.reportEvent(ev) {
+				break dataLoadingIssue
+			}
 		}
 		if !bt.hasHandledEvent {
 			bt.hasHandledEvent = true
@@ -88,6 +103,106 @@ dataLoadingIssue:
 	}
 }
 
+// countDataEvents returns the total amount of data events loaded
+func (bt *BackTest) countDataEvents() int64 {
+	var total int64
+	for _, exchangeMap := range bt.Datas.GetAllData() {
+		for _, assetMap := range exchangeMap {
+			for _, dataHandler := range assetMap {
+				total += int64(len(dataHandler.GetStream()))
+			}
+		}
+	}
+	return total
+}
+
+// reportProgress informs any run hooks of the backtest's progression
+func (bt *BackTest) reportProgress(processed, total int64) {
+	if bt.hooks == nil || bt.hooks.progress == nil {
+		return
+	}
+	bt.hooks.progress(processed, total)
+}
+
+// reportCandle informs any run hooks of a data event loaded for processing
+func (bt *BackTest) reportCandle(ev common.DataEventHandler) {
+	if bt.hooks == nil || bt.hooks.candle == nil {
+		return
+	}
+	bt.hooks.candle(ev)
+}
+
+// reportEvent informs any run hooks of a handled event, returning whether the
+// backtest should continue
+func (bt *BackTest) reportEvent(ev common.EventHandler) bool {
+	if bt.hooks == nil || bt.hooks.event == nil {
+		return true
+	}
+	return bt.hooks.event(ev)
+}
+
+// isCancelled returns whether run hooks have requested the backtest stops
+func (bt *BackTest) isCancelled() bool {
+	if bt.hooks == nil || bt.hooks.cancel == nil {
+		return false
+	}
+	select {
+	case <-bt.hooks.cancel:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitWhilePaused blocks while run hooks have paused the backtest
+func (bt *BackTest) waitWhilePaused() {
+	if bt.hooks == nil || bt.hooks.gate == nil {
+		return
+	}
+	bt.hooks.gate.wait()
+}
+
+// applySettingsUpdates applies any pending custom strategy settings received
+// via run hooks
+func (bt *BackTest) applySettingsUpdates() {
+	if bt.hooks == nil || bt.hooks.settings == nil {
+		return
+	}
+	for {
+		select {
+		case u := <-bt.hooks.settings:
+			u.result <- bt.updateStrategySettings(u.settings)
+		default:
+			return
+		}
+	}
+}
+
+// updateStrategySettings sets custom settings on the strategy if it supports
+// being updated while running
+func (bt *BackTest) updateStrategySettings(settings map[string]interface{}) error {
+	updater, ok := bt.Strategy.(strategies.LiveSettingsUpdater)
+	if !ok || !updater.SupportsLiveSettingsUpdates() {
+		return fmt.Errorf("%v %w", bt.Strategy.Name(), errLiveUpdatesUnsupported)
+	}
+	return bt.Strategy.SetCustomSettings(settings)
+}
+
+// reportEquity informs any run hooks of the latest total value of the holdings
+// for the data event's exchange, asset and pair
+func (bt *BackTest) reportEquity(ev common.DataEventHandler) {
+	if bt.hooks == nil || bt.hooks.equity == nil {
+		return
+	}
+	h, err := bt.Portfolio.ViewHoldingAtTimePeriod(ev)
+	if err != nil {
+		log.Errorf(common.Backtester, "ViewHoldingAtTimePeriod %v", err)
+		return
+	}
+	key := fmt.Sprintf("%v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair())
+	bt.hooks.equity(key, ev.GetTime(), h.TotalValue)
+}
+
 // handleEvent is the main processor of data for the backtester
 // after data has been loaded and Run has appended a data event to the queue,
 // handle event will process events and add further events to the queue if they
@@ -231,6 +346,8 @@ func (bt *BackTest) updateStatsForDataEvent(ev common.DataEventHandler, funds fu
 	err = bt.Portfolio.UpdateHoldings(ev, funds)
 	if err != nil {
 		log.Errorf(common.Backtester, "UpdateHoldings %v", err)
+	} else {
+		bt.reportEquity(ev)
 	}
 
 	if ev.GetAssetType().IsFutures() {
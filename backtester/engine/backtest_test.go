@@ -16,7 +16,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/size"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/dollarcostaverage"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/rsi"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
@@ -175,7 +177,23 @@ func TestFullCycle(t *testing.T) {
 	}
 	bt.Datas.SetDataForCurrency(ex, a, cp, &k)
 
+	var processed, total int64
+	var equityUpdates int
+	bt.hooks = &runHooks{
+		progress: func(p, t int64) {
+			processed, total = p, t
+		},
+		equity: func(string, time.Time, decimal.Decimal) {
+			equityUpdates++
+		},
+	}
 	bt.Run()
+	if processed != 1 || total != 1 {
+		t.Errorf("received '%v/%v' expecting '%v/%v'", processed, total, 1, 1)
+	}
+	if equityUpdates != 1 {
+		t.Errorf("received '%v' expecting '%v'", equityUpdates, 1)
+	}
 }
 
 func TestStop(t *testing.T) {
@@ -965,3 +983,108 @@ func TestProcessFuturesFillEvent(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, expectedError)
 	}
 }
+
+func TestApplySettingsUpdates(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{Strategy: &dollarcostaverage.Strategy{}}
+	bt.applySettingsUpdates()
+
+	bt.hooks = &runHooks{settings: make(chan *settingsUpdate, 1)}
+	u := &settingsUpdate{settings: map[string]interface{}{"rsi-high": 70.0}, result: make(chan error, 1)}
+	bt.hooks.settings <- u
+	bt.applySettingsUpdates()
+	if err := <-u.result; !errors.Is(err, errLiveUpdatesUnsupported) {
+		t.Errorf("received '%v' expected '%v'", err, errLiveUpdatesUnsupported)
+	}
+
+	bt.Strategy = &rsi.Strategy{}
+	bt.hooks.settings <- u
+	bt.applySettingsUpdates()
+	if err := <-u.result; !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+
+	u.settings = map[string]interface{}{"rsi-high": "seventy"}
+	bt.hooks.settings <- u
+	bt.applySettingsUpdates()
+	if err := <-u.result; !errors.Is(err, base.ErrInvalidCustomSettings) {
+		t.Errorf("received '%v' expected '%v'", err, base.ErrInvalidCustomSettings)
+	}
+}
+
+func TestWaitWhilePaused(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	bt.waitWhilePaused()
+
+	bt.hooks = &runHooks{gate: newRunGate()}
+	bt.hooks.gate.setPaused(true)
+	released := make(chan struct{})
+	go func() {
+		bt.waitWhilePaused()
+		close(released)
+	}()
+	select {
+	case <-released:
+		t.Fatal("expected backtest to wait while paused")
+	case <-time.After(time.Millisecond * 20):
+	}
+	bt.hooks.gate.setPaused(false)
+	select {
+	case <-released:
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected backtest to continue once resumed")
+	}
+}
+
+func TestReportEvent(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	ev := &evkline.Kline{Base: &event.Base{}}
+	if !bt.reportEvent(ev) {
+		t.Error("expected backtest to continue without hooks")
+	}
+	var received common.EventHandler
+	bt.hooks = &runHooks{event: func(e common.EventHandler) bool {
+		received = e
+		return false
+	}}
+	if bt.reportEvent(ev) {
+		t.Error("expected backtest to stop")
+	}
+	if received != ev {
+		t.Errorf("received '%v' expected '%v'", received, ev)
+	}
+}
+
+func TestReportCandle(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	ev := &evkline.Kline{Base: &event.Base{}}
+	bt.reportCandle(ev)
+	var received common.DataEventHandler
+	bt.hooks = &runHooks{candle: func(e common.DataEventHandler) {
+		received = e
+	}}
+	bt.reportCandle(ev)
+	if received != ev {
+		t.Errorf("received '%v' expected '%v'", received, ev)
+	}
+}
+
+func TestIsCancelled(t *testing.T) {
+	t.Parallel()
+	bt := &BackTest{}
+	if bt.isCancelled() {
+		t.Error("expected backtest without hooks not to be cancelled")
+	}
+	cancel := make(chan struct{})
+	bt.hooks = &runHooks{cancel: cancel}
+	if bt.isCancelled() {
+		t.Error("expected backtest not to be cancelled")
+	}
+	close(cancel)
+	if !bt.isCancelled() {
+		t.Error("expected backtest to be cancelled")
+	}
+}
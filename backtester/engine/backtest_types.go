@@ -2,7 +2,10 @@ package engine
 
 import (
 	"errors"
+	"time"
 
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
@@ -24,6 +27,7 @@ var (
 	errNilData                     = errors.New("nil data received")
 	errNilExchange                 = errors.New("nil exchange received")
 	errLiveUSDTrackingNotSupported = errors.New("USD tracking not supported for live data")
+	errLiveUpdatesUnsupported      = errors.New("strategy does not support live settings updates")
 )
 
 // BackTest is the main holder of all backtesting functionality
@@ -41,4 +45,37 @@ type BackTest struct {
 	exchangeManager *engine.ExchangeManager
 	orderManager    *engine.OrderManager
 	databaseManager *engine.DatabaseConnectionManager
+	hooks           *runHooks
+}
+
+// runHooks allows the caller of a backtest to be informed of its
+// progression while it runs
+type runHooks struct {
+	// progress is called every time a data event is processed
+	progress func(processed, total int64)
+	// equity is called every time the holdings of an exchange, asset and
+	// pair are updated with the latest price
+	equity func(key string, t time.Time, value decimal.Decimal)
+	// settings receives custom strategy settings to apply while the
+	// backtest is running
+	settings chan *settingsUpdate
+	// event is called every time an event is handled, returning false stops
+	// the backtest
+	event func(ev common.EventHandler) bool
+	// preview skips results calculation and report generation once the
+	// backtest has stopped
+	preview bool
+	// cancel is closed to stop the backtest early
+	cancel <-chan struct{}
+	// gate blocks the backtest between events while it is paused
+	gate *runGate
+	// candle is called with every data event loaded from the data handlers
+	candle func(ev common.DataEventHandler)
+}
+
+// settingsUpdate holds custom strategy settings to apply to a running
+// backtest and receives the result of applying them
+type settingsUpdate struct {
+	settings map[string]interface{}
+	result   chan error
 }
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+)
+
+// summariseRun calculates the summary metrics of a run from its equity curve
+// and recorded trades
+func summariseRun(run *Run) runMetrics {
+	m := runMetrics{
+		returnPercent:      equityReturnPercent(run.EquityCurve),
+		maxDrawdownPercent: maxDrawdownPercent(run.EquityCurve),
+		trades:             int64(len(run.Trades)),
+	}
+	if len(run.EquityCurve) > 0 {
+		m.finalEquity = run.EquityCurve[len(run.EquityCurve)-1].Equity
+	}
+	for i := range run.Trades {
+		m.volume += run.Trades[i].Price * run.Trades[i].Amount
+		m.fees += run.Trades[i].Fee
+	}
+	return m
+}
+
+// difference returns each metric of m minus the same metric of other
+func (m *runMetrics) difference(other *runMetrics) runMetrics {
+	return runMetrics{
+		finalEquity:        m.finalEquity - other.finalEquity,
+		returnPercent:      m.returnPercent - other.returnPercent,
+		maxDrawdownPercent: m.maxDrawdownPercent - other.maxDrawdownPercent,
+		trades:             m.trades - other.trades,
+		volume:             m.volume - other.volume,
+		fees:               m.fees - other.fees,
+	}
+}
+
+// toRPC converts the run metrics to their GRPC representation
+func (m *runMetrics) toRPC() *btrpc.RunComparisonMetrics {
+	return &btrpc.RunComparisonMetrics{
+		FinalEquity:        m.finalEquity,
+		ReturnPercent:      m.returnPercent,
+		MaxDrawdownPercent: m.maxDrawdownPercent,
+		Trades:             m.trades,
+		Volume:             m.volume,
+		Fees:               m.fees,
+	}
+}
+
+// equityCorrelation returns the pearson correlation of the returns of two
+// equity curves along with the number of points used. Curves are aligned by
+// intersecting their timestamps, returns are the fractional change between
+// consecutive aligned points
+func equityCorrelation(a, b []EquityPoint) (correlation float64, aligned int, err error) {
+	equityByTime := make(map[int64]float64, len(b))
+	for i := range b {
+		equityByTime[b[i].Time.UnixNano()] = b[i].Equity
+	}
+	var x, y []float64
+	for i := range a {
+		equity, ok := equityByTime[a[i].Time.UnixNano()]
+		if !ok {
+			continue
+		}
+		x = append(x, a[i].Equity)
+		y = append(y, equity)
+	}
+	aligned = len(x)
+	if aligned < 3 {
+		return 0, aligned, errTooFewAlignedPoints
+	}
+	xReturns := make([]float64, 0, aligned-1)
+	yReturns := make([]float64, 0, aligned-1)
+	for i := 1; i < aligned; i++ {
+		if x[i-1] == 0 || y[i-1] == 0 {
+			continue
+		}
+		xReturns = append(xReturns, (x[i]-x[i-1])/x[i-1])
+		yReturns = append(yReturns, (y[i]-y[i-1])/y[i-1])
+	}
+	if len(xReturns) < 2 {
+		return 0, aligned, errTooFewAlignedPoints
+	}
+	var xMean, yMean float64
+	for i := range xReturns {
+		xMean += xReturns[i]
+		yMean += yReturns[i]
+	}
+	xMean /= float64(len(xReturns))
+	yMean /= float64(len(yReturns))
+	var covariance, xVariance, yVariance float64
+	for i := range xReturns {
+		dx, dy := xReturns[i]-xMean, yReturns[i]-yMean
+		covariance += dx * dy
+		xVariance += dx * dx
+		yVariance += dy * dy
+	}
+	if xVariance == 0 || yVariance == 0 {
+		return 0, aligned, errNoVariance
+	}
+	return covariance / math.Sqrt(xVariance*yVariance), aligned, nil
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEquityCorrelation(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	curve := func(offset time.Duration, equity ...float64) []EquityPoint {
+		points := make([]EquityPoint, len(equity))
+		for i := range equity {
+			points[i] = EquityPoint{Time: start.Add(offset + time.Duration(i)*time.Hour), Equity: equity[i]}
+		}
+		return points
+	}
+	a := curve(0, 100, 110, 99, 120, 126)
+	correlation, aligned, err := equityCorrelation(a, curve(0, 200, 220, 198, 240, 252))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if math.Abs(correlation-1) > 1e-9 || aligned != 5 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", correlation, aligned, 1, 5)
+	}
+
+	correlation, _, err = equityCorrelation(a, curve(0, 100, 90, 99, 80, 76))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if correlation >= 0 {
+		t.Errorf("received '%v' expecting a negative correlation", correlation)
+	}
+
+	// only the final three hours of a are matched
+	correlation, aligned, err = equityCorrelation(a, curve(2*time.Hour, 50, 60.60606060606061, 63.63636363636364, 1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if math.Abs(correlation-1) > 1e-9 || aligned != 3 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", correlation, aligned, 1, 3)
+	}
+
+	_, _, err = equityCorrelation(a, curve(3*time.Hour, 1, 2))
+	if !errors.Is(err, errTooFewAlignedPoints) {
+		t.Errorf("received '%v' expecting '%v'", err, errTooFewAlignedPoints)
+	}
+	_, _, err = equityCorrelation(a, curve(0, 100, 100, 100, 100, 100))
+	if !errors.Is(err, errNoVariance) {
+		t.Errorf("received '%v' expecting '%v'", err, errNoVariance)
+	}
+}
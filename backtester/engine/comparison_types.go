@@ -0,0 +1,19 @@
+package engine
+
+import "errors"
+
+var (
+	errTooFewAlignedPoints = errors.New("at least three equity points with matching times are required")
+	errNoVariance          = errors.New("equity returns have no variance")
+)
+
+// runMetrics holds the summary metrics of a completed run which are compared
+// against another run
+type runMetrics struct {
+	finalEquity        float64
+	returnPercent      float64
+	maxDrawdownPercent float64
+	trades             int64
+	volume             float64
+	fees               float64
+}
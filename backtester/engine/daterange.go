@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// analyseCandles calculates the return, volatility, drawdown and market regime
+// of candles. Volatility is the standard deviation of the percentage change
+// between closing prices
+func analyseCandles(candles []gctkline.Candle) (*rangeStats, error) {
+	if len(candles) == 0 {
+		return nil, errNoCandles
+	}
+	sorted := make([]gctkline.Candle, len(candles))
+	copy(sorted, candles)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+	first, last := sorted[0], sorted[len(sorted)-1]
+	stats := &rangeStats{
+		candles:    int64(len(sorted)),
+		start:      first.Time,
+		end:        last.Time,
+		startPrice: first.Close,
+		endPrice:   last.Close,
+		regime:     RegimeSideways,
+	}
+	if first.Close != 0 {
+		stats.returnPercent = (last.Close - first.Close) / first.Close * 100
+	}
+
+	var returns []float64
+	peak := first.Close
+	for i := range sorted {
+		if i > 0 && sorted[i-1].Close != 0 {
+			returns = append(returns, (sorted[i].Close-sorted[i-1].Close)/sorted[i-1].Close*100)
+		}
+		if sorted[i].Close > peak {
+			peak = sorted[i].Close
+		}
+		if peak > 0 {
+			if drawdown := (peak - sorted[i].Close) / peak * 100; drawdown > stats.maxDrawdownPercent {
+				stats.maxDrawdownPercent = drawdown
+			}
+		}
+	}
+	if len(returns) > 1 {
+		var mean float64
+		for i := range returns {
+			mean += returns[i]
+		}
+		mean /= float64(len(returns))
+		var variance float64
+		for i := range returns {
+			variance += (returns[i] - mean) * (returns[i] - mean)
+		}
+		stats.volatilityPercent = math.Sqrt(variance / float64(len(returns)-1))
+	}
+
+	switch {
+	case stats.returnPercent > regimeTrendPercent:
+		stats.regime = RegimeBull
+	case stats.returnPercent < -regimeTrendPercent:
+		stats.regime = RegimeBear
+	}
+	stats.containsCrash = stats.maxDrawdownPercent >= crashDrawdownPercent
+	return stats, nil
+}
+
+// estimateDownload estimates the number of candles and bytes required for each
+// currency of the config over its date range, including the final interval
+// when the end date is inclusive
+func estimateDownload(cfg *config.Config) ([]downloadEstimate, error) {
+	start, end, err := configDateRange(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, gctcommon.ErrStartAfterEnd
+	}
+	interval := cfg.DataSettings.Interval
+	if interval <= 0 {
+		return nil, errInvalidInterval
+	}
+	if (cfg.DataSettings.APIData != nil && cfg.DataSettings.APIData.InclusiveEndDate) ||
+		(cfg.DataSettings.APIData == nil && cfg.DataSettings.DatabaseData != nil && cfg.DataSettings.DatabaseData.InclusiveEndDate) {
+		end = end.Add(interval.Duration())
+	}
+	candles := int64(math.Ceil(float64(end.Sub(start)) / float64(interval.Duration())))
+	estimates := make([]downloadEstimate, len(cfg.CurrencySettings))
+	for i := range cfg.CurrencySettings {
+		estimates[i] = downloadEstimate{
+			exchange: cfg.CurrencySettings[i].ExchangeName,
+			asset:    cfg.CurrencySettings[i].Asset,
+			pair:     currency.NewPair(cfg.CurrencySettings[i].Base, cfg.CurrencySettings[i].Quote),
+			interval: interval,
+			candles:  candles,
+			bytes:    candles * estimatedCandleBytes,
+		}
+	}
+	return estimates, nil
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestAnalyseCandles(t *testing.T) {
+	t.Parallel()
+	_, err := analyseCandles(nil)
+	if !errors.Is(err, errNoCandles) {
+		t.Errorf("received '%v' expecting '%v'", err, errNoCandles)
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	// candles are out of order to ensure they are sorted by time
+	candles := []gctkline.Candle{
+		{Time: start.Add(time.Hour * 3), Close: 121},
+		{Time: start, Close: 100},
+		{Time: start.Add(time.Hour * 2), Close: 88},
+		{Time: start.Add(time.Hour), Close: 110},
+	}
+	stats, err := analyseCandles(candles)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if stats.candles != 4 || !stats.start.Equal(start) || !stats.end.Equal(start.Add(time.Hour*3)) {
+		t.Errorf("received '%v' expecting 4 candles over 3 hours", stats)
+	}
+	if stats.startPrice != 100 || stats.endPrice != 121 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", stats.startPrice, stats.endPrice, 100, 121)
+	}
+	for _, tc := range []struct {
+		name     string
+		received float64
+		expected float64
+	}{
+		{"return", stats.returnPercent, 21},
+		{"drawdown", stats.maxDrawdownPercent, 20},
+		// standard deviation of 10%, -20% and 37.5%
+		{"volatility", stats.volatilityPercent, 28.7590},
+	} {
+		if math.Abs(tc.received-tc.expected) > 0.0001 {
+			t.Errorf("%v received '%v' expecting '%v'", tc.name, tc.received, tc.expected)
+		}
+	}
+	if stats.regime != RegimeBull || !stats.containsCrash {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", stats.regime, stats.containsCrash, RegimeBull, true)
+	}
+
+	stats, err = analyseCandles([]gctkline.Candle{
+		{Time: start, Close: 100},
+		{Time: start.Add(time.Hour), Close: 85},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if stats.regime != RegimeBear || stats.containsCrash || stats.volatilityPercent != 0 {
+		t.Errorf("received '%v' expecting a bear regime without a crash", stats)
+	}
+
+	stats, err = analyseCandles([]gctkline.Candle{{Time: start, Close: 100}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if stats.regime != RegimeSideways || stats.returnPercent != 0 {
+		t.Errorf("received '%v' expecting a sideways regime", stats)
+	}
+}
+
+func TestEstimateDownload(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		CurrencySettings: []config.CurrencySettings{
+			{ExchangeName: "binance", Asset: asset.Spot, Base: currency.BTC, Quote: currency.USDT},
+			{ExchangeName: "binance", Asset: asset.Spot, Base: currency.ETH, Quote: currency.USDT},
+		},
+	}
+	_, err := estimateDownload(cfg)
+	if !errors.Is(err, errNoDateRange) {
+		t.Errorf("received '%v' expecting '%v'", err, errNoDateRange)
+	}
+	cfg.DataSettings.APIData = &config.APIData{StartDate: start, EndDate: start.Add(time.Hour * 24)}
+	_, err = estimateDownload(cfg)
+	if !errors.Is(err, errInvalidInterval) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidInterval)
+	}
+	cfg.DataSettings.Interval = gctkline.OneHour
+	day, err := estimateDownload(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(day) != 2 || day[0].candles != 24 || day[0].bytes != 24*estimatedCandleBytes {
+		t.Errorf("received '%+v' expecting 2 estimates of '%v' candles", day, 24)
+	}
+
+	week, err := estimateDownload(withDateRange(cfg, start, start.Add(time.Hour*24*7), nil))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if week[1].candles != day[1].candles*7 || week[1].bytes != day[1].bytes*7 {
+		t.Errorf("received '%v' expecting '%v'", week[1].candles, day[1].candles*7)
+	}
+
+	cfg.DataSettings.APIData.InclusiveEndDate = true
+	inclusive, err := estimateDownload(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if inclusive[0].candles != 25 {
+		t.Errorf("received '%v' expecting '%v'", inclusive[0].candles, 25)
+	}
+
+	cfg.DataSettings.APIData.EndDate = start
+	_, err = estimateDownload(cfg)
+	if !errors.Is(err, gctcommon.ErrStartAfterEnd) {
+		t.Errorf("received '%v' expecting '%v'", err, gctcommon.ErrStartAfterEnd)
+	}
+}
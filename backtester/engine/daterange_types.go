@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+const (
+	// RegimeBull is a range where price rose by more than regimeTrendPercent
+	RegimeBull = "bull"
+	// RegimeBear is a range where price fell by more than regimeTrendPercent
+	RegimeBear = "bear"
+	// RegimeSideways is a range where price moved less than
+	// regimeTrendPercent in either direction
+	RegimeSideways = "sideways"
+
+	// regimeTrendPercent is the return over a range required for it to be
+	// considered trending
+	regimeTrendPercent = 10
+	// crashDrawdownPercent is the peak to trough fall which is considered a
+	// crash
+	crashDrawdownPercent = 20
+	// estimatedCandleBytes is the approximate size of a single candle as
+	// returned by an exchange API, used to estimate download sizes
+	estimatedCandleBytes = 100
+)
+
+var (
+	errNoCandles       = errors.New("no candles to analyse")
+	errInvalidInterval = errors.New("invalid interval")
+)
+
+// rangeStats holds the volatility and regime of a pair's price over a date
+// range
+type rangeStats struct {
+	candles            int64
+	start              time.Time
+	end                time.Time
+	startPrice         float64
+	endPrice           float64
+	returnPercent      float64
+	volatilityPercent  float64
+	maxDrawdownPercent float64
+	regime             string
+	containsCrash      bool
+}
+
+// downloadEstimate holds the approximate amount of data required for a
+// currency of a config over its date range
+type downloadEstimate struct {
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
+	interval gctkline.Interval
+	candles  int64
+	bytes    int64
+}
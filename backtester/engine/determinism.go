@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+)
+
+// compareRepeatedRuns executes the config the requested number of times and
+// compares the summary metrics of each execution against the first, stopping
+// at the first divergence. progress is called after every backtest executed
+func compareRepeatedRuns(cfg *config.Config, btCfg *config.BacktesterConfig, executor func(*config.Config, *config.BacktesterConfig, *runHooks) error, runs int, cancel <-chan struct{}, progress func()) ([]determinismSummary, *divergence, error) {
+	if runs < 2 {
+		return nil, nil, errTooFewRuns
+	}
+	summaries := make([]determinismSummary, 0, runs)
+	for i := 0; i < runs; i++ {
+		var (
+			tracker equityTracker
+			summary determinismSummary
+		)
+		err := executor(cfg, btCfg, &runHooks{
+			equity: tracker.record,
+			event: func(ev common.EventHandler) bool {
+				f, ok := ev.(fill.Event)
+				if !ok || f.GetOrder() == nil {
+					return true
+				}
+				summary.trades++
+				summary.volume += f.GetPurchasePrice().Mul(f.GetAmount()).InexactFloat64()
+				summary.fees += f.GetExchangeFee().InexactFloat64()
+				return true
+			},
+			preview: true,
+			cancel:  cancel,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("run %v: %w", i+1, err)
+		}
+		progress()
+		summary.finalEquity = tracker.last
+		summary.returnPercent = tracker.returnPercent()
+		summaries = append(summaries, summary)
+		if i == 0 {
+			continue
+		}
+		expected, received := summaries[0].metrics(), summary.metrics()
+		for j := range expected {
+			if expected[j].value != received[j].value {
+				return summaries, &divergence{
+					run:      i + 1,
+					metric:   expected[j].name,
+					expected: expected[j].value,
+					received: received[j].value,
+				}, nil
+			}
+		}
+	}
+	return summaries, nil, nil
+}
+
+// metrics returns the summary metrics in the order they are compared
+func (d *determinismSummary) metrics() []determinismMetric {
+	return []determinismMetric{
+		{name: "trades", value: float64(d.trades)},
+		{name: "volume", value: d.volume},
+		{name: "fees", value: d.fees},
+		{name: "final-equity", value: d.finalEquity},
+		{name: "return-percent", value: d.returnPercent},
+	}
+}
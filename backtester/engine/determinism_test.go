@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+)
+
+func TestCompareRepeatedRuns(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var executions int
+	executor := func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+		executions++
+		hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+		hooks.equity("binance spot ETH-USDT", start, decimal.NewFromInt(50))
+		hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromFloat(100.1))
+		hooks.equity("binance spot ETH-USDT", start.Add(time.Hour), decimal.NewFromFloat(49.7))
+		return nil
+	}
+	progress := func() {}
+	_, _, err := compareRepeatedRuns(&config.Config{}, nil, executor, 1, nil, progress)
+	if !errors.Is(err, errTooFewRuns) {
+		t.Errorf("received '%v' expecting '%v'", err, errTooFewRuns)
+	}
+
+	summaries, diverged, err := compareRepeatedRuns(&config.Config{}, nil, executor, 3, nil, progress)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if diverged != nil {
+		t.Errorf("received '%+v' expecting no divergence", diverged)
+	}
+	if len(summaries) != 3 || executions != 3 {
+		t.Errorf("received '%v %v' expecting '%v %v'", len(summaries), executions, 3, 3)
+	}
+
+	// the second execution onwards reports a different final equity
+	executions = 0
+	drifting := func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+		executions++
+		hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+		hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(100+int64(executions/2)))
+		return nil
+	}
+	summaries, diverged, err = compareRepeatedRuns(&config.Config{}, nil, drifting, 4, nil, progress)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if diverged == nil || diverged.run != 2 || diverged.metric != "final-equity" || diverged.expected != 100 || diverged.received != 101 {
+		t.Errorf("received '%+v' expecting run 2 final-equity to diverge from 100 to 101", diverged)
+	}
+	if len(summaries) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(summaries), 2)
+	}
+
+	errExecution := errors.New("execution failed")
+	_, _, err = compareRepeatedRuns(&config.Config{}, nil, func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+		return errExecution
+	}, 2, nil, progress)
+	if !errors.Is(err, errExecution) {
+		t.Errorf("received '%v' expecting '%v'", err, errExecution)
+	}
+}
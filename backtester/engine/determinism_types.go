@@ -0,0 +1,30 @@
+package engine
+
+import "errors"
+
+var errTooFewRuns = errors.New("at least two runs are required to verify determinism")
+
+// determinismSummary holds the summary metrics of a backtest which are
+// compared between repeated executions of the same config
+type determinismSummary struct {
+	finalEquity   float64
+	returnPercent float64
+	trades        int64
+	volume        float64
+	fees          float64
+}
+
+// determinismMetric is a named summary metric
+type determinismMetric struct {
+	name  string
+	value float64
+}
+
+// divergence describes the first summary metric of a repeated execution which
+// differed from the first execution
+type divergence struct {
+	run      int
+	metric   string
+	expected float64
+	received float64
+}
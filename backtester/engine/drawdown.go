@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// drawdownPeriods returns every drawdown episode of the equity curve whose
+// fall from peak to trough is at least minPercent. A period which has not
+// recovered by the end of the curve lasts until its final point
+func drawdownPeriods(points []EquityPoint, minPercent float64) []drawdownPeriod {
+	if len(points) == 0 {
+		return nil
+	}
+	var (
+		periods []drawdownPeriod
+		current *drawdownPeriod
+	)
+	peak := points[0]
+	closePeriod := func(end EquityPoint, recovered bool) {
+		current.recovery, current.recovered = end, recovered
+		current.duration = end.Time.Sub(current.peak.Time)
+		if current.drawdownPercent > 0 && current.drawdownPercent >= minPercent {
+			periods = append(periods, *current)
+		}
+		current = nil
+	}
+	for i := 1; i < len(points); i++ {
+		if points[i].Equity >= peak.Equity {
+			if current != nil {
+				closePeriod(points[i], true)
+			}
+			peak = points[i]
+			continue
+		}
+		if current == nil {
+			current = &drawdownPeriod{peak: peak, trough: points[i]}
+		} else if points[i].Equity < current.trough.Equity {
+			current.trough = points[i]
+		}
+		if peak.Equity > 0 {
+			current.drawdownPercent = (peak.Equity - current.trough.Equity) / peak.Equity * 100
+		}
+	}
+	if current != nil {
+		closePeriod(points[len(points)-1], false)
+	}
+	return periods
+}
+
+// maxDrawdownPercent returns the largest fall from peak to trough of the
+// equity curve as a percentage of the peak
+func maxDrawdownPercent(points []EquityPoint) float64 {
+	var highest float64
+	periods := drawdownPeriods(points, 0)
+	for i := range periods {
+		if periods[i].drawdownPercent > highest {
+			highest = periods[i].drawdownPercent
+		}
+	}
+	return highest
+}
+
+// record updates the equity of an exchange, asset and pair, checking the
+// drawdown of the total equity against the limit. A zero limit disables the
+// guard
+func (g *drawdownGuard) record(key string, t time.Time, value decimal.Decimal) {
+	if g.limit <= 0 || g.breached != nil {
+		return
+	}
+	g.tracker.record(key, t, value)
+	if g.tracker.last > g.peak {
+		g.peak = g.tracker.last
+	}
+	if g.peak <= 0 {
+		return
+	}
+	drawdown := (g.peak - g.tracker.last) / g.peak * 100
+	if drawdown >= g.limit {
+		g.breached = fmt.Errorf("%w drawdown of %.2f%% reached limit of %v%%", errRunAborted, drawdown, g.limit)
+	}
+}
+
+// toRPC converts the drawdown period to its GRPC representation
+func (d *drawdownPeriod) toRPC() *btrpc.DrawdownPeriod {
+	period := &btrpc.DrawdownPeriod{
+		PeakTime:        timestamppb.New(d.peak.Time),
+		PeakEquity:      d.peak.Equity,
+		TroughTime:      timestamppb.New(d.trough.Time),
+		TroughEquity:    d.trough.Equity,
+		DrawdownPercent: d.drawdownPercent,
+		DurationSeconds: int64(d.duration.Seconds()),
+		Recovered:       d.recovered,
+	}
+	if d.recovered {
+		period.RecoveryTime = timestamppb.New(d.recovery.Time)
+	}
+	return period
+}
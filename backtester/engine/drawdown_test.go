@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDrawdownPeriods(t *testing.T) {
+	t.Parallel()
+	if periods := drawdownPeriods(nil, 0); periods != nil {
+		t.Errorf("received '%v' expecting '%v'", periods, nil)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var points []EquityPoint
+	for i, equity := range []float64{100, 90, 95, 80, 100, 110, 105, 110, 120, 100} {
+		points = append(points, EquityPoint{Time: start.Add(time.Duration(i) * time.Hour), Equity: equity})
+	}
+	periods := drawdownPeriods(points, 0)
+	if len(periods) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(periods), 3)
+	}
+	for i, expected := range []struct {
+		peak, trough, recovery int
+		recovered              bool
+		percent                float64
+	}{
+		{peak: 0, trough: 3, recovery: 4, recovered: true, percent: 20},
+		{peak: 5, trough: 6, recovery: 7, recovered: true, percent: 5.0 / 110 * 100},
+		{peak: 8, trough: 9, recovery: 9, recovered: false, percent: 20.0 / 120 * 100},
+	} {
+		p := periods[i]
+		if !p.peak.Time.Equal(points[expected.peak].Time) || !p.trough.Time.Equal(points[expected.trough].Time) {
+			t.Errorf("period %v received peak '%v' trough '%v' expecting '%v' '%v'", i, p.peak, p.trough, points[expected.peak], points[expected.trough])
+		}
+		if p.recovered != expected.recovered || !p.recovery.Time.Equal(points[expected.recovery].Time) {
+			t.Errorf("period %v received recovery '%v %v' expecting '%v %v'", i, p.recovered, p.recovery, expected.recovered, points[expected.recovery])
+		}
+		if p.duration != time.Duration(expected.recovery-expected.peak)*time.Hour {
+			t.Errorf("period %v received '%v' expecting '%v'", i, p.duration, time.Duration(expected.recovery-expected.peak)*time.Hour)
+		}
+		if math.Abs(p.drawdownPercent-expected.percent) > 1e-9 {
+			t.Errorf("period %v received '%v' expecting '%v'", i, p.drawdownPercent, expected.percent)
+		}
+	}
+
+	periods = drawdownPeriods(points, 10)
+	if len(periods) != 2 || periods[0].drawdownPercent != 20 {
+		t.Errorf("received '%+v' expecting the 2 periods of at least 10 percent", periods)
+	}
+}
+
+func TestDrawdownGuard(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	disabled := &drawdownGuard{}
+	disabled.record("a", start, decimal.NewFromInt(100))
+	disabled.record("a", start.Add(time.Hour), decimal.NewFromInt(1))
+	if disabled.breached != nil {
+		t.Errorf("received '%v' expecting '%v'", disabled.breached, nil)
+	}
+
+	g := &drawdownGuard{limit: 20}
+	for i, equity := range []int64{100, 120, 100} {
+		g.record("a", start.Add(time.Duration(i)*time.Hour), decimal.NewFromInt(equity))
+	}
+	if g.breached != nil {
+		t.Errorf("received '%v' expecting '%v'", g.breached, nil)
+	}
+	g.record("b", start.Add(3*time.Hour), decimal.NewFromInt(-4))
+	if !errors.Is(g.breached, errRunAborted) {
+		t.Errorf("received '%v' expecting '%v'", g.breached, errRunAborted)
+	}
+}
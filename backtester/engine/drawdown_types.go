@@ -0,0 +1,23 @@
+package engine
+
+import "time"
+
+// drawdownPeriod is an episode where equity fell below its previous peak,
+// lasting until equity recovered to the peak or the equity curve ended
+type drawdownPeriod struct {
+	peak            EquityPoint
+	trough          EquityPoint
+	recovery        EquityPoint
+	recovered       bool
+	drawdownPercent float64
+	duration        time.Duration
+}
+
+// drawdownGuard tracks the total equity of a running backtest, recording an
+// error once equity falls the limit percentage below its peak
+type drawdownGuard struct {
+	limit    float64
+	tracker  equityTracker
+	peak     float64
+	breached error
+}
@@ -4,18 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/rand"
 	"net"
 	"net/http"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/gofrs/uuid"
 	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -27,25 +43,107 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	errBadPort = errors.New("received bad port")
+	// equityCurvePollInterval is how often a running run is checked for new
+	// equity curve points when streaming
+	equityCurvePollInterval = time.Second
+	// interactiveProgressInterval is how often progress is sent to
+	// interactive strategy clients
+	interactiveProgressInterval = time.Second
+	// resourceUsageInterval is how often resource usage is sampled for a
+	// run when no interval is requested
+	resourceUsageInterval = time.Second
+	// exchangeConnectivityTimeout is the longest an exchange connectivity
+	// check can take before the exchange is deemed unreachable
+	exchangeConnectivityTimeout = time.Second * 10
+	// maxPreviewEvents is the most events a strategy preview can return
+	maxPreviewEvents uint64 = 1000
+	// maxTradesPageSize is the most trades returned by a single
+	// GetStrategyTrades request
+	maxTradesPageSize uint64 = 1000
+	// maxMonteCarloIterations is the most resamples a Monte Carlo
+	// simulation request can run
+	maxMonteCarloIterations uint64 = 10000
+	// sweepLeaderboardPollInterval is how often a sweep's runs are checked
+	// in case a run event was missed while streaming its leaderboard
+	sweepLeaderboardPollInterval = time.Second
+	// largeDownloadBytes is the estimated download size above which
+	// EstimateDataDownload warns of a large download
+	largeDownloadBytes uint64 = 100 * 1024 * 1024
+	// maxDeterminismRuns is the most times a config can be executed when
+	// verifying determinism
+	maxDeterminismRuns uint64 = 10
+	// maxRollingWindows is the most windows GetRollingMetrics will calculate
+	// for a single request
+	maxRollingWindows = 10000
 )
 
 // GRPCServer struct
 type GRPCServer struct {
 	btrpc.BacktesterServiceServer
 	*config.BacktesterConfig
+	runs RunManager
+	// strategyExecutor allows for the execution of strategies to be
+	// overridden, defaults to executeStrategy when unset
+	strategyExecutor func(*config.Config, *config.BacktesterConfig, *runHooks) error
+	// exchangePinger allows for exchange connectivity checks to be
+	// overridden, defaults to pingExchange when unset
+	exchangePinger func(ctx context.Context, exchangeName string) error
+	// dataAvailability allows for the source of data availability to be
+	// overridden, defaults to databaseAvailability when unset
+	dataAvailability func(exchangeName string, pair currency.Pair, a asset.Item, interval gctkline.Interval) (*dataWindow, error)
+	// candleSource allows for the source of candles used in date range
+	// analysis to be overridden, defaults to databaseCandles when unset
+	candleSource func(exchangeName string, pair currency.Pair, a asset.Item, interval gctkline.Interval, start, end time.Time) ([]gctkline.Candle, error)
+	// clock allows the time used to launch scheduled runs to be overridden,
+	// defaults to systemClock when unset
+	clock clock
+	// paused is set to 1 when new runs are not being accepted
+	paused    int32
+	webhooks  completionWebhooks
+	limits    serverLimits
+	templates configTemplates
+	schedules scheduledRuns
+	sessions  sessions
+}
+
+// dataWindow describes the candles available for an exchange, asset, pair and
+// interval
+type dataWindow struct {
+	earliest time.Time
+	latest   time.Time
+	candles  int64
+}
+
+// coverage returns the ratio of available candles to the candles expected
+// between the earliest and latest candle
+func (d *dataWindow) coverage(interval gctkline.Interval) float64 {
+	if d.candles <= 0 || interval <= 0 || d.latest.Before(d.earliest) {
+		return 0
+	}
+	expected := int64(d.latest.Sub(d.earliest)/interval.Duration()) + 1
+	if d.candles >= expected {
+		return 1
+	}
+	return float64(d.candles) / float64(expected)
 }
 
 // SetupRPCServer sets up the gRPC server
 func SetupRPCServer(cfg *config.BacktesterConfig) *GRPCServer {
-	return &GRPCServer{
+	s := &GRPCServer{
 		BacktesterConfig: cfg,
 	}
+	runLogs.register(&s.runs)
+	return s
 }
 
 // StartRPCServer starts a gRPC server with TLS auth
@@ -65,10 +163,7 @@ func StartRPCServer(server *GRPCServer) error {
 		return err
 	}
 
-	opts := []grpc.ServerOption{
-		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(server.authenticateClient)),
-	}
+	opts := append([]grpc.ServerOption{grpc.Creds(creds)}, server.interceptors()...)
 	s := grpc.NewServer(opts...)
 	btrpc.RegisterBacktesterServiceServer(s, server)
 
@@ -87,6 +182,15 @@ func StartRPCServer(server *GRPCServer) error {
 	return nil
 }
 
+// interceptors returns the server options which authenticate every unary and
+// streaming call before it is handled
+func (s *GRPCServer) interceptors() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpcauth.UnaryServerInterceptor(s.authenticateClient), s.recordSessionCall),
+		grpc.ChainStreamInterceptor(grpcauth.StreamServerInterceptor(s.authenticateClient)),
+	}
+}
+
 // StartRPCRESTProxy starts a gRPC proxy
 func (s *GRPCServer) StartRPCRESTProxy() error {
 	log.Debugf(log.GRPCSys, "GRPC proxy server support enabled. Starting gRPC proxy server on http://%v.\n", s.GRPC.GRPCProxyListenAddress)
@@ -155,18 +259,16 @@ func (s *GRPCServer) ExecuteStrategyFromFile(_ context.Context, request *btrpc.E
 	if request == nil {
 		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
 	}
-	dir := request.StrategyFilePath
-	cfg, err := config.ReadStrategyConfigFromFile(dir)
+	err := s.checkAcceptingRuns()
 	if err != nil {
 		return nil, err
 	}
-	err = ExecuteStrategy(cfg, s.BacktesterConfig)
+	dir := request.StrategyFilePath
+	cfg, err := config.ReadStrategyConfigFromFile(dir)
 	if err != nil {
 		return nil, err
 	}
-	return &btrpc.ExecuteStrategyResponse{
-		Success: true,
-	}, nil
+	return s.executeRun(cfg, request.Labels, request.RejectDuplicateConfig, request.AbortOnDrawdownPct)
 }
 
 // ExecuteStrategyFromConfig will backtest a strategy config built from a GRPC command
@@ -176,6 +278,10 @@ func (s *GRPCServer) ExecuteStrategyFromConfig(_ context.Context, request *btrpc
 	if request == nil || request.Config == nil {
 		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
 	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
 
 	rfr, err := decimal.NewFromString(request.Config.StatisticSettings.RiskFreeRate)
 	if err != nil {
@@ -495,11 +601,2338 @@ func (s *GRPCServer) ExecuteStrategyFromConfig(_ context.Context, request *btrpc
 		},
 	}
 
-	err = ExecuteStrategy(cfg, s.BacktesterConfig)
+	return s.executeRun(cfg, request.Labels, request.RejectDuplicateConfig, request.AbortOnDrawdownPct)
+}
+
+// RegisterCompletionWebhook registers a URL which is sent the run summary via
+// a POST request when a run finishes. When no run ID is supplied the URL is
+// notified whenever any run finishes. Registering against a run which has
+// already finished notifies the URL immediately
+func (s *GRPCServer) RegisterCompletionWebhook(_ context.Context, request *btrpc.RegisterCompletionWebhookRequest) (*btrpc.RegisterCompletionWebhookResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	var run *Run
+	if request.RunId != "" {
+		var err error
+		run, err = s.getRun(request.RunId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	switch {
+	case run == nil:
+		err = s.webhooks.register(uuid.Nil, request.Url)
+	case run.Status == RunStatusRunning:
+		err = s.webhooks.register(run.ID, request.Url)
+		if err != nil {
+			break
+		}
+		// the run may have finished and taken its webhooks before the URL
+		// was registered, in which case it is notified here instead
+		id := run.ID
+		run, err = s.runs.GetRun(id)
+		if err != nil {
+			s.webhooks.remove(id, request.Url)
+			break
+		}
+		if run.Status != RunStatusRunning && s.webhooks.remove(run.ID, request.Url) {
+			s.webhooks.notify(run, []string{request.Url})
+		}
+	default:
+		err = validateWebhookURL(request.Url)
+		if err == nil {
+			s.webhooks.notify(run, []string{request.Url})
+		}
+	}
+	if errors.Is(err, errInvalidWebhookURL) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.RegisterCompletionWebhookResponse{
+		Success: true,
+	}, nil
+}
+
+// notifyWebhooks sends the summary of a finished run to its webhooks
+func (s *GRPCServer) notifyWebhooks(id uuid.UUID) {
+	run, err := s.runs.GetRun(id)
+	if err != nil {
+		log.Error(common.Backtester, err)
+		return
+	}
+	s.webhooks.notify(run, s.webhooks.take(id))
+}
+
+// SetServerPaused pauses or resumes the acceptance of new runs. Runs which
+// are already executing are unaffected
+func (s *GRPCServer) SetServerPaused(_ context.Context, request *btrpc.SetServerPausedRequest) (*btrpc.SetServerPausedResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	var paused int32
+	if request.Paused {
+		paused = 1
+	}
+	atomic.StoreInt32(&s.paused, paused)
+	return &btrpc.SetServerPausedResponse{
+		Paused: request.Paused,
+	}, nil
+}
+
+// GetServerInfo returns the current state of the server
+func (s *GRPCServer) GetServerInfo(_ context.Context, request *btrpc.GetServerInfoRequest) (*btrpc.GetServerInfoResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	build := currentBuildInfo()
+	return &btrpc.GetServerInfoResponse{
+		Paused:    s.isPaused(),
+		Version:   build.Version,
+		Commit:    build.Commit,
+		GoVersion: build.GoVersion,
+	}, nil
+}
+
+// isPaused returns whether the server is not accepting new runs
+func (s *GRPCServer) isPaused() bool {
+	return atomic.LoadInt32(&s.paused) == 1
+}
+
+// checkAcceptingRuns returns an unavailable status while the server is paused
+func (s *GRPCServer) checkAcceptingRuns() error {
+	if s.isPaused() {
+		return status.Error(codes.Unavailable, "server is paused and not accepting new runs")
+	}
+	return nil
+}
+
+// executeRun registers and runs the strategy config. When rejectDuplicate is
+// set, a config identical to an active run will not be started and the active
+// run ID is returned in the error details. A non-zero abortOnDrawdown stops
+// the run once its equity falls that percentage below its peak, the aborted
+// run keeps its partial results and its ID is returned in the error details
+func (s *GRPCServer) executeRun(cfg *config.Config, labels map[string]string, rejectDuplicate bool, abortOnDrawdown float64) (*btrpc.ExecuteStrategyResponse, error) {
+	if abortOnDrawdown < 0 || abortOnDrawdown > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "abort on drawdown percent must be between 0 and 100, received %v", abortOnDrawdown)
+	}
+	hash, err := HashConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = s.limits.acquireRun()
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer s.limits.releaseRun()
+	settings := make(chan *settingsUpdate)
+	cancel := make(chan struct{})
+	gate := newRunGate()
+	run, err := s.runs.StartRun(&Run{
+		ConfigHash:      hash,
+		Strategy:        cfg.StrategySettings.Name,
+		Labels:          labels,
+		BuildInfo:       currentBuildInfo(),
+		settingsUpdates: settings,
+		cancel:          cancel,
+		gate:            gate,
+		config:          cfg,
+	}, rejectDuplicate)
+	if errors.Is(err, errRunAlreadyActive) {
+		st, detailErr := status.New(codes.AlreadyExists, err.Error()).WithDetails(&btrpc.ExecuteStrategyResponse{
+			Message: err.Error(),
+			RunId:   run.ID.String(),
+		})
+		if detailErr != nil {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, st.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	executor := s.strategyExecutor
+	if executor == nil {
+		executor = executeStrategy
+	}
+	guard := &drawdownGuard{limit: abortOnDrawdown}
+	err = executor(cfg, s.BacktesterConfig, &runHooks{
+		progress: func(processed, total int64) {
+			if progressErr := s.runs.UpdateProgress(run.ID, processed, total); progressErr != nil {
+				log.Error(common.Backtester, progressErr)
+			}
+		},
+		equity: func(key string, t time.Time, value decimal.Decimal) {
+			if equityErr := s.runs.RecordEquity(run.ID, key, t, value.InexactFloat64()); equityErr != nil {
+				log.Error(common.Backtester, equityErr)
+			}
+			guard.record(key, t, value)
+		},
+		event: func(ev common.EventHandler) bool {
+			f, ok := ev.(fill.Event)
+			if !ok || f.GetOrder() == nil {
+				return guard.breached == nil
+			}
+			if tradeErr := s.runs.RecordTrade(run.ID, &TradeRecord{
+				Time:     f.GetTime(),
+				Exchange: f.GetExchange(),
+				Asset:    f.GetAssetType().String(),
+				Pair:     f.Pair().String(),
+				Side:     f.GetOrder().Side.String(),
+				Price:    f.GetPurchasePrice().InexactFloat64(),
+				Amount:   f.GetAmount().InexactFloat64(),
+				Fee:      f.GetExchangeFee().InexactFloat64(),
+			}); tradeErr != nil {
+				log.Error(common.Backtester, tradeErr)
+			}
+			return guard.breached == nil
+		},
+		candle: func(ev common.DataEventHandler) {
+			candle := &candleRecord{
+				time:     ev.GetTime(),
+				exchange: ev.GetExchange(),
+				asset:    ev.GetAssetType(),
+				pair:     ev.Pair(),
+				open:     ev.GetOpenPrice().InexactFloat64(),
+				high:     ev.GetHighPrice().InexactFloat64(),
+				low:      ev.GetLowPrice().InexactFloat64(),
+				close:    ev.GetClosePrice().InexactFloat64(),
+			}
+			if k, ok := ev.(*evkline.Kline); ok {
+				candle.volume = k.Volume.InexactFloat64()
+			}
+			if candleErr := s.runs.RecordCandle(run.ID, candle); candleErr != nil {
+				log.Error(common.Backtester, candleErr)
+			}
+		},
+		settings: settings,
+		cancel:   cancel,
+		gate:     gate,
+	})
+	if err == nil && guard.breached != nil {
+		err = guard.breached
+	}
+	finishErr := s.runs.FinishRun(run.ID, err)
+	if finishErr == nil {
+		s.notifyWebhooks(run.ID)
+	}
+	if errors.Is(err, errRunCancelled) {
+		return nil, status.Errorf(codes.Canceled, "%v %v", err, run.ID)
+	}
+	if errors.Is(err, errRunAborted) {
+		st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(&btrpc.ExecuteStrategyResponse{
+			Message: err.Error(),
+			RunId:   run.ID.String(),
+		})
+		if detailErr != nil {
+			return nil, status.Errorf(codes.Aborted, "%v %v", err, run.ID)
+		}
+		return nil, st.Err()
+	}
 	if err != nil {
 		return nil, err
 	}
+	if finishErr != nil {
+		return nil, finishErr
+	}
 	return &btrpc.ExecuteStrategyResponse{
 		Success: true,
+		RunId:   run.ID.String(),
+	}, nil
+}
+
+// ListRuns returns all runs executed by the server, optionally filtered to
+// runs containing all of the supplied labels
+func (s *GRPCServer) ListRuns(_ context.Context, request *btrpc.ListRunsRequest) (*btrpc.ListRunsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	runs, err := s.runs.ListRuns(request.Labels)
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.ListRunsResponse{
+		Runs: make([]*btrpc.RunSummary, len(runs)),
+	}
+	for i := range runs {
+		resp.Runs[i] = runs[i].toRPCSummary()
+	}
+	return resp, nil
+}
+
+// GetRunProgress returns how far through its data a run is, along with an
+// estimated time remaining when one can be determined
+func (s *GRPCServer) GetRunProgress(_ context.Context, request *btrpc.GetRunProgressRequest) (*btrpc.GetRunProgressResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	return runProgressToRPC(run, time.Now()), nil
+}
+
+// runProgressToRPC converts the progress of a run at the supplied time to its
+// RPC representation
+func runProgressToRPC(run *Run, now time.Time) *btrpc.GetRunProgressResponse {
+	resp := &btrpc.GetRunProgressResponse{
+		RunId:           run.ID.String(),
+		Status:          run.Status,
+		EventsProcessed: run.EventsProcessed,
+		EventsTotal:     run.EventsTotal,
+		PercentComplete: run.PercentComplete(),
+		Paused:          run.Paused,
+	}
+	if eta, ok := run.EstimateTimeRemaining(now); ok && !run.Paused {
+		etaSeconds := int64(eta.Round(time.Second) / time.Second)
+		resp.EtaSeconds = &etaSeconds
+	}
+	return resp
+}
+
+// StreamEquityCurve sends the equity curve points of a run. Points of a
+// running run continue to be sent as they are recorded until the run finishes.
+// The points available when the request is made are downsampled to the
+// maximum amount of points when one is set
+func (s *GRPCServer) StreamEquityCurve(request *btrpc.StreamEquityCurveRequest, stream btrpc.BacktesterService_StreamEquityCurveServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.MaxPoints < 0 {
+		return status.Errorf(codes.InvalidArgument, "max points cannot be negative: %v", request.MaxPoints)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	points := downsampleEquity(run.EquityCurve, int(request.MaxPoints))
+	for i := range points {
+		err = stream.Send(points[i].toRPC())
+		if err != nil {
+			return err
+		}
+	}
+	sent := len(run.EquityCurve)
+	ticker := time.NewTicker(equityCurvePollInterval)
+	defer ticker.Stop()
+	for run.Status == RunStatusRunning {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+		run, err = s.runs.GetRun(run.ID)
+		if err != nil {
+			return err
+		}
+		for ; sent < len(run.EquityCurve); sent++ {
+			err = stream.Send(run.EquityCurve[sent].toRPC())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SubscribeRunEvents sends an event whenever a run starts or finishes until
+// the client disconnects. When replay active is set, a started event is first
+// sent for every run which is already running
+func (s *GRPCServer) SubscribeRunEvents(request *btrpc.SubscribeRunEventsRequest, stream btrpc.BacktesterService_SubscribeRunEventsServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	ch, active, err := s.runs.SubscribeRunEvents()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unsubErr := s.runs.UnsubscribeRunEvents(ch); unsubErr != nil {
+			log.Error(common.Backtester, unsubErr)
+		}
+	}()
+	if request.ReplayActive {
+		for i := range active {
+			ev := RunEvent{
+				Event:    RunEventStarted,
+				RunID:    active[i].ID,
+				Strategy: active[i].Strategy,
+				Time:     active[i].StartTime,
+			}
+			err = stream.Send(ev.toRPC())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case ev := <-ch:
+			err = stream.Send(ev.toRPC())
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ExportResults returns the results of a run encoded in each of the requested
+// formats, keyed by format
+func (s *GRPCServer) ExportResults(_ context.Context, request *btrpc.ExportResultsRequest) (*btrpc.ExportResultsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if len(request.Formats) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no export formats requested")
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.ExportResultsResponse{
+		Exports: make(map[string][]byte, len(request.Formats)),
+	}
+	var size int
+	for i := range request.Formats {
+		format := strings.ToLower(request.Formats[i])
+		if _, ok := resp.Exports[format]; ok {
+			continue
+		}
+		var data []byte
+		data, err = run.Export(format)
+		if errors.Is(err, errUnsupportedFormat) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+		size += len(data)
+		if size > maxExportResultsSize {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"%v export of run %v takes the response over %v bytes, request fewer formats or read the equity curve with StreamEquityCurve and trades with GetStrategyTrades",
+				format, run.ID, maxExportResultsSize)
+		}
+		resp.Exports[format] = data
+	}
+	return resp, nil
+}
+
+// GetDefaultConfig returns an example strategy config for the named strategy
+// which can be used as a template for a new config
+func (s *GRPCServer) GetDefaultConfig(_ context.Context, request *btrpc.GetDefaultConfigRequest) (*btrpc.GetDefaultConfigResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	data, err := config.GetExampleConfig(request.StrategyName)
+	if errors.Is(err, config.ErrExampleConfigNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.GetDefaultConfigResponse{
+		Config: data,
 	}, nil
 }
+
+// GetRecentRuns returns the most recently completed runs, newest first.
+// Limits above the maximum are capped
+func (s *GRPCServer) GetRecentRuns(_ context.Context, request *btrpc.GetRecentRunsRequest) (*btrpc.GetRecentRunsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.Limit <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be greater than zero, received %v", request.Limit)
+	}
+	limit := request.Limit
+	if limit > maxRecentRuns {
+		limit = maxRecentRuns
+	}
+	runs, err := s.runs.RecentCompletedRuns(int(limit))
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.GetRecentRunsResponse{
+		Runs: make([]*btrpc.RecentRun, len(runs)),
+	}
+	for i := range runs {
+		resp.Runs[i] = &btrpc.RecentRun{
+			Id:           runs[i].ID.String(),
+			StrategyName: runs[i].Strategy,
+			EndTime:      timestamppb.New(runs[i].EndTime),
+		}
+		if ret, ok := runs[i].ReturnPercent(); ok {
+			resp.Runs[i].ReturnPercent = &ret
+		}
+	}
+	return resp, nil
+}
+
+// InteractiveStrategy allows custom strategy settings to be updated while a
+// run is executing. The first request must contain the ID of a running run.
+// Progress is sent after every applied update and periodically until the run
+// finishes. Strategies which do not support live updates reject them with a
+// failed precondition status
+func (s *GRPCServer) InteractiveStrategy(stream btrpc.BacktesterService_InteractiveStrategyServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	if run.Status != RunStatusRunning {
+		return status.Errorf(codes.FailedPrecondition, "%v %v", errRunNotRunning, run.ID)
+	}
+
+	requests := make(chan *btrpc.InteractiveStrategyRequest)
+	recvErrs := make(chan error, 1)
+	go func() {
+		for {
+			req, recvErr := stream.Recv()
+			if recvErr != nil {
+				recvErrs <- recvErr
+				return
+			}
+			select {
+			case requests <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interactiveProgressInterval)
+	defer ticker.Stop()
+	var applied int64
+	for {
+		if request != nil && len(request.CustomSettings) > 0 {
+			err = s.runs.UpdateSettings(stream.Context(), run.ID, customSettingsToMap(request.CustomSettings))
+			switch {
+			case errors.Is(err, errLiveUpdatesUnsupported), errors.Is(err, errRunNotRunning):
+				return status.Error(codes.FailedPrecondition, err.Error())
+			case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+				return err
+			case err != nil:
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			applied++
+		}
+		request = nil
+		run, err = s.runs.GetRun(run.ID)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(&btrpc.InteractiveStrategyResponse{
+			RunId:                  run.ID.String(),
+			Status:                 run.Status,
+			EventsProcessed:        run.EventsProcessed,
+			EventsTotal:            run.EventsTotal,
+			PercentComplete:        run.PercentComplete(),
+			SettingsUpdatesApplied: applied,
+		})
+		if err != nil {
+			return err
+		}
+		if run.Status != RunStatusRunning {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case err = <-recvErrs:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			// the client has finished sending updates, continue sending
+			// progress until the run finishes
+			recvErrs = nil
+		case request = <-requests:
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckExchangeConnectivity checks whether the server can retrieve data from
+// each of the named exchanges, reporting reachability and latency per exchange
+func (s *GRPCServer) CheckExchangeConnectivity(ctx context.Context, request *btrpc.CheckExchangeConnectivityRequest) (*btrpc.CheckExchangeConnectivityResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if len(request.Exchanges) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no exchanges supplied")
+	}
+	pinger := s.exchangePinger
+	if pinger == nil {
+		pinger = pingExchange
+	}
+	var exchanges []string
+	for i := range request.Exchanges {
+		name := strings.ToLower(request.Exchanges[i])
+		if !gctcommon.StringDataCompare(exchanges, name) {
+			exchanges = append(exchanges, name)
+		}
+	}
+	resp := &btrpc.CheckExchangeConnectivityResponse{
+		Results: make([]*btrpc.ExchangeConnectivity, len(exchanges)),
+	}
+	var wg sync.WaitGroup
+	wg.Add(len(exchanges))
+	for i := range exchanges {
+		go func(i int) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, exchangeConnectivityTimeout)
+			defer cancel()
+			start := time.Now()
+			err := pinger(pingCtx, exchanges[i])
+			result := &btrpc.ExchangeConnectivity{
+				Exchange:  exchanges[i],
+				Reachable: err == nil,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			resp.Results[i] = result
+		}(i)
+	}
+	wg.Wait()
+	return resp, nil
+}
+
+// pingExchange sets up the exchange and retrieves its tradable pairs to
+// confirm its data source can be reached
+func pingExchange(ctx context.Context, exchangeName string) error {
+	exch, err := gctengine.SetupExchangeManager().NewExchangeByName(exchangeName)
+	if err != nil {
+		return err
+	}
+	conf, err := exch.GetDefaultConfig()
+	if err != nil {
+		return err
+	}
+	conf.Enabled = true
+	conf.Websocket = convert.BoolPtr(false)
+	err = exch.Setup(conf)
+	if err != nil {
+		return err
+	}
+	assets := exch.GetAssetTypes(false)
+	if len(assets) == 0 {
+		return fmt.Errorf("%v has no supported assets", exchangeName)
+	}
+	_, err = exch.FetchTradablePairs(ctx, assets[0])
+	return err
+}
+
+// GetDataAvailability returns the earliest and latest candles available along
+// with their coverage for each requested exchange, asset, pair and interval
+func (s *GRPCServer) GetDataAvailability(_ context.Context, request *btrpc.GetDataAvailabilityRequest) (*btrpc.GetDataAvailabilityResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if len(request.Queries) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no data availability queries")
+	}
+	source := s.dataAvailability
+	if source == nil {
+		source = databaseAvailability
+	}
+	resp := &btrpc.GetDataAvailabilityResponse{
+		Availability: make([]*btrpc.DataAvailability, len(request.Queries)),
+	}
+	for i, q := range request.Queries {
+		if q == nil || q.ExchangeName == "" || q.Base == "" || q.Quote == "" || q.Interval == 0 || q.Interval > math.MaxInt64 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid data availability query %v", i)
+		}
+		a, err := asset.New(q.Asset)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		interval := gctkline.Interval(q.Interval)
+		availability := &btrpc.DataAvailability{
+			ExchangeName: q.ExchangeName,
+			Asset:        q.Asset,
+			Base:         q.Base,
+			Quote:        q.Quote,
+			Interval:     q.Interval,
+		}
+		resp.Availability[i] = availability
+		window, err := source(q.ExchangeName, currency.NewPair(currency.NewCode(q.Base), currency.NewCode(q.Quote)), a, interval)
+		if err != nil {
+			availability.Error = err.Error()
+			continue
+		}
+		if window == nil || window.candles <= 0 {
+			continue
+		}
+		availability.Available = true
+		availability.Earliest = timestamppb.New(window.earliest)
+		availability.Latest = timestamppb.New(window.latest)
+		availability.Candles = uint64(window.candles)
+		availability.Coverage = window.coverage(interval)
+	}
+	return resp, nil
+}
+
+// databaseAvailability determines the candles stored in the database for an
+// exchange, asset, pair and interval
+func databaseAvailability(exchangeName string, pair currency.Pair, a asset.Item, interval gctkline.Interval) (*dataWindow, error) {
+	candles, err := databaseCandles(exchangeName, pair, a, interval, time.Unix(0, 0), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) == 0 {
+		return &dataWindow{}, nil
+	}
+	window := &dataWindow{
+		earliest: candles[0].Time,
+		latest:   candles[0].Time,
+		candles:  int64(len(candles)),
+	}
+	for i := range candles {
+		if candles[i].Time.Before(window.earliest) {
+			window.earliest = candles[i].Time
+		}
+		if candles[i].Time.After(window.latest) {
+			window.latest = candles[i].Time
+		}
+	}
+	return window, nil
+}
+
+// databaseCandles loads the candles stored in the database for an exchange,
+// asset, pair and interval within a date range
+func databaseCandles(exchangeName string, pair currency.Pair, a asset.Item, interval gctkline.Interval, start, end time.Time) ([]gctkline.Candle, error) {
+	if !database.DB.IsConnected() {
+		return nil, database.ErrDatabaseNotConnected
+	}
+	item, err := gctkline.LoadFromDatabase(exchangeName, pair, a, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return item.Candles, nil
+}
+
+// AnalyzeDateRange returns the volatility and market regime of each requested
+// pair over a date range, allowing configs to be checked for coverage of
+// differing market conditions
+func (s *GRPCServer) AnalyzeDateRange(_ context.Context, request *btrpc.AnalyzeDateRangeRequest) (*btrpc.AnalyzeDateRangeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if len(request.Pairs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no pairs to analyse")
+	}
+	if request.Interval == 0 || request.Interval > math.MaxInt64 {
+		return nil, status.Error(codes.InvalidArgument, "invalid interval")
+	}
+	start, end := request.StartDate.AsTime(), request.EndDate.AsTime()
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, gctcommon.ErrStartAfterEnd.Error())
+	}
+	source := s.candleSource
+	if source == nil {
+		source = databaseCandles
+	}
+	interval := gctkline.Interval(request.Interval)
+	resp := &btrpc.AnalyzeDateRangeResponse{
+		Stats: make([]*btrpc.DateRangeStats, len(request.Pairs)),
+	}
+	for i, p := range request.Pairs {
+		if p == nil || p.ExchangeName == "" || p.Base == "" || p.Quote == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pair %v", i)
+		}
+		a, err := asset.New(p.Asset)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		rpcStats := &btrpc.DateRangeStats{
+			ExchangeName: p.ExchangeName,
+			Asset:        p.Asset,
+			Base:         p.Base,
+			Quote:        p.Quote,
+		}
+		resp.Stats[i] = rpcStats
+		candles, err := source(p.ExchangeName, currency.NewPair(currency.NewCode(p.Base), currency.NewCode(p.Quote)), a, interval, start, end)
+		if err != nil {
+			rpcStats.Error = err.Error()
+			continue
+		}
+		stats, err := analyseCandles(candles)
+		if err != nil {
+			rpcStats.Error = err.Error()
+			continue
+		}
+		rpcStats.Candles = uint64(stats.candles)
+		rpcStats.Start = timestamppb.New(stats.start)
+		rpcStats.End = timestamppb.New(stats.end)
+		rpcStats.StartPrice = stats.startPrice
+		rpcStats.EndPrice = stats.endPrice
+		rpcStats.ReturnPercent = stats.returnPercent
+		rpcStats.VolatilityPercent = stats.volatilityPercent
+		rpcStats.MaxDrawdownPercent = stats.maxDrawdownPercent
+		rpcStats.Regime = stats.regime
+		rpcStats.ContainsCrash = stats.containsCrash
+	}
+	return resp, nil
+}
+
+// customSettingsToMap converts custom settings to the format used by strategy
+// configs, where numeric values are parsed as float64
+func customSettingsToMap(settings []*btrpc.CustomSettings) map[string]interface{} {
+	resp := make(map[string]interface{}, len(settings))
+	for i := range settings {
+		if f, err := strconv.ParseFloat(settings[i].KeyValue, 64); err == nil {
+			resp[settings[i].KeyField] = f
+			continue
+		}
+		resp[settings[i].KeyField] = settings[i].KeyValue
+	}
+	return resp
+}
+
+// GetStrategyLogs returns the buffered logs of a run, optionally filtered by
+// level and limited to the latest lines
+func (s *GRPCServer) GetStrategyLogs(_ context.Context, request *btrpc.GetStrategyLogsRequest) (*btrpc.GetStrategyLogsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	level := strings.ToLower(request.Level)
+	switch level {
+	case "", LogLevelInfo, LogLevelWarn, LogLevelDebug, LogLevelError:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "%v '%v'", errInvalidLogLevel, request.Level)
+	}
+	if request.Limit > math.MaxInt32 {
+		request.Limit = math.MaxInt32
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.runs.GetLogs(run.ID, level, int(request.Limit))
+	if errors.Is(err, errLogsEvicted) || errors.Is(err, errRunNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.GetStrategyLogsResponse{
+		RunId: run.ID.String(),
+		Logs:  make([]*btrpc.LogRecord, len(logs)),
+	}
+	for i := range logs {
+		resp.Logs[i] = logs[i].toRPC()
+	}
+	return resp, nil
+}
+
+// CanonicalizeConfig returns the canonical form of a strategy config so that
+// configs can be compared across environments
+func (s *GRPCServer) CanonicalizeConfig(_ context.Context, request *btrpc.CanonicalizeConfigRequest) (*btrpc.CanonicalizeConfigResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	data, err := config.Canonicalize(request.Config)
+	if errors.Is(err, config.ErrInvalidConfigData) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.CanonicalizeConfigResponse{
+		Config: data,
+	}, nil
+}
+
+// ExportRegistry returns all finished runs so they can be imported into
+// another server
+func (s *GRPCServer) ExportRegistry(_ context.Context, request *btrpc.ExportRegistryRequest) (*btrpc.ExportRegistryResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	data, err := s.runs.ExportRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.ExportRegistryResponse{
+		Registry: data,
+	}, nil
+}
+
+// ImportRegistry adds the runs from a registry exported by another server
+func (s *GRPCServer) ImportRegistry(_ context.Context, request *btrpc.ImportRegistryRequest) (*btrpc.ImportRegistryResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	imported, skipped, err := s.runs.ImportRegistry(request.Registry)
+	switch {
+	case errors.Is(err, errRegistryVersion):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errInvalidRegistry):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &btrpc.ImportRegistryResponse{
+		Imported: int64(imported),
+		Skipped:  int64(skipped),
+	}, nil
+}
+
+// PreviewStrategy executes a strategy until the requested number of events
+// have been handled and returns them, allowing strategy wiring to be checked
+// without running a full backtest. Previews are not tracked as runs
+func (s *GRPCServer) PreviewStrategy(_ context.Context, request *btrpc.PreviewStrategyRequest) (*btrpc.PreviewStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.Events == 0 {
+		return nil, status.Error(codes.InvalidArgument, "events must be greater than zero")
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DataSettings.LiveData != nil {
+		return nil, status.Error(codes.InvalidArgument, "live data strategies cannot be previewed")
+	}
+	limit := request.Events
+	if limit > maxPreviewEvents {
+		limit = maxPreviewEvents
+	}
+	err = s.limits.acquireRun()
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer s.limits.releaseRun()
+
+	executor := s.strategyExecutor
+	if executor == nil {
+		executor = executeStrategy
+	}
+	resp := &btrpc.PreviewStrategyResponse{}
+	err = executor(cfg, s.BacktesterConfig, &runHooks{
+		preview: true,
+		event: func(ev common.EventHandler) bool {
+			resp.Events = append(resp.Events, &btrpc.PreviewEvent{
+				EventType: eventType(ev),
+				Exchange:  ev.GetExchange(),
+				Asset:     ev.GetAssetType().String(),
+				Pair:      ev.Pair().String(),
+				Timestamp: timestamppb.New(ev.GetTime()),
+				Reason:    ev.GetConcatReasons(),
+			})
+			return uint64(len(resp.Events)) < limit
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// eventType returns a readable name for the type of a backtester event
+func eventType(ev common.EventHandler) string {
+	switch ev.(type) {
+	case common.DataEventHandler:
+		return "data"
+	case signal.Event:
+		return "signal"
+	case order.Event:
+		return "order"
+	case fill.Event:
+		return "fill"
+	default:
+		return fmt.Sprintf("%T", ev)
+	}
+}
+
+// UpdateServerConfig applies new server limits and returns the effective
+// config. Only the limits set in the request are changed
+func (s *GRPCServer) UpdateServerConfig(_ context.Context, request *btrpc.UpdateServerConfigRequest) (*btrpc.UpdateServerConfigResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.MaxConcurrentRuns != nil && *request.MaxConcurrentRuns > math.MaxInt32 {
+		return nil, status.Errorf(codes.InvalidArgument, "max concurrent runs cannot exceed %v", math.MaxInt32)
+	}
+	if request.MaxBufferedRunLogs != nil && (*request.MaxBufferedRunLogs == 0 || *request.MaxBufferedRunLogs > maxRecentRuns) {
+		return nil, status.Errorf(codes.InvalidArgument, "max buffered run logs must be between 1 and %v", maxRecentRuns)
+	}
+	if request.MaxBufferedRunCandles != nil && (*request.MaxBufferedRunCandles == 0 || *request.MaxBufferedRunCandles > maxRecentRuns) {
+		return nil, status.Errorf(codes.InvalidArgument, "max buffered run candles must be between 1 and %v", maxRecentRuns)
+	}
+	if request.MaxRetainedRuns != nil && (*request.MaxRetainedRuns == 0 || *request.MaxRetainedRuns > math.MaxInt32) {
+		return nil, status.Errorf(codes.InvalidArgument, "max retained runs must be between 1 and %v", math.MaxInt32)
+	}
+	if request.MaxConcurrentRuns != nil {
+		s.limits.setMaxConcurrentRuns(int(*request.MaxConcurrentRuns))
+	}
+	if request.MaxBufferedRunLogs != nil {
+		err := s.runs.SetLogRetention(int(*request.MaxBufferedRunLogs))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.MaxBufferedRunCandles != nil {
+		err := s.runs.SetCandleRetention(int(*request.MaxBufferedRunCandles))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.MaxRetainedRuns != nil {
+		err := s.runs.SetRunRetention(int(*request.MaxRetainedRuns))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &btrpc.UpdateServerConfigResponse{
+		Config: &btrpc.ServerConfig{
+			MaxConcurrentRuns:     uint64(s.limits.getMaxConcurrentRuns()),
+			MaxBufferedRunLogs:    uint64(s.runs.LogRetention()),
+			MaxBufferedRunCandles: uint64(s.runs.CandleRetention()),
+			MaxRetainedRuns:       uint64(s.runs.RunRetention()),
+		},
+	}, nil
+}
+
+// RestartWithParams cancels a run if it is still running, then executes its
+// config again with custom strategy settings overridden, returning the new
+// run's ID
+func (s *GRPCServer) RestartWithParams(ctx context.Context, request *btrpc.RestartWithParamsRequest) (*btrpc.ExecuteStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.config == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v %v", errNoStoredConfig, run.ID)
+	}
+	if run.Status == RunStatusRunning {
+		err = s.runs.CancelRun(ctx, run.ID)
+		switch {
+		case errors.Is(err, errRunNotCancellable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, errRunNotRunning):
+			// the run finished before it could be cancelled
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	cfg := *run.config
+	cfg.StrategySettings.CustomSettings = make(map[string]interface{}, len(run.config.StrategySettings.CustomSettings)+len(request.CustomSettings))
+	for k, v := range run.config.StrategySettings.CustomSettings {
+		cfg.StrategySettings.CustomSettings[k] = v
+	}
+	for k, v := range customSettingsToMap(request.CustomSettings) {
+		cfg.StrategySettings.CustomSettings[k] = v
+	}
+	return s.executeRun(&cfg, run.Labels, false, 0)
+}
+
+// StreamStrategyProgress streams the progress of a run as it changes until
+// the run finishes. When a minimum interval is requested, updates arriving
+// within it are coalesced so that no two messages are sent closer together
+// than the interval. The final progress of the run is always sent
+func (s *GRPCServer) StreamStrategyProgress(request *btrpc.StreamStrategyProgressRequest, stream btrpc.BacktesterService_StreamStrategyProgressServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.MinIntervalMs < 0 {
+		return status.Errorf(codes.InvalidArgument, "min interval cannot be negative: %v", request.MinIntervalMs)
+	}
+	minInterval := time.Duration(request.MinIntervalMs) * time.Millisecond
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	updates, err := s.runs.WatchProgress(run.ID)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	defer func() {
+		if unwatchErr := s.runs.UnwatchProgress(run.ID, updates); unwatchErr != nil {
+			log.Errorf(common.Backtester, "could not stop watching progress of run %v: %v", run.ID, unwatchErr)
+		}
+	}()
+	// refetch so no update between the lookup and watching is missed
+	run, err = s.runs.GetRun(run.ID)
+	if err != nil {
+		return err
+	}
+	lastSent := time.Now()
+	err = stream.Send(runProgressToRPC(run, lastSent))
+	if err != nil {
+		return err
+	}
+	for run.Status == RunStatusRunning {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-updates:
+		case <-run.done:
+		}
+		if wait := minInterval - time.Since(lastSent); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-stream.Context().Done():
+				timer.Stop()
+				return stream.Context().Err()
+			case <-timer.C:
+			}
+		}
+		run, err = s.runs.GetRun(run.ID)
+		if err != nil {
+			return err
+		}
+		lastSent = time.Now()
+		err = stream.Send(runProgressToRPC(run, lastSent))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreviewPositionSizing reads a strategy config and returns the initial
+// position size of each of its pairs implied by its funding and sizing rules
+// without running the strategy
+func (s *GRPCServer) PreviewPositionSizing(_ context.Context, request *btrpc.PreviewPositionSizingRequest) (*btrpc.PreviewPositionSizingResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	positions, pools, err := sizePositions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.PreviewPositionSizingResponse{
+		Positions:    make([]*btrpc.PositionSize, len(positions)),
+		FundingPools: make([]*btrpc.FundingPool, len(pools)),
+	}
+	for i := range positions {
+		resp.Positions[i] = &btrpc.PositionSize{
+			ExchangeName:   positions[i].exchange,
+			Asset:          positions[i].asset.String(),
+			Base:           positions[i].pair.Base.String(),
+			Quote:          positions[i].pair.Quote.String(),
+			AvailableFunds: positions[i].available.InexactFloat64(),
+			PositionSize:   positions[i].size.InexactFloat64(),
+			LimitedBy:      positions[i].limitedBy,
+		}
+	}
+	for i := range pools {
+		resp.FundingPools[i] = &btrpc.FundingPool{
+			ExchangeName: pools[i].exchange,
+			Asset:        pools[i].asset.String(),
+			Currency:     pools[i].currency.String(),
+			InitialFunds: pools[i].funds.InexactFloat64(),
+			Allocated:    pools[i].allocated.InexactFloat64(),
+		}
+	}
+	return resp, nil
+}
+
+// StreamRunResourceUsage periodically samples the goroutine count and heap
+// usage of the server while a run executes, ending when the run finishes.
+// Samples cover the whole process, so the heap delta since the run started
+// includes allocations made by anything running alongside it
+func (s *GRPCServer) StreamRunResourceUsage(request *btrpc.StreamRunResourceUsageRequest, stream btrpc.BacktesterService_StreamRunResourceUsageServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.IntervalMs < 0 {
+		return status.Errorf(codes.InvalidArgument, "interval cannot be negative: %v", request.IntervalMs)
+	}
+	interval := resourceUsageInterval
+	if request.IntervalMs > 0 {
+		interval = time.Duration(request.IntervalMs) * time.Millisecond
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	if run.Status != RunStatusRunning {
+		return nil
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var finished bool
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-run.done:
+			finished = true
+		case <-ticker.C:
+		}
+		err = stream.Send(sampleResourceUsage(run))
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+	}
+}
+
+// GetStrategyTrades returns a page of the orders filled during a run as ledger
+// entries. Pages are limited to maxTradesPageSize trades, the next offset is
+// equal to the total trades once all have been returned
+func (s *GRPCServer) GetStrategyTrades(_ context.Context, request *btrpc.GetStrategyTradesRequest) (*btrpc.GetStrategyTradesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	limit := request.Limit
+	if limit == 0 || limit > maxTradesPageSize {
+		limit = maxTradesPageSize
+	}
+	if request.Offset > math.MaxInt32 {
+		return nil, status.Errorf(codes.InvalidArgument, "offset cannot exceed %v", math.MaxInt32)
+	}
+	trades, total, err := s.runs.GetTrades(run.ID, int(request.Offset), int(limit))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	resp := &btrpc.GetStrategyTradesResponse{
+		RunId:       run.ID.String(),
+		Trades:      make([]*btrpc.Trade, len(trades)),
+		TotalTrades: uint64(total),
+		NextOffset:  request.Offset + uint64(len(trades)),
+	}
+	if resp.NextOffset > resp.TotalTrades {
+		resp.NextOffset = resp.TotalTrades
+	}
+	for i := range trades {
+		resp.Trades[i] = trades[i].toRPC()
+	}
+	return resp, nil
+}
+
+// SaveConfigAsTemplate stores the config a run was executed with as a named
+// template, exposing the requested config fields as variables which can be
+// set by ExecuteStrategyFromTemplate
+func (s *GRPCServer) SaveConfigAsTemplate(_ context.Context, request *btrpc.SaveConfigAsTemplateRequest) (*btrpc.SaveConfigAsTemplateResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.config == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v %v", errNoStoredConfig, run.ID)
+	}
+	overwritten, err := s.templates.save(request.TemplateName, run.config, request.Variables, request.Overwrite)
+	switch {
+	case errors.Is(err, errTemplateExists):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errInvalidTemplateName), errors.Is(err, errInvalidVariablePath):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	variables := make([]string, 0, len(request.Variables))
+	for k := range request.Variables {
+		variables = append(variables, k)
+	}
+	sort.Strings(variables)
+	return &btrpc.SaveConfigAsTemplateResponse{
+		TemplateName: strings.TrimSpace(request.TemplateName),
+		Variables:    variables,
+		Overwritten:  overwritten,
+	}, nil
+}
+
+// ExecuteStrategyFromTemplate executes the config of a saved template with its
+// variables set to the supplied values
+func (s *GRPCServer) ExecuteStrategyFromTemplate(_ context.Context, request *btrpc.ExecuteStrategyFromTemplateRequest) (*btrpc.ExecuteStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.templates.render(request.TemplateName, request.Values)
+	switch {
+	case errors.Is(err, errTemplateNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errUnknownVariable),
+		errors.Is(err, errInvalidVariablePath),
+		errors.Is(err, config.ErrInvalidConfigData):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return s.executeRun(cfg, request.Labels, request.RejectDuplicateConfig, request.AbortOnDrawdownPct)
+}
+
+// ExecuteWalkForward splits the date range of a strategy config into windows,
+// optimises the requested parameters over the in sample period of each window
+// and reports the performance of the best parameters over its out of sample
+// period. The walk forward is tracked as a single run so its progress can be
+// followed and it can be cancelled
+func (s *GRPCServer) ExecuteWalkForward(_ context.Context, request *btrpc.ExecuteWalkForwardRequest) (*btrpc.ExecuteWalkForwardResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.Windows > math.MaxInt32 {
+		return nil, status.Errorf(codes.InvalidArgument, "windows cannot exceed %v", math.MaxInt32)
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := configDateRange(cfg)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	windows, err := splitWalkForwardWindows(start, end, cfg.DataSettings.Interval.Duration(), int(request.Windows), request.InSampleRatio)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	combinations, err := parameterCombinations(request.Parameters)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := HashConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = s.limits.acquireRun()
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer s.limits.releaseRun()
+	cancel := make(chan struct{})
+	run, err := s.runs.StartRun(&Run{
+		ConfigHash: hash,
+		Strategy:   cfg.StrategySettings.Name,
+		Labels:     request.Labels,
+		cancel:     cancel,
+		config:     cfg,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := s.strategyExecutor
+	if executor == nil {
+		executor = executeStrategy
+	}
+	total := int64(len(windows) * (len(combinations) + 1))
+	var processed int64
+	results, err := walkForward(cfg, s.BacktesterConfig, executor, windows, combinations, cancel, func() {
+		processed++
+		if progressErr := s.runs.UpdateProgress(run.ID, processed, total); progressErr != nil {
+			log.Error(common.Backtester, progressErr)
+		}
+	})
+	finishErr := s.runs.FinishRun(run.ID, err)
+	if finishErr == nil {
+		s.notifyWebhooks(run.ID)
+	}
+	if errors.Is(err, errRunCancelled) {
+		return nil, status.Errorf(codes.Canceled, "%v %v", err, run.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if finishErr != nil {
+		return nil, finishErr
+	}
+	resp := &btrpc.ExecuteWalkForwardResponse{
+		RunId:   run.ID.String(),
+		Windows: make([]*btrpc.WalkForwardWindow, len(results)),
+	}
+	compounded := 1.0
+	for i := range results {
+		resp.Windows[i] = results[i].toRPC(i + 1)
+		compounded *= 1 + results[i].outOfSampleReturn/100
+	}
+	resp.OutOfSampleReturnPercent = (compounded - 1) * 100
+	return resp, nil
+}
+
+// GetStrategyParameters returns the custom settings accepted by a strategy
+// along with their types, defaults and constraints. Strategies which do not
+// describe their settings return no parameters
+func (s *GRPCServer) GetStrategyParameters(_ context.Context, request *btrpc.GetStrategyParametersRequest) (*btrpc.GetStrategyParametersResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	strategy, err := strategies.LoadStrategyByName(request.StrategyName, false)
+	if errors.Is(err, base.ErrStrategyNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &btrpc.GetStrategyParametersResponse{
+		StrategyName: strategy.Name(),
+		Description:  strategy.Description(),
+	}
+	describer, ok := strategy.(strategies.ParameterDescriber)
+	if !ok {
+		return resp, nil
+	}
+	params := describer.Parameters()
+	resp.Parameters = make([]*btrpc.StrategyParameter, len(params))
+	for i := range params {
+		resp.Parameters[i] = &btrpc.StrategyParameter{
+			Name:         params[i].Name,
+			Type:         params[i].Type,
+			DefaultValue: fmt.Sprint(params[i].Default),
+			Description:  params[i].Description,
+		}
+		if params[i].Positive {
+			zero := 0.0
+			resp.Parameters[i].ExclusiveMinimum = &zero
+		}
+	}
+	return resp, nil
+}
+
+// DescribeStrategy returns the description of a strategy along with the data
+// it can operate on. Strategies which do not restrict their data are described
+// as supporting every data type and interval
+func (s *GRPCServer) DescribeStrategy(_ context.Context, request *btrpc.DescribeStrategyRequest) (*btrpc.DescribeStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	strategy, err := strategies.LoadStrategyByName(request.StrategyName, false)
+	if errors.Is(err, base.ErrStrategyNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	dataTypes := []string{common.CandleStr, common.TradeStr}
+	intervals := gctkline.SupportedIntervals
+	if describer, ok := strategy.(strategies.DataRequirementDescriber); ok {
+		if required := describer.RequiredDataTypes(); len(required) > 0 {
+			dataTypes = required
+		}
+		if supported := describer.SupportedIntervals(); len(supported) > 0 {
+			intervals = supported
+		}
+	}
+	resp := &btrpc.DescribeStrategyResponse{
+		StrategyName:                   strategy.Name(),
+		Description:                    strategy.Description(),
+		SupportsSimultaneousProcessing: strategy.SupportsSimultaneousProcessing(),
+		RequiredDataTypes:              dataTypes,
+		SupportedIntervals:             make([]uint64, len(intervals)),
+	}
+	for i := range intervals {
+		resp.SupportedIntervals[i] = uint64(intervals[i].Duration())
+	}
+	return resp, nil
+}
+
+// RunMonteCarlo bootstrap resamples the trades of a completed run to build
+// the distribution of returns and maximum drawdowns. The starting equity is the
+// first point of the run's equity curve and iterations are capped at
+// maxMonteCarloIterations. A non-zero seed makes the simulation
+// reproducible
+func (s *GRPCServer) RunMonteCarlo(_ context.Context, request *btrpc.RunMonteCarloRequest) (*btrpc.RunMonteCarloResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.Iterations == 0 {
+		return nil, status.Error(codes.InvalidArgument, errInvalidIterationAmount.Error())
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != RunStatusCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", run.ID, run.Status, RunStatusCompleted)
+	}
+	if len(run.EquityCurve) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v has no equity curve to determine starting equity", run.ID)
+	}
+	trades, _, err := s.runs.GetTrades(run.ID, 0, 0)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	iterations := request.Iterations
+	if iterations > maxMonteCarloIterations {
+		iterations = maxMonteCarloIterations
+	}
+	seed := request.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	result, err := monteCarlo(trades, run.EquityCurve[0].Equity, int(iterations), rand.New(rand.NewSource(seed))) //nolint:gosec // resampling does not require crypto/rand
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v: %v", run.ID, err)
+	}
+	return &btrpc.RunMonteCarloResponse{
+		RunId:                    run.ID.String(),
+		Iterations:               iterations,
+		TradeCount:               uint64(len(trades)),
+		ActualReturnPercent:      result.actualReturnPercent,
+		ActualMaxDrawdownPercent: result.actualMaxDrawdownPercent,
+		Percentiles:              result.toRPC(),
+	}, nil
+}
+
+// StreamSweepLeaderboard sends the completed runs of a sweep ranked by the
+// requested metric whenever a run of the sweep finishes. Runs are grouped into
+// a sweep by their SweepLabel. The stream ends once no run of the sweep is
+// running and at least the expected number of runs have finished, the final
+// update is flagged as such
+func (s *GRPCServer) StreamSweepLeaderboard(request *btrpc.StreamSweepLeaderboardRequest, stream btrpc.BacktesterService_StreamSweepLeaderboardServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.SweepId == "" {
+		return status.Error(codes.InvalidArgument, errNoSweepID.Error())
+	}
+	metric := request.Metric
+	if metric == "" {
+		metric = LeaderboardMetricReturn
+	}
+	if _, ok := leaderboardMetrics[metric]; !ok {
+		return status.Errorf(codes.InvalidArgument, "%v '%v'", errUnknownMetric, metric)
+	}
+	limit := int(request.Limit)
+	if request.Limit > math.MaxInt32 {
+		limit = math.MaxInt32
+	}
+	// subscribe before listing runs so no finished run can be missed
+	ch, _, err := s.runs.SubscribeRunEvents()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unsubErr := s.runs.UnsubscribeRunEvents(ch); unsubErr != nil {
+			log.Error(common.Backtester, unsubErr)
+		}
+	}()
+	ticker := time.NewTicker(sweepLeaderboardPollInterval)
+	defer ticker.Stop()
+	sentFinished := -1
+	for {
+		runs, err := s.runs.ListRuns(map[string]string{SweepLabel: request.SweepId})
+		if err != nil {
+			return err
+		}
+		board, err := rankRuns(runs, metric, limit)
+		if err != nil {
+			return err
+		}
+		done := board.running == 0 && board.finished > 0 && uint64(board.finished) >= request.ExpectedRuns
+		if board.finished != sentFinished {
+			update := board.toRPC(request.SweepId, metric)
+			update.Final = done
+			if err = stream.Send(update); err != nil {
+				return err
+			}
+			sentFinished = board.finished
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ch:
+		case <-ticker.C:
+		}
+	}
+}
+
+// BenchmarkAgainstHold compares the return of a completed run against buying
+// and holding each of its configured pairs over the run's date range. Alpha is
+// the strategy return less the hold return. Pairs whose candles cannot be
+// loaded report an error rather than failing the request
+func (s *GRPCServer) BenchmarkAgainstHold(_ context.Context, request *btrpc.BenchmarkAgainstHoldRequest) (*btrpc.BenchmarkAgainstHoldResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != RunStatusCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", run.ID, run.Status, RunStatusCompleted)
+	}
+	if run.config == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v %v", errNoStoredConfig, run.ID)
+	}
+	start, end, err := configDateRange(run.config)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v: %v", run.ID, err)
+	}
+	source := s.candleSource
+	if source == nil {
+		source = databaseCandles
+	}
+	resp := &btrpc.BenchmarkAgainstHoldResponse{
+		RunId:                 run.ID.String(),
+		StartDate:             timestamppb.New(start),
+		EndDate:               timestamppb.New(end),
+		StrategyReturnPercent: equityReturnPercent(run.EquityCurve),
+		Benchmarks:            make([]*btrpc.HoldBenchmark, len(run.config.CurrencySettings)),
+	}
+	for i := range run.config.CurrencySettings {
+		cs := &run.config.CurrencySettings[i]
+		benchmark := &btrpc.HoldBenchmark{
+			ExchangeName: cs.ExchangeName,
+			Asset:        cs.Asset.String(),
+			Base:         cs.Base.String(),
+			Quote:        cs.Quote.String(),
+		}
+		resp.Benchmarks[i] = benchmark
+		candles, err := source(cs.ExchangeName, currency.NewPair(cs.Base, cs.Quote), cs.Asset, run.config.DataSettings.Interval, start, end)
+		if err != nil {
+			benchmark.Error = err.Error()
+			continue
+		}
+		stats, err := analyseCandles(candles)
+		if err != nil {
+			benchmark.Error = err.Error()
+			continue
+		}
+		benchmark.HoldReturnPercent = stats.returnPercent
+		benchmark.AlphaPercent = resp.StrategyReturnPercent - stats.returnPercent
+	}
+	return resp, nil
+}
+
+// EstimateDataDownload returns the approximate number of candles and bytes a
+// strategy config will load for each of its currencies over the configured
+// date range, warning when the total is larger than largeDownloadBytes
+func (s *GRPCServer) EstimateDataDownload(_ context.Context, request *btrpc.EstimateDataDownloadRequest) (*btrpc.EstimateDataDownloadResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := configDateRange(cfg)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	estimates, err := estimateDownload(cfg)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &btrpc.EstimateDataDownloadResponse{
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(end),
+		Estimates: make([]*btrpc.DataDownloadEstimate, len(estimates)),
+	}
+	for i := range estimates {
+		resp.Estimates[i] = &btrpc.DataDownloadEstimate{
+			ExchangeName: estimates[i].exchange,
+			Asset:        estimates[i].asset.String(),
+			Base:         estimates[i].pair.Base.String(),
+			Quote:        estimates[i].pair.Quote.String(),
+			Interval:     uint64(estimates[i].interval),
+			Candles:      uint64(estimates[i].candles),
+			Bytes:        uint64(estimates[i].bytes),
+		}
+		resp.TotalCandles += uint64(estimates[i].candles)
+		resp.TotalBytes += uint64(estimates[i].bytes)
+	}
+	if resp.TotalBytes > largeDownloadBytes {
+		resp.Warning = fmt.Sprintf("estimated download of %v bytes exceeds %v bytes", resp.TotalBytes, largeDownloadBytes)
+	}
+	return resp, nil
+}
+
+// VerifyDeterminism executes a strategy config the requested number of times
+// and reports whether every execution produced identical summary metrics,
+// flagging the first divergence. The executions are tracked as a single run
+// which can be cancelled
+func (s *GRPCServer) VerifyDeterminism(_ context.Context, request *btrpc.VerifyDeterminismRequest) (*btrpc.VerifyDeterminismResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.Runs < 2 || request.Runs > maxDeterminismRuns {
+		return nil, status.Errorf(codes.InvalidArgument, "runs must be between 2 and %v", maxDeterminismRuns)
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := HashConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = s.limits.acquireRun()
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer s.limits.releaseRun()
+	cancel := make(chan struct{})
+	run, err := s.runs.StartRun(&Run{
+		ConfigHash: hash,
+		Strategy:   cfg.StrategySettings.Name,
+		Labels:     request.Labels,
+		cancel:     cancel,
+		config:     cfg,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := s.strategyExecutor
+	if executor == nil {
+		executor = executeStrategy
+	}
+	total := int64(request.Runs)
+	var processed int64
+	summaries, diverged, err := compareRepeatedRuns(cfg, s.BacktesterConfig, executor, int(request.Runs), cancel, func() {
+		processed++
+		if progressErr := s.runs.UpdateProgress(run.ID, processed, total); progressErr != nil {
+			log.Error(common.Backtester, progressErr)
+		}
+	})
+	finishErr := s.runs.FinishRun(run.ID, err)
+	if finishErr == nil {
+		s.notifyWebhooks(run.ID)
+	}
+	if errors.Is(err, errRunCancelled) {
+		return nil, status.Errorf(codes.Canceled, "%v %v", err, run.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if finishErr != nil {
+		return nil, finishErr
+	}
+	resp := &btrpc.VerifyDeterminismResponse{
+		RunId:         run.ID.String(),
+		Deterministic: diverged == nil,
+		Summaries:     make([]*btrpc.DeterminismSummary, len(summaries)),
+	}
+	for i := range summaries {
+		resp.Summaries[i] = &btrpc.DeterminismSummary{
+			Run:           uint64(i + 1),
+			FinalEquity:   summaries[i].finalEquity,
+			ReturnPercent: summaries[i].returnPercent,
+			Trades:        uint64(summaries[i].trades),
+			Volume:        summaries[i].volume,
+			Fees:          summaries[i].fees,
+		}
+	}
+	if diverged != nil {
+		resp.DivergentRun = uint64(diverged.run)
+		resp.DivergentMetric = diverged.metric
+		resp.ExpectedValue = diverged.expected
+		resp.ReceivedValue = diverged.received
+	}
+	return resp, nil
+}
+
+// PauseStrategy blocks a running run from processing further events until it
+// is resumed via ResumeStrategy. Paused runs are reported as such by the run
+// status RPCs and can still be cancelled
+func (s *GRPCServer) PauseStrategy(_ context.Context, request *btrpc.PauseStrategyRequest) (*btrpc.PauseStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.runs.PauseRun(run.ID)
+	if err != nil {
+		return nil, pauseErrorToStatus(err)
+	}
+	return &btrpc.PauseStrategyResponse{RunId: run.ID.String(), Paused: true}, nil
+}
+
+// ResumeStrategy releases a run paused via PauseStrategy
+func (s *GRPCServer) ResumeStrategy(_ context.Context, request *btrpc.ResumeStrategyRequest) (*btrpc.ResumeStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.runs.ResumeRun(run.ID)
+	if err != nil {
+		return nil, pauseErrorToStatus(err)
+	}
+	return &btrpc.ResumeStrategyResponse{RunId: run.ID.String(), Paused: false}, nil
+}
+
+// pauseErrorToStatus converts errors from pausing or resuming a run to their
+// GRPC status equivalents
+func pauseErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errRunNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errRunNotRunning),
+		errors.Is(err, errRunNotPausable),
+		errors.Is(err, errRunAlreadyPaused),
+		errors.Is(err, errRunNotPaused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// StreamDrawdownPeriods sends each drawdown episode of a completed run's equity
+// curve in time order, omitting episodes which fell less than the minimum
+// drawdown percent
+func (s *GRPCServer) StreamDrawdownPeriods(request *btrpc.StreamDrawdownPeriodsRequest, stream btrpc.BacktesterService_StreamDrawdownPeriodsServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.MinDrawdownPercent < 0 {
+		return status.Errorf(codes.InvalidArgument, "min drawdown percent cannot be negative: %v", request.MinDrawdownPercent)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	if run.Status != RunStatusCompleted {
+		return status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", run.ID, run.Status, RunStatusCompleted)
+	}
+	periods := drawdownPeriods(run.EquityCurve, request.MinDrawdownPercent)
+	for i := range periods {
+		err = stream.Send(periods[i].toRPC())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRollingMetrics calculates the return, maximum drawdown and sharpe ratio
+// of a completed run over rolling windows of its equity curve. Windows advance
+// by the step, which defaults to the window size for consecutive windows
+func (s *GRPCServer) GetRollingMetrics(_ context.Context, request *btrpc.GetRollingMetricsRequest) (*btrpc.GetRollingMetricsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	window := time.Duration(request.WindowSeconds) * time.Second
+	step := window
+	if request.StepSeconds != 0 {
+		step = time.Duration(request.StepSeconds) * time.Second
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != RunStatusCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", run.ID, run.Status, RunStatusCompleted)
+	}
+	count, err := rollingWindowCount(run.EquityCurve, window, step)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if count > maxRollingWindows {
+		return nil, status.Errorf(codes.InvalidArgument, "%v windows exceeds maximum of %v", count, maxRollingWindows)
+	}
+	windows, err := rollingWindows(run.EquityCurve, window, step)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &btrpc.GetRollingMetricsResponse{
+		RunId:   run.ID.String(),
+		Windows: make([]*btrpc.RollingMetricsWindow, len(windows)),
+	}
+	for i := range windows {
+		resp.Windows[i] = windows[i].toRPC()
+	}
+	return resp, nil
+}
+
+// ExecuteStrategyAcrossPairs executes a single currency strategy config once
+// for each requested pair, returning the run ID or error of each pair in the
+// order requested. At most max concurrency runs execute at once, limited
+// further by the server's maximum concurrent runs
+func (s *GRPCServer) ExecuteStrategyAcrossPairs(_ context.Context, request *btrpc.ExecuteStrategyAcrossPairsRequest) (*btrpc.ExecuteStrategyAcrossPairsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if len(request.Pairs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no pairs to run")
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*config.Config, len(request.Pairs))
+	for i := range request.Pairs {
+		pair, err := currency.NewPairFromString(request.Pairs[i])
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pair '%v': %v", request.Pairs[i], err)
+		}
+		configs[i], err = configForPair(cfg, pair)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	workers := len(configs)
+	if request.MaxConcurrency > 0 && request.MaxConcurrency < uint64(workers) {
+		workers = int(request.MaxConcurrency)
+	}
+	if limit := s.limits.getMaxConcurrentRuns(); limit > 0 && limit < workers {
+		workers = limit
+	}
+	resp := &btrpc.ExecuteStrategyAcrossPairsResponse{
+		Runs: make([]*btrpc.PairRun, len(configs)),
+	}
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := range configs {
+		labels := make(map[string]string, len(request.Labels))
+		for k, v := range request.Labels {
+			labels[k] = v
+		}
+		resp.Runs[i] = &btrpc.PairRun{Pair: request.Pairs[i]}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(cfg *config.Config, result *btrpc.PairRun) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			executed, err := s.executeRun(cfg, labels, false, 0)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.RunId = executed.RunId
+		}(configs[i], resp.Runs[i])
+	}
+	wg.Wait()
+	return resp, nil
+}
+
+// SetBaselineRun marks a completed run as the baseline which CompareRuns
+// compares against when no other run is requested
+func (s *GRPCServer) SetBaselineRun(_ context.Context, request *btrpc.SetBaselineRunRequest) (*btrpc.SetBaselineRunResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.runs.SetBaseline(run.ID)
+	switch {
+	case errors.Is(err, errRunNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errRunNotCompleted):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	resp := &btrpc.SetBaselineRunResponse{RunId: run.ID.String()}
+	if !previous.IsNil() {
+		resp.PreviousRunId = previous.String()
+	}
+	return resp, nil
+}
+
+// CompareRuns compares the summary metrics of two completed runs. When no
+// other run is requested the run is compared against the baseline run set via
+// SetBaselineRun. Differences are the run's metrics minus the other run's
+func (s *GRPCServer) CompareRuns(_ context.Context, request *btrpc.CompareRunsRequest) (*btrpc.CompareRunsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	otherID := request.OtherRunId
+	isBaseline := otherID == ""
+	if isBaseline {
+		baseline, err := s.runs.Baseline()
+		if errors.Is(err, errNoBaseline) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+		otherID = baseline.String()
+	}
+	other, err := s.getRun(otherID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range []*Run{run, other} {
+		if r.Status != RunStatusCompleted {
+			return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", r.ID, r.Status, RunStatusCompleted)
+		}
+	}
+	current, compared := summariseRun(run), summariseRun(other)
+	difference := current.difference(&compared)
+	return &btrpc.CompareRunsResponse{
+		RunId:           run.ID.String(),
+		OtherRunId:      other.ID.String(),
+		OtherIsBaseline: isBaseline,
+		Run:             current.toRPC(),
+		Other:           compared.toRPC(),
+		Difference:      difference.toRPC(),
+	}, nil
+}
+
+// StreamRunCandles streams the candles a run consumed for an exchange, asset
+// and pair in time order, optionally limited to a date range. Candles are
+// available while the run is executing, limited to those consumed so far
+func (s *GRPCServer) StreamRunCandles(request *btrpc.StreamRunCandlesRequest, stream btrpc.BacktesterService_StreamRunCandlesServer) error {
+	if request == nil {
+		return fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.ExchangeName == "" || request.Base == "" || request.Quote == "" {
+		return status.Error(codes.InvalidArgument, "exchange name, base and quote are required")
+	}
+	a, err := asset.New(request.Asset)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var start, end time.Time
+	if request.StartDate != nil {
+		start = request.StartDate.AsTime()
+	}
+	if request.EndDate != nil {
+		end = request.EndDate.AsTime()
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return status.Errorf(codes.InvalidArgument, "end date %v is before start date %v", end, start)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return err
+	}
+	pair := currency.NewPair(currency.NewCode(request.Base), currency.NewCode(request.Quote))
+	candles, err := s.runs.GetCandles(run.ID, request.ExchangeName, a, pair, start, end)
+	if errors.Is(err, errCandlesEvicted) || errors.Is(err, errRunNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	for i := range candles {
+		err = stream.Send(candles[i].toRPC())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ComputeRunCorrelation returns the pearson correlation of the equity curve
+// returns of two completed runs. Curves are aligned on the times both runs
+// recorded equity, so runs over different date ranges or intervals only
+// compare their overlap
+func (s *GRPCServer) ComputeRunCorrelation(_ context.Context, request *btrpc.ComputeRunCorrelationRequest) (*btrpc.ComputeRunCorrelationResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	other, err := s.getRun(request.OtherRunId)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range []*Run{run, other} {
+		if r.Status != RunStatusCompleted {
+			return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v, expecting %v", r.ID, r.Status, RunStatusCompleted)
+		}
+	}
+	correlation, aligned, err := equityCorrelation(run.EquityCurve, other.EquityCurve)
+	if errors.Is(err, errTooFewAlignedPoints) || errors.Is(err, errNoVariance) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.ComputeRunCorrelationResponse{
+		RunId:         run.ID.String(),
+		OtherRunId:    other.ID.String(),
+		Correlation:   correlation,
+		AlignedPoints: uint64(aligned),
+	}, nil
+}
+
+// GetRunFeeSchedule returns the maker and taker fee rates applied to each
+// exchange, asset and pair of a finished run. Fees which were not overridden in
+// the strategy config are populated from the exchange during setup and are
+// flagged as such
+func (s *GRPCServer) GetRunFeeSchedule(_ context.Context, request *btrpc.GetRunFeeScheduleRequest) (*btrpc.GetRunFeeScheduleResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status == RunStatusRunning {
+		return nil, status.Errorf(codes.FailedPrecondition, "run %v is %v", run.ID, run.Status)
+	}
+	if run.config == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v %v", errNoStoredConfig, run.ID)
+	}
+	fees := make([]*btrpc.RunFeeRate, len(run.config.CurrencySettings))
+	for i := range run.config.CurrencySettings {
+		cs := &run.config.CurrencySettings[i]
+		makerFee, takerFee := decimal.Zero, decimal.Zero
+		if cs.MakerFee != nil {
+			makerFee = *cs.MakerFee
+		}
+		if cs.TakerFee != nil {
+			takerFee = *cs.TakerFee
+		}
+		fees[i] = &btrpc.RunFeeRate{
+			ExchangeName:          cs.ExchangeName,
+			Asset:                 cs.Asset.String(),
+			Base:                  cs.Base.String(),
+			Quote:                 cs.Quote.String(),
+			MakerFee:              makerFee.String(),
+			TakerFee:              takerFee.String(),
+			UsingExchangeMakerFee: cs.UsingExchangeMakerFee,
+			UsingExchangeTakerFee: cs.UsingExchangeTakerFee,
+		}
+	}
+	return &btrpc.GetRunFeeScheduleResponse{
+		RunId: run.ID.String(),
+		Fees:  fees,
+	}, nil
+}
+
+// ScheduleStrategy reads the strategy config from the filepath provided and
+// executes it once the start time arrives, returning an ID which can be
+// passed to CancelStrategy before then. Scheduled runs are held in memory and
+// do not survive a server restart
+func (s *GRPCServer) ScheduleStrategy(_ context.Context, request *btrpc.ScheduleStrategyRequest) (*btrpc.ScheduleStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.StartTime == nil {
+		return nil, status.Error(codes.InvalidArgument, "start time is required")
+	}
+	err := s.checkAcceptingRuns()
+	if err != nil {
+		return nil, err
+	}
+	if request.AbortOnDrawdownPct < 0 || request.AbortOnDrawdownPct > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "abort on drawdown percent must be between 0 and 100, received %v", request.AbortOnDrawdownPct)
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(request.StrategyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	c := s.clock
+	if c == nil {
+		c = systemClock{}
+	}
+	startTime := request.StartTime.AsTime()
+	id, err := s.schedules.schedule(c, startTime, func() {
+		if runErr := s.checkAcceptingRuns(); runErr != nil {
+			log.Errorf(common.Backtester, "scheduled run of %v not started: %v", request.StrategyFilePath, runErr)
+			return
+		}
+		if _, runErr := s.executeRun(cfg, request.Labels, request.RejectDuplicateConfig, request.AbortOnDrawdownPct); runErr != nil {
+			log.Errorf(common.Backtester, "scheduled run of %v: %v", request.StrategyFilePath, runErr)
+		}
+	})
+	if errors.Is(err, errScheduleInPast) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.ScheduleStrategyResponse{
+		ScheduledId: id.String(),
+		StartTime:   timestamppb.New(startTime),
+	}, nil
+}
+
+// CancelStrategy cancels a scheduled run which has not yet started. Started
+// runs are no longer scheduled, so cancelling them returns NotFound
+func (s *GRPCServer) CancelStrategy(_ context.Context, request *btrpc.CancelStrategyRequest) (*btrpc.CancelStrategyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	id, err := uuid.FromString(request.ScheduledId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scheduled id '%v': %v", request.ScheduledId, err)
+	}
+	startTime, err := s.schedules.cancel(id)
+	if errors.Is(err, errScheduleNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.CancelStrategyResponse{
+		ScheduledId: id.String(),
+		StartTime:   timestamppb.New(startTime),
+	}, nil
+}
+
+// RecordSession starts recording a session when no session ID is supplied,
+// returning its ID. Run executing calls made with the ID set in the
+// backtester-session-id metadata are then recorded in order until the session
+// is stopped by calling RecordSession with its ID and stop set. Calls which
+// change server state are not recorded, and only the latest maxSessions
+// sessions of up to maxSessionCalls calls are kept
+func (s *GRPCServer) RecordSession(_ context.Context, request *btrpc.RecordSessionRequest) (*btrpc.RecordSessionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	if request.SessionId == "" {
+		if request.Stop {
+			return nil, status.Error(codes.InvalidArgument, "a session id is required to stop recording")
+		}
+		id, err := s.sessions.start()
+		if err != nil {
+			return nil, err
+		}
+		return &btrpc.RecordSessionResponse{
+			SessionId: id.String(),
+			Recording: true,
+		}, nil
+	}
+	if !request.Stop {
+		return nil, status.Error(codes.InvalidArgument, "a session id can only be supplied to stop recording")
+	}
+	id, err := uuid.FromString(request.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session id '%v': %v", request.SessionId, err)
+	}
+	calls, err := s.sessions.stop(id)
+	if errors.Is(err, errSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.RecordSessionResponse{
+		SessionId: id.String(),
+		Calls:     uint64(calls),
+	}, nil
+}
+
+// ReplaySession makes each call recorded in the session again in order,
+// returning every call's response or error. A failed call does not stop the
+// replay and replayed calls are not recorded
+func (s *GRPCServer) ReplaySession(ctx context.Context, request *btrpc.ReplaySessionRequest) (*btrpc.ReplaySessionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	id, err := uuid.FromString(request.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session id '%v': %v", request.SessionId, err)
+	}
+	calls, err := s.sessions.getCalls(id)
+	if errors.Is(err, errSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	replayed := make([]*btrpc.ReplayedCall, len(calls))
+	for i := range calls {
+		if err = ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		replayed[i] = &btrpc.ReplayedCall{Method: calls[i].method}
+		resp, callErr := s.replayCall(ctx, &calls[i])
+		if callErr != nil {
+			replayed[i].Error = callErr.Error()
+			continue
+		}
+		replayed[i].Response, err = anypb.New(resp)
+		if err != nil {
+			return nil, err
+		}
+		replayed[i].Success = true
+	}
+	return &btrpc.ReplaySessionResponse{
+		SessionId: id.String(),
+		Calls:     replayed,
+	}, nil
+}
+
+// GetRunProvenance returns the version and commit of the backtester build
+// which executed the run, tying its results to the code which produced them.
+// Runs imported from a registry written before builds were recorded have no
+// provenance
+func (s *GRPCServer) GetRunProvenance(_ context.Context, request *btrpc.GetRunProvenanceRequest) (*btrpc.GetRunProvenanceResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w nil request", common.ErrNilArguments)
+	}
+	run, err := s.getRun(request.RunId)
+	if err != nil {
+		return nil, err
+	}
+	return &btrpc.GetRunProvenanceResponse{
+		RunId:      run.ID.String(),
+		Version:    run.BuildInfo.Version,
+		Commit:     run.BuildInfo.Commit,
+		GoVersion:  run.BuildInfo.GoVersion,
+		ConfigHash: run.ConfigHash,
+	}, nil
+}
+
+// getRun parses the run ID and returns the matching run, converting errors
+// to their GRPC status equivalents
+func (s *GRPCServer) getRun(runID string) (*Run, error) {
+	id, err := uuid.FromString(runID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid run id '%v': %v", runID, err)
+	}
+	run, err := s.runs.GetRun(id)
+	if errors.Is(err, errRunNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return run, err
+}
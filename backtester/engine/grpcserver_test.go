@@ -1,16 +1,48 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	evkline "github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -283,3 +315,3432 @@ func TestExecuteStrategyFromConfig(t *testing.T) {
 		t.Error("expected an error from a bad setup")
 	}
 }
+
+func TestExecuteStrategyRejectDuplicateConfig(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	}
+	request := &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath:      dcaConfigPath,
+		RejectDuplicateConfig: true,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var resp *btrpc.ExecuteStrategyResponse
+	var respErr error
+	go func() {
+		defer wg.Done()
+		resp, respErr = s.ExecuteStrategyFromFile(context.Background(), request)
+	}()
+	<-started
+
+	_, err := s.ExecuteStrategyFromFile(context.Background(), request)
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.AlreadyExists {
+		t.Errorf("received '%v' expecting '%v'", err, codes.AlreadyExists)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(st.Details()), 1)
+	}
+	detail, ok := st.Details()[0].(*btrpc.ExecuteStrategyResponse)
+	if !ok {
+		t.Fatalf("received '%T' expecting '%T'", st.Details()[0], detail)
+	}
+
+	close(release)
+	wg.Wait()
+	if !errors.Is(respErr, nil) {
+		t.Fatalf("received '%v' expecting '%v'", respErr, nil)
+	}
+	if detail.RunId != resp.RunId {
+		t.Errorf("received '%v' expecting '%v'", detail.RunId, resp.RunId)
+	}
+
+	// the original run has completed so the config may be run again
+	resp, err = s.ExecuteStrategyFromFile(context.Background(), request)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.RunId == detail.RunId {
+		t.Error("expected a new run ID")
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			return nil
+		},
+	}
+	_, err := s.ListRuns(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	labels := map[string]string{"experiment": "1337", "note": "moon"}
+	labelled, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+		Labels:           labels,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	resp, err := s.ListRuns(context.Background(), &btrpc.ListRunsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Runs) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(resp.Runs), 2)
+	}
+
+	resp, err = s.ListRuns(context.Background(), &btrpc.ListRunsRequest{
+		Labels: map[string]string{"experiment": "1337"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Runs) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Runs), 1)
+	}
+	if resp.Runs[0].Id != labelled.RunId {
+		t.Errorf("received '%v' expecting '%v'", resp.Runs[0].Id, labelled.RunId)
+	}
+	if resp.Runs[0].Labels["note"] != "moon" {
+		t.Errorf("received '%v' expecting '%v'", resp.Runs[0].Labels["note"], "moon")
+	}
+	if resp.Runs[0].Status != RunStatusCompleted {
+		t.Errorf("received '%v' expecting '%v'", resp.Runs[0].Status, RunStatusCompleted)
+	}
+}
+
+func TestGetRunProgress(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.progress(50, 100)
+			close(started)
+			<-release
+			return nil
+		},
+	}
+	_, err := s.GetRunProgress(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: "1337"})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if !errors.Is(execErr, nil) {
+			t.Errorf("received '%v' expecting '%v'", execErr, nil)
+		}
+	}()
+	<-started
+
+	s.runs.m.Lock()
+	runID := s.runs.runs[0].ID
+	s.runs.runs[0].StartTime = time.Now().Add(-time.Minute)
+	s.runs.m.Unlock()
+
+	resp, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: runID.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.PercentComplete != 50 {
+		t.Errorf("received '%v' expecting '%v'", resp.PercentComplete, 50)
+	}
+	if resp.EtaSeconds == nil {
+		t.Fatal("expected an ETA at 50 percent")
+	}
+	if *resp.EtaSeconds < 55 || *resp.EtaSeconds > 65 {
+		t.Errorf("received '%v' expecting roughly '%v'", *resp.EtaSeconds, 60)
+	}
+
+	close(release)
+	wg.Wait()
+	resp, err = s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: runID.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.PercentComplete != 100 {
+		t.Errorf("received '%v' expecting '%v'", resp.PercentComplete, 100)
+	}
+	if resp.EtaSeconds != nil {
+		t.Errorf("received '%v' expecting no ETA", *resp.EtaSeconds)
+	}
+}
+
+type fakeEquityCurveStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	points []*btrpc.EquityPoint
+}
+
+func (f *fakeEquityCurveStream) Send(p *btrpc.EquityPoint) error {
+	f.points = append(f.points, p)
+	return nil
+}
+
+func (f *fakeEquityCurveStream) Context() context.Context {
+	return f.ctx
+}
+
+// newBufconnClient serves the server over an in memory listener using its
+// interceptors and returns a client connected to it
+func newBufconnClient(t *testing.T, s *GRPCServer) btrpc.BacktesterServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(s.interceptors()...)
+	btrpc.RegisterBacktesterServiceServer(srv, s)
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			t.Error(err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	t.Cleanup(func() {
+		if err := conn.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return btrpc.NewBacktesterServiceClient(conn)
+}
+
+// withBasicAuth returns a context carrying basic auth credentials
+func withBasicAuth(ctx context.Context, username, password string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+crypto.Base64Encode([]byte(username+":"+password)))
+}
+
+func TestStreamAuthentication(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{BacktesterConfig: &config.BacktesterConfig{
+		GRPC: config.GRPC{Username: "rpcuser", Password: "helloImTheDefaultPassword"},
+	}}
+	client := newBufconnClient(t, s)
+
+	stream, err := client.StreamEquityCurve(context.Background(), &btrpc.StreamEquityCurveRequest{RunId: uuid.Nil.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = stream.Recv()
+	if st, _ := status.FromError(err); st.Message() != "authorization header missing" {
+		t.Errorf("received '%v' expecting an authorization error", err)
+	}
+
+	stream, err = client.StreamEquityCurve(withBasicAuth(context.Background(), "rpcuser", "wrong"), &btrpc.StreamEquityCurveRequest{RunId: uuid.Nil.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = stream.Recv()
+	if st, _ := status.FromError(err); st.Code() == codes.NotFound {
+		t.Errorf("received '%v' expecting an authorization error", err)
+	}
+
+	stream, err = client.StreamEquityCurve(withBasicAuth(context.Background(), "rpcuser", "helloImTheDefaultPassword"), &btrpc.StreamEquityCurveRequest{RunId: uuid.Nil.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = stream.Recv()
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+}
+
+func TestStreamEquityCurve(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for i := 0; i < 100; i++ {
+				tt := start.Add(time.Duration(i) * time.Hour)
+				hooks.equity("binance spot BTC-USDT", tt, decimal.NewFromInt(int64(1000+i)))
+				hooks.equity("binance spot ETH-USDT", tt, decimal.NewFromInt(int64(500+i)))
+			}
+			return nil
+		},
+	}
+	stream := &fakeEquityCurveStream{ctx: context.Background()}
+	err := s.StreamEquityCurve(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = s.StreamEquityCurve(&btrpc.StreamEquityCurveRequest{RunId: uuid.Nil.String()}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	err = s.StreamEquityCurve(&btrpc.StreamEquityCurveRequest{RunId: uuid.Nil.String(), MaxPoints: -1}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	err = s.StreamEquityCurve(&btrpc.StreamEquityCurveRequest{RunId: resp.RunId}, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.points) != 100 {
+		t.Fatalf("received '%v' expecting '%v'", len(stream.points), 100)
+	}
+	if stream.points[99].Equity != 1099+599 {
+		t.Errorf("received '%v' expecting '%v'", stream.points[99].Equity, 1099+599)
+	}
+	for i := 1; i < len(stream.points); i++ {
+		if !stream.points[i].Timestamp.AsTime().After(stream.points[i-1].Timestamp.AsTime()) {
+			t.Fatalf("timestamp '%v' at index %v is not after '%v'",
+				stream.points[i].Timestamp.AsTime(), i, stream.points[i-1].Timestamp.AsTime())
+		}
+	}
+
+	stream = &fakeEquityCurveStream{ctx: context.Background()}
+	err = s.StreamEquityCurve(&btrpc.StreamEquityCurveRequest{RunId: resp.RunId, MaxPoints: 10}, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.points) != 10 {
+		t.Fatalf("received '%v' expecting '%v'", len(stream.points), 10)
+	}
+	for i := 1; i < len(stream.points); i++ {
+		if !stream.points[i].Timestamp.AsTime().After(stream.points[i-1].Timestamp.AsTime()) {
+			t.Fatalf("timestamp '%v' at index %v is not after '%v'",
+				stream.points[i].Timestamp.AsTime(), i, stream.points[i-1].Timestamp.AsTime())
+		}
+	}
+	if !stream.points[9].Timestamp.AsTime().Equal(start.Add(99 * time.Hour)) {
+		t.Errorf("received '%v' expecting '%v'", stream.points[9].Timestamp.AsTime(), start.Add(99*time.Hour))
+	}
+}
+
+type fakeRunEventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *btrpc.RunEvent
+}
+
+func (f *fakeRunEventStream) Send(ev *btrpc.RunEvent) error {
+	f.events <- ev
+	return nil
+}
+
+func (f *fakeRunEventStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestSubscribeRunEvents(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			return nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeRunEventStream{ctx: ctx, events: make(chan *btrpc.RunEvent, 10)}
+	err := s.SubscribeRunEvents(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	active, err := s.runs.StartRun(&Run{ConfigHash: "hash", Strategy: "active"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	subErr := make(chan error, 1)
+	go func() {
+		subErr <- s.SubscribeRunEvents(&btrpc.SubscribeRunEventsRequest{ReplayActive: true}, stream)
+	}()
+	ev := <-stream.events
+	if ev.Event != RunEventStarted || ev.RunId != active.ID.String() {
+		t.Errorf("received '%v %v' expecting '%v %v'", ev.Event, ev.RunId, RunEventStarted, active.ID)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ev = <-stream.events
+	if ev.Event != RunEventStarted || ev.RunId != resp.RunId {
+		t.Errorf("received '%v %v' expecting '%v %v'", ev.Event, ev.RunId, RunEventStarted, resp.RunId)
+	}
+	if ev.StrategyName == "" {
+		t.Error("expected strategy name to be set")
+	}
+	ev = <-stream.events
+	if ev.Event != RunStatusCompleted || ev.RunId != resp.RunId {
+		t.Errorf("received '%v %v' expecting '%v %v'", ev.Event, ev.RunId, RunStatusCompleted, resp.RunId)
+	}
+
+	cancel()
+	err = <-subErr
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("received '%v' expecting '%v'", err, context.Canceled)
+	}
+	s.runs.m.Lock()
+	if len(s.runs.subscribers) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(s.runs.subscribers), 0)
+	}
+	s.runs.m.Unlock()
+}
+
+func TestExportResults(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(1000))
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromFloat(1337.5))
+			return nil
+		},
+	}
+	_, err := s.ExportResults(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ExportResults(context.Background(), &btrpc.ExportResultsRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.ExportResults(context.Background(), &btrpc.ExportResultsRequest{RunId: uuid.Nil.String(), Formats: []string{ExportFormatJSON}})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	run, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.ExportResults(context.Background(), &btrpc.ExportResultsRequest{RunId: run.RunId, Formats: []string{"xlsx"}})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.ExportResults(context.Background(), &btrpc.ExportResultsRequest{
+		RunId:   run.RunId,
+		Formats: []string{ExportFormatJSON, "CSV"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Exports) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Exports), 2)
+	}
+	var exported Run
+	err = json.Unmarshal(resp.Exports[ExportFormatJSON], &exported)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if exported.ID.String() != run.RunId {
+		t.Errorf("received '%v' expecting '%v'", exported.ID, run.RunId)
+	}
+	if len(exported.EquityCurve) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(exported.EquityCurve), 2)
+	}
+	expectedCSV := "time,equity\n2022-01-01T00:00:00Z,1000\n2022-01-01T01:00:00Z,1337.5\n"
+	if string(resp.Exports[ExportFormatCSV]) != expectedCSV {
+		t.Errorf("received '%v' expecting '%v'", string(resp.Exports[ExportFormatCSV]), expectedCSV)
+	}
+
+	id, err := uuid.FromString(run.RunId)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := 2; i < 200000; i++ {
+		err = s.runs.RecordEquity(id, "binance spot BTC-USDT", start.Add(time.Duration(i)*time.Hour), float64(i))
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	_, err = s.ExportResults(context.Background(), &btrpc.ExportResultsRequest{
+		RunId:   run.RunId,
+		Formats: []string{ExportFormatJSON, ExportFormatCSV},
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.ResourceExhausted {
+		t.Errorf("received '%v' expecting '%v'", err, codes.ResourceExhausted)
+	} else if !strings.Contains(st.Message(), "StreamEquityCurve") || !strings.Contains(st.Message(), "GetStrategyTrades") {
+		t.Errorf("received '%v' expecting the streaming alternatives to be named", st.Message())
+	}
+}
+
+func TestGetDefaultConfig(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.GetDefaultConfig(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetDefaultConfig(context.Background(), &btrpc.GetDefaultConfigRequest{StrategyName: "1337"})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	resp, err := s.GetDefaultConfig(context.Background(), &btrpc.GetDefaultConfigRequest{StrategyName: "dollarcostaverage"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	var cfg config.Config
+	err = json.Unmarshal(resp.Config, &cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if cfg.StrategySettings.Name != "dollarcostaverage" {
+		t.Errorf("received '%v' expecting '%v'", cfg.StrategySettings.Name, "dollarcostaverage")
+	}
+	err = cfg.Validate()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestGetRecentRuns(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(110))
+			return nil
+		},
+	}
+	_, err := s.GetRecentRuns(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetRecentRuns(context.Background(), &btrpc.GetRecentRunsRequest{})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	// running runs are excluded
+	_, err = s.runs.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	s.runs.m.Lock()
+	// finish the runs out of order
+	s.runs.runs[0].EndTime = start.Add(time.Hour)
+	s.runs.runs[1].EndTime = start.Add(time.Hour * 3)
+	s.runs.runs[2].EndTime = start.Add(time.Hour * 2)
+	expected := []string{s.runs.runs[1].ID.String(), s.runs.runs[2].ID.String(), s.runs.runs[0].ID.String()}
+	s.runs.m.Unlock()
+
+	resp, err := s.GetRecentRuns(context.Background(), &btrpc.GetRecentRunsRequest{Limit: 1337})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Runs) != len(expected) {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Runs), len(expected))
+	}
+	for i := range expected {
+		if resp.Runs[i].Id != expected[i] {
+			t.Errorf("received '%v' expecting '%v' at index %v", resp.Runs[i].Id, expected[i], i)
+		}
+		if i > 0 && resp.Runs[i].EndTime.AsTime().After(resp.Runs[i-1].EndTime.AsTime()) {
+			t.Errorf("run at index %v finished after run at index %v", i, i-1)
+		}
+	}
+	if resp.Runs[0].ReturnPercent == nil || math.Abs(*resp.Runs[0].ReturnPercent-10) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", resp.Runs[0].ReturnPercent, 10)
+	}
+
+	resp, err = s.GetRecentRuns(context.Background(), &btrpc.GetRecentRunsRequest{Limit: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Runs) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Runs), 2)
+	}
+	if resp.Runs[0].Id != expected[0] {
+		t.Errorf("received '%v' expecting '%v'", resp.Runs[0].Id, expected[0])
+	}
+}
+
+type fakeInteractiveStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  chan *btrpc.InteractiveStrategyRequest
+	responses chan *btrpc.InteractiveStrategyResponse
+}
+
+func (f *fakeInteractiveStream) Send(resp *btrpc.InteractiveStrategyResponse) error {
+	f.responses <- resp
+	return nil
+}
+
+func (f *fakeInteractiveStream) Recv() (*btrpc.InteractiveStrategyRequest, error) {
+	req, ok := <-f.requests
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (f *fakeInteractiveStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestInteractiveStrategyAuthentication(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{BacktesterConfig: &config.BacktesterConfig{
+		GRPC: config.GRPC{Username: "rpcuser", Password: "helloImTheDefaultPassword"},
+	}}
+	client := newBufconnClient(t, s)
+	stream, err := client.InteractiveStrategy(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = stream.Recv()
+	if st, _ := status.FromError(err); st.Message() != "authorization header missing" {
+		t.Errorf("received '%v' expecting an authorization error", err)
+	}
+}
+
+func TestInteractiveStrategy(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var received map[string]interface{}
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.progress(1, 2)
+			close(started)
+			u := <-hooks.settings
+			received = u.settings
+			u.result <- nil
+			hooks.progress(2, 2)
+			<-release
+			return nil
+		},
+	}
+	newStream := func() *fakeInteractiveStream {
+		return &fakeInteractiveStream{
+			ctx:       context.Background(),
+			requests:  make(chan *btrpc.InteractiveStrategyRequest, 1),
+			responses: make(chan *btrpc.InteractiveStrategyResponse, 10),
+		}
+	}
+
+	stream := newStream()
+	stream.requests <- &btrpc.InteractiveStrategyRequest{RunId: uuid.Nil.String()}
+	err := s.InteractiveStrategy(stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	// runs without a settings channel do not accept updates
+	unsupported, err := s.runs.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	stream = newStream()
+	stream.requests <- &btrpc.InteractiveStrategyRequest{
+		RunId:          unsupported.ID.String(),
+		CustomSettings: []*btrpc.CustomSettings{{KeyField: "rsi-high", KeyValue: "70"}},
+	}
+	err = s.InteractiveStrategy(stream)
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+	err = s.runs.FinishRun(unsupported.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	stream = newStream()
+	stream.requests <- &btrpc.InteractiveStrategyRequest{RunId: unsupported.ID.String()}
+	err = s.InteractiveStrategy(stream)
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+
+	execErr := make(chan error, 1)
+	go func() {
+		_, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		execErr <- err
+	}()
+	<-started
+	runs, err := s.runs.ListRuns(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	runID := runs[len(runs)-1].ID.String()
+
+	stream = newStream()
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- s.InteractiveStrategy(stream)
+	}()
+	stream.requests <- &btrpc.InteractiveStrategyRequest{
+		RunId:          runID,
+		CustomSettings: []*btrpc.CustomSettings{{KeyField: "rsi-high", KeyValue: "70"}},
+	}
+	resp := <-stream.responses
+	if resp.SettingsUpdatesApplied != 1 {
+		t.Errorf("received '%v' expecting '%v'", resp.SettingsUpdatesApplied, 1)
+	}
+	if resp.EventsProcessed != 2 || resp.EventsTotal != 2 {
+		t.Errorf("received '%v/%v' expecting '%v/%v'", resp.EventsProcessed, resp.EventsTotal, 2, 2)
+	}
+	if received["rsi-high"] != 70.0 {
+		t.Errorf("received '%v' expecting '%v'", received["rsi-high"], 70.0)
+	}
+
+	close(release)
+	err = <-execErr
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	close(stream.requests)
+	err = <-streamErr
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	resp = <-stream.responses
+	if resp.Status != RunStatusCompleted {
+		t.Errorf("received '%v' expecting '%v'", resp.Status, RunStatusCompleted)
+	}
+}
+
+func TestCheckExchangeConnectivity(t *testing.T) {
+	t.Parallel()
+	errUnreachable := errors.New("unreachable")
+	s := &GRPCServer{
+		exchangePinger: func(_ context.Context, exchangeName string) error {
+			if exchangeName == "unreachable" {
+				return errUnreachable
+			}
+			time.Sleep(time.Millisecond * 5)
+			return nil
+		},
+	}
+	_, err := s.CheckExchangeConnectivity(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.CheckExchangeConnectivity(context.Background(), &btrpc.CheckExchangeConnectivityRequest{})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.CheckExchangeConnectivity(context.Background(), &btrpc.CheckExchangeConnectivityRequest{
+		Exchanges: []string{"Reachable", "unreachable", "reachable"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Results), 2)
+	}
+	if resp.Results[0].Exchange != "reachable" || !resp.Results[0].Reachable || resp.Results[0].Error != "" {
+		t.Errorf("received '%v' expecting reachable exchange", resp.Results[0])
+	}
+	if resp.Results[0].LatencyMs < 5 {
+		t.Errorf("received '%v' expecting at least '%v'", resp.Results[0].LatencyMs, 5)
+	}
+	if resp.Results[1].Exchange != "unreachable" || resp.Results[1].Reachable || resp.Results[1].Error != errUnreachable.Error() {
+		t.Errorf("received '%v' expecting unreachable exchange", resp.Results[1])
+	}
+}
+
+func TestPingExchange(t *testing.T) {
+	t.Parallel()
+	err := pingExchange(context.Background(), "definitely-not-an-exchange")
+	if err == nil {
+		t.Error("expected an error for an unknown exchange")
+	}
+}
+
+func TestSetServerPaused(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			return nil
+		},
+	}
+	_, err := s.SetServerPaused(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetServerInfo(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	resp, err := s.SetServerPaused(context.Background(), &btrpc.SetServerPausedRequest{Paused: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !resp.Paused {
+		t.Error("expected server to be paused")
+	}
+	info, err := s.GetServerInfo(context.Background(), &btrpc.GetServerInfoRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !info.Paused {
+		t.Error("expected server info to report paused")
+	}
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.Unavailable {
+		t.Errorf("received '%v' expecting '%v'", err, codes.Unavailable)
+	}
+	_, err = s.ExecuteStrategyFromConfig(context.Background(), &btrpc.ExecuteStrategyFromConfigRequest{
+		Config: &btrpc.Config{},
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.Unavailable {
+		t.Errorf("received '%v' expecting '%v'", err, codes.Unavailable)
+	}
+
+	_, err = s.SetServerPaused(context.Background(), &btrpc.SetServerPausedRequest{Paused: false})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	info, err = s.GetServerInfo(context.Background(), &btrpc.GetServerInfoRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if info.Paused {
+		t.Error("expected server info to report resumed")
+	}
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestRegisterCompletionWebhook(t *testing.T) {
+	t.Parallel()
+	callbacks := make(chan string, 3)
+	var failed int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.CompareAndSwapInt32(&failed, 0, 1) {
+			// fail the first callback to ensure it is retried
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var summary btrpc.RunSummary
+		body, err := io.ReadAll(r.Body)
+		if err == nil {
+			err = protojson.Unmarshal(body, &summary)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		callbacks <- r.URL.Path + " " + summary.Id + " " + summary.Status
+	}))
+	defer srv.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			close(started)
+			<-release
+			return nil
+		},
+	}
+	s.webhooks.initialBackoff = time.Millisecond
+
+	_, err := s.RegisterCompletionWebhook(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.RegisterCompletionWebhook(context.Background(), &btrpc.RegisterCompletionWebhookRequest{Url: "not a url"})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.RegisterCompletionWebhook(context.Background(), &btrpc.RegisterCompletionWebhookRequest{
+		RunId: uuid.Nil.String(),
+		Url:   srv.URL,
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	_, err = s.RegisterCompletionWebhook(context.Background(), &btrpc.RegisterCompletionWebhookRequest{
+		Url: srv.URL + "/global",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	execResp := make(chan *btrpc.ExecuteStrategyResponse, 1)
+	go func() {
+		resp, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if execErr != nil {
+			t.Error(execErr)
+		}
+		execResp <- resp
+	}()
+	<-started
+	runs, err := s.runs.ListRuns(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	runID := runs[0].ID.String()
+	_, err = s.RegisterCompletionWebhook(context.Background(), &btrpc.RegisterCompletionWebhookRequest{
+		RunId: runID,
+		Url:   srv.URL + "/run",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	close(release)
+	<-execResp
+
+	received := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case cb := <-callbacks:
+			received[cb] = true
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for webhook")
+		}
+	}
+	for _, path := range []string{"/global", "/run"} {
+		if expected := path + " " + runID + " " + RunStatusCompleted; !received[expected] {
+			t.Errorf("expected webhook callback '%v', received '%v'", expected, received)
+		}
+	}
+
+	// registering against a finished run notifies immediately
+	_, err = s.RegisterCompletionWebhook(context.Background(), &btrpc.RegisterCompletionWebhookRequest{
+		RunId: runID,
+		Url:   srv.URL + "/late",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	select {
+	case cb := <-callbacks:
+		if expected := "/late " + runID + " " + RunStatusCompleted; cb != expected {
+			t.Errorf("received '%v' expecting '%v'", cb, expected)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for webhook")
+	}
+}
+
+func TestGetStrategyLogsConcurrentRuns(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{BacktesterConfig: &config.BacktesterConfig{}}
+	// each run logs from its own goroutine while the other is running
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ids := make([]string, 2)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			run, err := s.runs.StartRun(&Run{ConfigHash: strconv.Itoa(i)}, false)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ids[i] = run.ID.String()
+			started <- struct{}{}
+			<-release
+			s.runs.AppendLog(LogRecord{Time: time.Now(), Level: LogLevelInfo, Message: "run " + strconv.Itoa(i)})
+			if err = s.runs.FinishRun(run.ID, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	<-started
+	<-started
+	close(release)
+	wg.Wait()
+
+	for i := range ids {
+		resp, err := s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{RunId: ids[i]})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		if expected := "run " + strconv.Itoa(i); len(resp.Logs) != 1 || resp.Logs[0].Message != expected {
+			t.Errorf("received '%v' expecting '%v'", resp.Logs, expected)
+		}
+	}
+}
+
+func TestGetStrategyLogs(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+	}
+	s.strategyExecutor = func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+		s.runs.AppendLog(LogRecord{Time: time.Now(), Level: LogLevelInfo, Message: "loading data"})
+		s.runs.AppendLog(LogRecord{Time: time.Now(), Level: LogLevelError, Message: "order rejected"})
+		s.runs.AppendLog(LogRecord{Time: time.Now(), Level: LogLevelInfo, Message: "run complete"})
+		return nil
+	}
+	_, err := s.GetStrategyLogs(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	_, err = s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{
+		RunId: uuid.Nil.String(),
+		Level: "verbose",
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	runID := resp.RunId
+
+	logs, err := s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs.Logs) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(logs.Logs), 3)
+	}
+	logs, err = s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{
+		RunId: runID,
+		Level: "Error",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs.Logs) != 1 || logs.Logs[0].Message != "order rejected" {
+		t.Errorf("received '%v' expecting '%v'", logs.Logs, "order rejected")
+	}
+	logs, err = s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{
+		RunId: runID,
+		Limit: 1,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs.Logs) != 1 || logs.Logs[0].Message != "run complete" {
+		t.Errorf("received '%v' expecting '%v'", logs.Logs, "run complete")
+	}
+
+	s.runs.m.Lock()
+	s.runs.runs[0].logs = nil
+	s.runs.runs[0].logsEvicted = true
+	s.runs.m.Unlock()
+	_, err = s.GetStrategyLogs(context.Background(), &btrpc.GetStrategyLogsRequest{RunId: runID})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+}
+
+func TestCanonicalizeConfig(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.CanonicalizeConfig(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.CanonicalizeConfig(context.Background(), &btrpc.CanonicalizeConfigRequest{Config: []byte("not json")})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	data, err := os.ReadFile(dcaConfigPath)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	var cfg config.Config
+	err = json.Unmarshal(data, &cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	// an equivalent config with different formatting and casing
+	cfg.CurrencySettings[0].ExchangeName = strings.ToUpper(cfg.CurrencySettings[0].ExchangeName)
+	cfg.CurrencySettings[0].Base = cfg.CurrencySettings[0].Base.Lower()
+	equivalent, err := json.Marshal(&cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	resp, err := s.CanonicalizeConfig(context.Background(), &btrpc.CanonicalizeConfigRequest{Config: data})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	resp2, err := s.CanonicalizeConfig(context.Background(), &btrpc.CanonicalizeConfigRequest{Config: equivalent})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !bytes.Equal(resp.Config, resp2.Config) {
+		t.Errorf("received '%s' expecting '%s'", resp2.Config, resp.Config)
+	}
+}
+
+func TestExportImportRegistry(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(110))
+			return nil
+		},
+	}
+	_, err := source.ExportRegistry(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = source.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+		Labels:           map[string]string{"env": "old-host"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	exported, err := source.ExportRegistry(context.Background(), &btrpc.ExportRegistryRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	destination := &GRPCServer{}
+	_, err = destination.ImportRegistry(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = destination.ImportRegistry(context.Background(), &btrpc.ImportRegistryRequest{Registry: []byte("nope")})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = destination.ImportRegistry(context.Background(), &btrpc.ImportRegistryRequest{Registry: []byte(`{"version":1}`)})
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+	resp, err := destination.ImportRegistry(context.Background(), &btrpc.ImportRegistryRequest{Registry: exported.Registry})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Imported != 1 || resp.Skipped != 0 {
+		t.Errorf("received '%v' expecting '%v'", resp, "1 imported")
+	}
+
+	sourceRuns, err := source.ListRuns(context.Background(), &btrpc.ListRunsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	destinationRuns, err := destination.ListRuns(context.Background(), &btrpc.ListRunsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !proto.Equal(sourceRuns, destinationRuns) {
+		t.Errorf("received '%v' expecting '%v'", destinationRuns, sourceRuns)
+	}
+	sourceExport, err := source.ExportResults(context.Background(), &btrpc.ExportResultsRequest{
+		RunId:   sourceRuns.Runs[0].Id,
+		Formats: []string{ExportFormatJSON},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	destinationExport, err := destination.ExportResults(context.Background(), &btrpc.ExportResultsRequest{
+		RunId:   sourceRuns.Runs[0].Id,
+		Formats: []string{ExportFormatJSON},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !bytes.Equal(sourceExport.Exports[ExportFormatJSON], destinationExport.Exports[ExportFormatJSON]) {
+		t.Errorf("received '%s' expecting '%s'", destinationExport.Exports[ExportFormatJSON], sourceExport.Exports[ExportFormatJSON])
+	}
+
+	// imported runs keep their strategy config so they can be executed again
+	destination.BacktesterConfig = &config.BacktesterConfig{}
+	destination.strategyExecutor = source.strategyExecutor
+	restarted, err := destination.RestartWithParams(context.Background(), &btrpc.RestartWithParamsRequest{
+		RunId: sourceRuns.Runs[0].Id,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if restarted.RunId == sourceRuns.Runs[0].Id {
+		t.Errorf("received '%v' expecting a new run", restarted.RunId)
+	}
+}
+
+func TestPreviewStrategy(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	var handled int
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			if !hooks.preview {
+				return errors.New("expected preview run")
+			}
+			handled = 0
+			for i := 0; i < int(maxPreviewEvents)*2; i++ {
+				b := &event.Base{
+					Exchange:     "binance",
+					AssetType:    asset.Spot,
+					CurrencyPair: cp,
+					Time:         start.Add(time.Hour * time.Duration(i)),
+				}
+				var ev common.EventHandler = &evkline.Kline{Base: b}
+				if i%2 == 1 {
+					ev = &signal.Signal{Base: b}
+				}
+				handled++
+				if !hooks.event(ev) {
+					break
+				}
+			}
+			return nil
+		},
+	}
+	_, err := s.PreviewStrategy(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.PreviewStrategy(context.Background(), &btrpc.PreviewStrategyRequest{StrategyFilePath: dcaConfigPath})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.PreviewStrategy(context.Background(), &btrpc.PreviewStrategyRequest{
+		StrategyFilePath: dcaConfigPath,
+		Events:           3,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Events) != 3 || handled != 3 {
+		t.Fatalf("received '%v' events after handling '%v' expecting '%v'", len(resp.Events), handled, 3)
+	}
+	for i, expected := range []string{"data", "signal", "data"} {
+		if resp.Events[i].EventType != expected {
+			t.Errorf("received '%v' expecting '%v'", resp.Events[i].EventType, expected)
+		}
+	}
+	if resp.Events[0].Pair != cp.String() || !resp.Events[0].Timestamp.AsTime().Equal(start) {
+		t.Errorf("received '%v' expecting '%v' at '%v'", resp.Events[0], cp, start)
+	}
+
+	// previews are capped and not tracked as runs
+	resp, err = s.PreviewStrategy(context.Background(), &btrpc.PreviewStrategyRequest{
+		StrategyFilePath: dcaConfigPath,
+		Events:           math.MaxUint64,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if uint64(len(resp.Events)) != maxPreviewEvents {
+		t.Errorf("received '%v' expecting '%v'", len(resp.Events), maxPreviewEvents)
+	}
+	runs, err := s.runs.ListRuns(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(runs), 0)
+	}
+}
+
+func TestUpdateServerConfig(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var executions int32
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			if atomic.AddInt32(&executions, 1) == 1 {
+				close(started)
+				<-release
+			}
+			return nil
+		},
+	}
+	_, err := s.UpdateServerConfig(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	zero := uint64(0)
+	_, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxBufferedRunLogs: &zero})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxBufferedRunCandles: &zero})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Config.MaxConcurrentRuns != 0 || resp.Config.MaxBufferedRunLogs != defaultBufferedRunLogs || resp.Config.MaxBufferedRunCandles != defaultBufferedRunCandles ||
+		resp.Config.MaxRetainedRuns != defaultRetainedRuns {
+		t.Errorf("received '%v' expecting defaults", resp.Config)
+	}
+
+	_, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxRetainedRuns: &zero})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	retained := uint64(5)
+	resp, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxRetainedRuns: &retained})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Config.MaxRetainedRuns != 5 || s.runs.RunRetention() != 5 {
+		t.Errorf("received '%v' expecting '%v'", resp.Config.MaxRetainedRuns, 5)
+	}
+
+	candles := uint64(2)
+	resp, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxBufferedRunCandles: &candles})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Config.MaxBufferedRunCandles != 2 || s.runs.CandleRetention() != 2 {
+		t.Errorf("received '%v' expecting '%v'", resp.Config.MaxBufferedRunCandles, 2)
+	}
+
+	one := uint64(1)
+	resp, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxConcurrentRuns: &one})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Config.MaxConcurrentRuns != 1 || resp.Config.MaxBufferedRunLogs != defaultBufferedRunLogs {
+		t.Errorf("received '%v' expecting '%v'", resp.Config, "one concurrent run")
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		errs <- execErr
+	}()
+	<-started
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.ResourceExhausted {
+		t.Errorf("received '%v' expecting '%v'", err, codes.ResourceExhausted)
+	}
+
+	// raising the limit takes effect while the first run is still active
+	two := uint64(2)
+	_, err = s.UpdateServerConfig(context.Background(), &btrpc.UpdateServerConfigRequest{MaxConcurrentRuns: &two})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	close(release)
+	if err = <-errs; !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestGetDataAvailability(t *testing.T) {
+	t.Parallel()
+	earliest := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest := earliest.Add(time.Hour * 9)
+	s := &GRPCServer{
+		dataAvailability: func(exchangeName string, pair currency.Pair, a asset.Item, interval gctkline.Interval) (*dataWindow, error) {
+			if exchangeName != "binance" || a != asset.Spot || interval != gctkline.OneHour {
+				return nil, errors.New("unexpected query")
+			}
+			if !pair.Equal(currency.NewPair(currency.BTC, currency.USDT)) {
+				return &dataWindow{}, nil
+			}
+			// 5 of the 10 hourly candles between earliest and latest
+			return &dataWindow{earliest: earliest, latest: latest, candles: 5}, nil
+		},
+	}
+	_, err := s.GetDataAvailability(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetDataAvailability(context.Background(), &btrpc.GetDataAvailabilityRequest{})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.GetDataAvailability(context.Background(), &btrpc.GetDataAvailabilityRequest{
+		Queries: []*btrpc.DataAvailabilityQuery{{ExchangeName: "binance", Asset: "spot", Base: "BTC", Quote: "USDT"}},
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	query := func(exch, base string) *btrpc.DataAvailabilityQuery {
+		return &btrpc.DataAvailabilityQuery{
+			ExchangeName: exch,
+			Asset:        "spot",
+			Base:         base,
+			Quote:        "USDT",
+			Interval:     uint64(gctkline.OneHour),
+		}
+	}
+	resp, err := s.GetDataAvailability(context.Background(), &btrpc.GetDataAvailabilityRequest{
+		Queries: []*btrpc.DataAvailabilityQuery{
+			query("binance", "BTC"),
+			query("binance", "ETH"),
+			query("ftx", "BTC"),
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Availability) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Availability), 3)
+	}
+	btc := resp.Availability[0]
+	if !btc.Available || btc.Candles != 5 || btc.Coverage != 0.5 {
+		t.Errorf("received '%v' expecting '%v'", btc, "5 candles with 0.5 coverage")
+	}
+	if !btc.Earliest.AsTime().Equal(earliest) || !btc.Latest.AsTime().Equal(latest) {
+		t.Errorf("received '%v' - '%v' expecting '%v' - '%v'", btc.Earliest.AsTime(), btc.Latest.AsTime(), earliest, latest)
+	}
+	if resp.Availability[1].Available || resp.Availability[1].Error != "" {
+		t.Errorf("received '%v' expecting no data", resp.Availability[1])
+	}
+	if resp.Availability[2].Available || resp.Availability[2].Error == "" {
+		t.Errorf("received '%v' expecting an error", resp.Availability[2])
+	}
+}
+
+func TestDataWindowCoverage(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		window   dataWindow
+		interval gctkline.Interval
+		expected float64
+	}{
+		{window: dataWindow{}, interval: gctkline.OneHour, expected: 0},
+		{window: dataWindow{earliest: start, latest: start, candles: 1}, interval: gctkline.OneHour, expected: 1},
+		{window: dataWindow{earliest: start, latest: start.Add(time.Hour * 3), candles: 2}, interval: gctkline.OneHour, expected: 0.5},
+		{window: dataWindow{earliest: start, latest: start.Add(time.Hour * 3), candles: 8}, interval: gctkline.OneHour, expected: 1},
+		{window: dataWindow{earliest: start, latest: start, candles: 1}, interval: 0, expected: 0},
+	} {
+		if received := tc.window.coverage(tc.interval); received != tc.expected {
+			t.Errorf("received '%v' expecting '%v'", received, tc.expected)
+		}
+	}
+}
+
+func TestAnalyzeDateRange(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 4)
+	s := &GRPCServer{
+		candleSource: func(exchangeName string, pair currency.Pair, _ asset.Item, interval gctkline.Interval, from, to time.Time) ([]gctkline.Candle, error) {
+			if interval != gctkline.OneHour || !from.Equal(start) || !to.Equal(end) {
+				return nil, errors.New("unexpected query")
+			}
+			if exchangeName != "binance" {
+				return nil, errors.New("no data")
+			}
+			if pair.Base.Equal(currency.ETH) {
+				return nil, nil
+			}
+			return []gctkline.Candle{
+				{Time: start, Close: 100},
+				{Time: start.Add(time.Hour), Close: 110},
+				{Time: start.Add(time.Hour * 2), Close: 88},
+				{Time: start.Add(time.Hour * 3), Close: 121},
+			}, nil
+		},
+	}
+	_, err := s.AnalyzeDateRange(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	pair := func(exch, base string) *btrpc.DateRangePair {
+		return &btrpc.DateRangePair{ExchangeName: exch, Asset: "spot", Base: base, Quote: "USDT"}
+	}
+	request := &btrpc.AnalyzeDateRangeRequest{
+		Pairs:     []*btrpc.DateRangePair{pair("binance", "BTC"), pair("binance", "ETH"), pair("ftx", "BTC")},
+		Interval:  uint64(gctkline.OneHour),
+		StartDate: timestamppb.New(end),
+		EndDate:   timestamppb.New(start),
+	}
+	_, err = s.AnalyzeDateRange(context.Background(), request)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	request.StartDate, request.EndDate = timestamppb.New(start), timestamppb.New(end)
+	resp, err := s.AnalyzeDateRange(context.Background(), request)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Stats) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Stats), 3)
+	}
+	btc := resp.Stats[0]
+	if btc.Error != "" || btc.Candles != 4 || btc.ReturnPercent != 21 || btc.MaxDrawdownPercent != 20 {
+		t.Errorf("received '%v' expecting 4 candles with a 21%% return and 20%% drawdown", btc)
+	}
+	if btc.Regime != RegimeBull || !btc.ContainsCrash {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", btc.Regime, btc.ContainsCrash, RegimeBull, true)
+	}
+	if resp.Stats[1].Error != errNoCandles.Error() {
+		t.Errorf("received '%v' expecting '%v'", resp.Stats[1].Error, errNoCandles)
+	}
+	if resp.Stats[2].Error != "no data" {
+		t.Errorf("received '%v' expecting '%v'", resp.Stats[2].Error, "no data")
+	}
+}
+
+func TestRestartWithParams(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	restarted := make(chan map[string]interface{}, 1)
+	var executions int32
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(cfg *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			if atomic.AddInt32(&executions, 1) == 1 {
+				close(started)
+				<-hooks.cancel
+				return errRunCancelled
+			}
+			restarted <- cfg.StrategySettings.CustomSettings
+			return nil
+		},
+	}
+	_, err := s.RestartWithParams(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.RestartWithParams(context.Background(), &btrpc.RestartWithParamsRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	imported, err := s.runs.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.RestartWithParams(context.Background(), &btrpc.RestartWithParamsRequest{RunId: imported.ID.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+			Labels:           map[string]string{"tuning": "true"},
+		})
+		errs <- execErr
+	}()
+	<-started
+	runs, err := s.runs.ListRuns(map[string]string{"tuning": "true"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	oldID := runs[0].ID
+
+	resp, err := s.RestartWithParams(context.Background(), &btrpc.RestartWithParamsRequest{
+		RunId: oldID.String(),
+		CustomSettings: []*btrpc.CustomSettings{
+			{KeyField: "rsi-high", KeyValue: "75"},
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if st, _ := status.FromError(<-errs); st.Code() != codes.Canceled {
+		t.Errorf("received '%v' expecting '%v'", st.Code(), codes.Canceled)
+	}
+	if resp.RunId == oldID.String() {
+		t.Errorf("expected a new run id, received '%v'", resp.RunId)
+	}
+	if settings := <-restarted; settings["rsi-high"] != 75.0 {
+		t.Errorf("received '%v' expecting '%v'", settings["rsi-high"], 75.0)
+	}
+
+	oldRun, err := s.runs.GetRun(oldID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if oldRun.Status != RunStatusCancelled {
+		t.Errorf("received '%v' expecting '%v'", oldRun.Status, RunStatusCancelled)
+	}
+	newID, err := uuid.FromString(resp.RunId)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	newRun, err := s.runs.GetRun(newID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if newRun.Status != RunStatusCompleted || newRun.Labels["tuning"] != "true" {
+		t.Errorf("received '%v' '%v' expecting a completed run with labels kept", newRun.Status, newRun.Labels)
+	}
+}
+
+type fakeProgressStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	m       sync.Mutex
+	updates []*btrpc.GetRunProgressResponse
+	times   []time.Time
+	first   chan struct{}
+}
+
+func (f *fakeProgressStream) Send(resp *btrpc.GetRunProgressResponse) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.updates = append(f.updates, resp)
+	f.times = append(f.times, time.Now())
+	if len(f.updates) == 1 && f.first != nil {
+		close(f.first)
+	}
+	return nil
+}
+
+func (f *fakeProgressStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestStreamStrategyProgress(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	stream := &fakeProgressStream{ctx: context.Background(), first: make(chan struct{})}
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			close(started)
+			<-stream.first
+			for i := int64(1); i <= 50; i++ {
+				hooks.progress(i, 50)
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		},
+	}
+	err := s.StreamStrategyProgress(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = s.StreamStrategyProgress(&btrpc.StreamStrategyProgressRequest{RunId: uuid.Nil.String(), MinIntervalMs: -1}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	err = s.StreamStrategyProgress(&btrpc.StreamStrategyProgressRequest{RunId: uuid.Nil.String()}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if !errors.Is(execErr, nil) {
+			t.Errorf("received '%v' expecting '%v'", execErr, nil)
+		}
+	}()
+	<-started
+	s.runs.m.Lock()
+	runID := s.runs.runs[0].ID
+	s.runs.m.Unlock()
+
+	const interval = 10 * time.Millisecond
+	err = s.StreamStrategyProgress(&btrpc.StreamStrategyProgressRequest{
+		RunId:         runID.String(),
+		MinIntervalMs: int64(interval / time.Millisecond),
+	}, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	wg.Wait()
+
+	if len(stream.updates) < 2 || len(stream.updates) > 50 {
+		t.Fatalf("received '%v' updates expecting between '%v' and '%v'", len(stream.updates), 2, 50)
+	}
+	for i := 1; i < len(stream.times); i++ {
+		if gap := stream.times[i].Sub(stream.times[i-1]); gap < interval {
+			t.Errorf("received gap '%v' at index %v expecting at least '%v'", gap, i, interval)
+		}
+	}
+	last := stream.updates[len(stream.updates)-1]
+	if last.Status != RunStatusCompleted {
+		t.Errorf("received '%v' expecting '%v'", last.Status, RunStatusCompleted)
+	}
+	if last.EventsProcessed != 50 {
+		t.Errorf("received '%v' expecting '%v'", last.EventsProcessed, 50)
+	}
+}
+
+func TestPreviewPositionSizing(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.PreviewPositionSizing(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	resp, err := s.PreviewPositionSizing(context.Background(), &btrpc.PreviewPositionSizingRequest{
+		StrategyFilePath: filepath.Join("..", "config", "strategyexamples", "t2b2-api-candles-exchange-funding.strat"),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Positions) != 6 || len(resp.FundingPools) != 2 {
+		t.Fatalf("received '%v' positions '%v' pools expecting '%v' '%v'", len(resp.Positions), len(resp.FundingPools), 6, 2)
+	}
+	for _, pool := range resp.FundingPools {
+		var total float64
+		for _, pos := range resp.Positions {
+			if pos.Quote == pool.Currency {
+				total += pos.PositionSize
+			}
+		}
+		if total <= 0 || total > pool.InitialFunds+1e-9 {
+			t.Errorf("%v received total '%v' expecting within budget '%v'", pool.Currency, total, pool.InitialFunds)
+		}
+	}
+}
+
+type fakeResourceUsageStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	samples chan *btrpc.ResourceUsageSample
+}
+
+func (f *fakeResourceUsageStream) Send(sample *btrpc.ResourceUsageSample) error {
+	f.samples <- sample
+	return nil
+}
+
+func (f *fakeResourceUsageStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestStreamRunResourceUsage(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(*config.Config, *config.BacktesterConfig, *runHooks) error {
+			close(started)
+			<-release
+			return nil
+		},
+	}
+	stream := &fakeResourceUsageStream{ctx: context.Background(), samples: make(chan *btrpc.ResourceUsageSample, 100)}
+	err := s.StreamRunResourceUsage(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = s.StreamRunResourceUsage(&btrpc.StreamRunResourceUsageRequest{RunId: uuid.Nil.String(), IntervalMs: -1}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	err = s.StreamRunResourceUsage(&btrpc.StreamRunResourceUsageRequest{RunId: uuid.Nil.String()}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if !errors.Is(execErr, nil) {
+			t.Errorf("received '%v' expecting '%v'", execErr, nil)
+		}
+	}()
+	<-started
+	s.runs.m.Lock()
+	runID := s.runs.runs[0].ID
+	s.runs.m.Unlock()
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- s.StreamRunResourceUsage(&btrpc.StreamRunResourceUsageRequest{RunId: runID.String(), IntervalMs: 5}, stream)
+	}()
+	for i := 0; i < 3; i++ {
+		sample := <-stream.samples
+		if sample.RunId != runID.String() {
+			t.Errorf("received '%v' expecting '%v'", sample.RunId, runID)
+		}
+		if sample.Goroutines <= 0 || sample.HeapAlloc == 0 {
+			t.Errorf("received '%v' goroutines '%v' heap expecting positive values", sample.Goroutines, sample.HeapAlloc)
+		}
+	}
+	close(release)
+	wg.Wait()
+	err = <-streamErr
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	// finished runs have no usage to stream
+	err = s.StreamRunResourceUsage(&btrpc.StreamRunResourceUsageRequest{RunId: runID.String()}, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestGetStrategyTrades(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for i := 0; i < 25; i++ {
+				side := gctorder.Buy
+				if i%2 == 1 {
+					side = gctorder.Sell
+				}
+				f := &fill.Fill{
+					Base: &event.Base{
+						Exchange:     "binance",
+						Time:         start.Add(time.Duration(i) * time.Hour),
+						CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+						AssetType:    asset.Spot,
+					},
+					Direction:     side,
+					Amount:        decimal.NewFromInt(1),
+					PurchasePrice: decimal.NewFromInt(int64(100 + i)),
+					ExchangeFee:   decimal.NewFromFloat(0.1),
+					Order:         &gctorder.Detail{Side: side},
+				}
+				if !hooks.event(f) {
+					return nil
+				}
+			}
+			// fills without an order were not placed and are not trades
+			hooks.event(&fill.Fill{Base: &event.Base{}, Direction: gctorder.CouldNotBuy})
+			return nil
+		},
+	}
+	_, err := s.GetStrategyTrades(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetStrategyTrades(context.Background(), &btrpc.GetStrategyTradesRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	runs, err := s.ListRuns(context.Background(), &btrpc.ListRunsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs.Runs) != 1 || runs.Runs[0].TradeCount != 25 {
+		t.Fatalf("received '%v' expecting one run with '%v' trades", runs.Runs, 25)
+	}
+
+	var trades []*btrpc.Trade
+	var offset uint64
+	for pages := 0; offset < runs.Runs[0].TradeCount; pages++ {
+		if pages > 3 {
+			t.Fatal("expected pagination to finish")
+		}
+		page, pageErr := s.GetStrategyTrades(context.Background(), &btrpc.GetStrategyTradesRequest{
+			RunId:  resp.RunId,
+			Offset: offset,
+			Limit:  10,
+		})
+		if !errors.Is(pageErr, nil) {
+			t.Fatalf("received '%v' expecting '%v'", pageErr, nil)
+		}
+		if page.TotalTrades != runs.Runs[0].TradeCount {
+			t.Errorf("received '%v' expecting '%v'", page.TotalTrades, runs.Runs[0].TradeCount)
+		}
+		trades = append(trades, page.Trades...)
+		offset = page.NextOffset
+	}
+	if uint64(len(trades)) != runs.Runs[0].TradeCount {
+		t.Fatalf("received '%v' expecting '%v'", len(trades), runs.Runs[0].TradeCount)
+	}
+	if trades[1].Side != gctorder.Sell.String() || trades[1].Price != 101 || trades[1].Fee != 0.1 {
+		t.Errorf("received '%v' expecting a sell at 101 with a fee of 0.1", trades[1])
+	}
+	if trades[1].RealisedPnl != 1 {
+		t.Errorf("received '%v' expecting '%v'", trades[1].RealisedPnl, 1)
+	}
+	if trades[24].Pair != "BTCUSDT" || !trades[24].Timestamp.AsTime().Equal(start.Add(24*time.Hour)) {
+		t.Errorf("received '%v' expecting the final trade", trades[24])
+	}
+
+	page, err := s.GetStrategyTrades(context.Background(), &btrpc.GetStrategyTradesRequest{
+		RunId:  resp.RunId,
+		Offset: 100,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(page.Trades) != 0 || page.NextOffset != 25 {
+		t.Errorf("received '%v' trades and offset '%v' expecting '%v' '%v'", len(page.Trades), page.NextOffset, 0, 25)
+	}
+}
+
+func TestSaveConfigAsTemplate(t *testing.T) {
+	t.Parallel()
+	var executed []*config.Config
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(cfg *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			executed = append(executed, cfg)
+			return nil
+		},
+	}
+	_, err := s.SaveConfigAsTemplate(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ExecuteStrategyFromTemplate(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ExecuteStrategyFromTemplate(context.Background(), &btrpc.ExecuteStrategyFromTemplateRequest{TemplateName: "tuned"})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	request := &btrpc.SaveConfigAsTemplateRequest{
+		RunId:        resp.RunId,
+		TemplateName: "tuned",
+		Variables: map[string]string{
+			"maxTotal": "currency-settings.0.buy-side.maximum-total",
+		},
+	}
+	saved, err := s.SaveConfigAsTemplate(context.Background(), request)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if saved.TemplateName != "tuned" || len(saved.Variables) != 1 || saved.Variables[0] != "maxTotal" || saved.Overwritten {
+		t.Errorf("received '%v' expecting template tuned with variable maxTotal", saved)
+	}
+	_, err = s.SaveConfigAsTemplate(context.Background(), request)
+	if st, _ := status.FromError(err); st.Code() != codes.AlreadyExists {
+		t.Errorf("received '%v' expecting '%v'", err, codes.AlreadyExists)
+	}
+	request.Overwrite = true
+	saved, err = s.SaveConfigAsTemplate(context.Background(), request)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !saved.Overwritten {
+		t.Error("expected template to be overwritten")
+	}
+	request.Variables["bad"] = "currency-settings.5"
+	_, err = s.SaveConfigAsTemplate(context.Background(), request)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	_, err = s.ExecuteStrategyFromTemplate(context.Background(), &btrpc.ExecuteStrategyFromTemplateRequest{
+		TemplateName: "tuned",
+		Values:       map[string]string{"bruh": "1"},
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	templateResp, err := s.ExecuteStrategyFromTemplate(context.Background(), &btrpc.ExecuteStrategyFromTemplateRequest{
+		TemplateName: "tuned",
+		Values:       map[string]string{"maxTotal": "1337"},
+		Labels:       map[string]string{"source": "template"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if templateResp.RunId == resp.RunId {
+		t.Error("expected template to be executed as a new run")
+	}
+	if len(executed) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(executed), 2)
+	}
+	if !executed[1].CurrencySettings[0].BuySide.MaximumTotal.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expecting '%v'", executed[1].CurrencySettings[0].BuySide.MaximumTotal, 1337)
+	}
+	if executed[1].StrategySettings.Name != executed[0].StrategySettings.Name {
+		t.Errorf("received '%v' expecting '%v'", executed[1].StrategySettings.Name, executed[0].StrategySettings.Name)
+	}
+}
+
+func TestExecuteWalkForward(t *testing.T) {
+	t.Parallel()
+	var m sync.Mutex
+	var executions int
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(cfg *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			m.Lock()
+			executions++
+			m.Unlock()
+			// the first half of the data rewards a higher ratio, the second
+			// half punishes it
+			ratio, ok := cfg.StrategySettings.CustomSettings["ratio"].(float64)
+			if !ok {
+				return errors.New("ratio not set")
+			}
+			start := cfg.DataSettings.APIData.StartDate
+			if start.After(time.Date(2021, 9, 25, 0, 0, 0, 0, time.UTC)) {
+				ratio = -ratio
+			}
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("binance spot BTC-USDT", cfg.DataSettings.APIData.EndDate, decimal.NewFromFloat(100+ratio))
+			return nil
+		},
+	}
+	_, err := s.ExecuteWalkForward(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ExecuteWalkForward(context.Background(), &btrpc.ExecuteWalkForwardRequest{
+		StrategyFilePath: dcaConfigPath,
+		Windows:          1000,
+		InSampleRatio:    0.5,
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.ExecuteWalkForward(context.Background(), &btrpc.ExecuteWalkForwardRequest{
+		StrategyFilePath: dcaConfigPath,
+		Windows:          2,
+		InSampleRatio:    0.75,
+		Parameters: []*btrpc.WalkForwardParameter{
+			{Key: "ratio", Values: []string{"1", "2"}},
+		},
+		Labels: map[string]string{"kind": "walk-forward"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if executions != 6 {
+		t.Errorf("received '%v' expecting '%v'", executions, 6)
+	}
+	if len(resp.Windows) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Windows), 2)
+	}
+	for i, expected := range []struct {
+		ratio       string
+		outOfSample float64
+	}{
+		{ratio: "2", outOfSample: 2},
+		{ratio: "1", outOfSample: -1},
+	} {
+		w := resp.Windows[i]
+		if len(w.BestSettings) != 1 || w.BestSettings[0].KeyValue != expected.ratio {
+			t.Errorf("window %v received '%v' expecting ratio '%v'", i+1, w.BestSettings, expected.ratio)
+		}
+		if math.Abs(w.OutOfSampleReturnPercent-expected.outOfSample) > 1e-9 {
+			t.Errorf("window %v received '%v' expecting '%v'", i+1, w.OutOfSampleReturnPercent, expected.outOfSample)
+		}
+		if !w.InSampleEnd.AsTime().Equal(w.OutOfSampleStart.AsTime()) {
+			t.Errorf("window %v in sample end '%v' expecting out of sample start '%v'", i+1, w.InSampleEnd.AsTime(), w.OutOfSampleStart.AsTime())
+		}
+	}
+	if math.Abs(resp.OutOfSampleReturnPercent-(1.02*0.99-1)*100) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", resp.OutOfSampleReturnPercent, (1.02*0.99-1)*100)
+	}
+
+	progress, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: resp.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if progress.Status != RunStatusCompleted || progress.EventsProcessed != 6 || progress.EventsTotal != 6 {
+		t.Errorf("received '%v' expecting a completed run with 6 of 6 backtests", progress)
+	}
+}
+
+func TestGetStrategyParameters(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.GetStrategyParameters(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	_, err = s.GetStrategyParameters(context.Background(), &btrpc.GetStrategyParametersRequest{StrategyName: "moon"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", status.Code(err), codes.NotFound)
+	}
+
+	resp, err := s.GetStrategyParameters(context.Background(), &btrpc.GetStrategyParametersRequest{StrategyName: "rsi"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	params := make(map[string]*btrpc.StrategyParameter)
+	for i := range resp.Parameters {
+		params[resp.Parameters[i].Name] = resp.Parameters[i]
+	}
+	for _, name := range []string{"rsi-high", "rsi-low", "rsi-period"} {
+		p, ok := params[name]
+		if !ok {
+			t.Errorf("received '%v' expecting parameter '%v'", resp.Parameters, name)
+			continue
+		}
+		if p.ExclusiveMinimum == nil || *p.ExclusiveMinimum != 0 {
+			t.Errorf("received '%v' expecting exclusive minimum of '%v' for '%v'", p.ExclusiveMinimum, 0, name)
+		}
+	}
+	if p, ok := params["rsi-high"]; ok && p.DefaultValue != "70" {
+		t.Errorf("received '%v' expecting '%v'", p.DefaultValue, "70")
+	}
+
+	resp, err = s.GetStrategyParameters(context.Background(), &btrpc.GetStrategyParametersRequest{StrategyName: "dollarcostaverage"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Parameters) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(resp.Parameters), 0)
+	}
+}
+
+func TestDescribeStrategy(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.DescribeStrategy(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	_, err = s.DescribeStrategy(context.Background(), &btrpc.DescribeStrategyRequest{StrategyName: "moon"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", status.Code(err), codes.NotFound)
+	}
+
+	resp, err := s.DescribeStrategy(context.Background(), &btrpc.DescribeStrategyRequest{StrategyName: "dollarcostaverage"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Description == "" {
+		t.Error("expected a description")
+	}
+	if !resp.SupportsSimultaneousProcessing {
+		t.Errorf("received '%v' expecting '%v'", resp.SupportsSimultaneousProcessing, true)
+	}
+	if len(resp.RequiredDataTypes) != 2 {
+		t.Errorf("received '%v' expecting '%v'", resp.RequiredDataTypes, []string{common.CandleStr, common.TradeStr})
+	}
+	if len(resp.SupportedIntervals) != len(gctkline.SupportedIntervals) {
+		t.Errorf("received '%v' expecting '%v'", len(resp.SupportedIntervals), len(gctkline.SupportedIntervals))
+	}
+
+	resp, err = s.DescribeStrategy(context.Background(), &btrpc.DescribeStrategyRequest{StrategyName: "ftx-cash-carry"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Description == "" {
+		t.Error("expected a description")
+	}
+	if len(resp.RequiredDataTypes) != 1 || resp.RequiredDataTypes[0] != common.CandleStr {
+		t.Errorf("received '%v' expecting '%v'", resp.RequiredDataTypes, []string{common.CandleStr})
+	}
+}
+
+func TestRunMonteCarlo(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(1000))
+			for i, price := range []int64{100, 110, 100, 95, 100, 120} {
+				side := gctorder.Buy
+				if i%2 == 1 {
+					side = gctorder.Sell
+				}
+				hooks.event(&fill.Fill{
+					Base: &event.Base{
+						Exchange:     "binance",
+						Time:         start.Add(time.Duration(i) * time.Hour),
+						CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+						AssetType:    asset.Spot,
+					},
+					Direction:     side,
+					Amount:        decimal.NewFromInt(1),
+					PurchasePrice: decimal.NewFromInt(price),
+					Order:         &gctorder.Detail{Side: side},
+				})
+			}
+			return nil
+		},
+	}
+	_, err := s.RunMonteCarlo(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.RunMonteCarlo(context.Background(), &btrpc.RunMonteCarloRequest{RunId: uuid.Nil.String(), Iterations: 10})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	exec, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	runID := exec.RunId
+	_, err = s.RunMonteCarlo(context.Background(), &btrpc.RunMonteCarloRequest{RunId: runID})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.RunMonteCarlo(context.Background(), &btrpc.RunMonteCarloRequest{
+		RunId:      runID,
+		Iterations: maxMonteCarloIterations + 1,
+		Seed:       1337,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Iterations != maxMonteCarloIterations {
+		t.Errorf("received '%v' expecting '%v'", resp.Iterations, maxMonteCarloIterations)
+	}
+	if resp.TradeCount != 6 {
+		t.Errorf("received '%v' expecting '%v'", resp.TradeCount, 6)
+	}
+	// realised profits of 10, -5 and 20 on 1000 starting equity
+	if math.Abs(resp.ActualReturnPercent-2.5) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", resp.ActualReturnPercent, 2.5)
+	}
+	if len(resp.Percentiles) != len(monteCarloPercentiles) {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Percentiles), len(monteCarloPercentiles))
+	}
+	median := resp.Percentiles[2]
+	if median.Percentile != 50 || median.ReturnPercent < 0 || median.ReturnPercent > 6 {
+		t.Errorf("received '%v' expecting a median return between '%v' and '%v'", median, 0, 6)
+	}
+
+	again, err := s.RunMonteCarlo(context.Background(), &btrpc.RunMonteCarloRequest{
+		RunId:      runID,
+		Iterations: maxMonteCarloIterations,
+		Seed:       1337,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := range again.Percentiles {
+		if again.Percentiles[i].ReturnPercent != resp.Percentiles[i].ReturnPercent ||
+			again.Percentiles[i].MaxDrawdownPercent != resp.Percentiles[i].MaxDrawdownPercent {
+			t.Errorf("received '%v' expecting seeded simulations to match '%v'", again.Percentiles[i], resp.Percentiles[i])
+		}
+	}
+}
+
+type fakeLeaderboardStream struct {
+	grpc.ServerStream
+	updates chan *btrpc.SweepLeaderboardUpdate
+}
+
+func (f *fakeLeaderboardStream) Send(resp *btrpc.SweepLeaderboardUpdate) error {
+	f.updates <- resp
+	return nil
+}
+
+func (f *fakeLeaderboardStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamSweepLeaderboard(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	release := make(chan float64, 1)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			returnPercent := <-release
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromFloat(100+returnPercent))
+			return nil
+		},
+	}
+	stream := &fakeLeaderboardStream{updates: make(chan *btrpc.SweepLeaderboardUpdate, 10)}
+	err := s.StreamSweepLeaderboard(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = s.StreamSweepLeaderboard(&btrpc.StreamSweepLeaderboardRequest{}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	err = s.StreamSweepLeaderboard(&btrpc.StreamSweepLeaderboardRequest{SweepId: "sweep", Metric: "vibes"}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	// runs of other sweeps are not ranked
+	release <- 50
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+		Labels:           map[string]string{SweepLabel: "other"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- s.StreamSweepLeaderboard(&btrpc.StreamSweepLeaderboardRequest{
+			SweepId:      "sweep",
+			ExpectedRuns: 3,
+		}, stream)
+	}()
+	receive := func() *btrpc.SweepLeaderboardUpdate {
+		t.Helper()
+		select {
+		case update := <-stream.updates:
+			return update
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for leaderboard update")
+		}
+		return nil
+	}
+	if update := receive(); update.FinishedRuns != 0 || len(update.Entries) != 0 || update.Final {
+		t.Fatalf("received '%v' expecting an empty leaderboard", update)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 3; i++ {
+			_, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+				StrategyFilePath: dcaConfigPath,
+				Labels:           map[string]string{SweepLabel: "sweep"},
+			})
+			if !errors.Is(execErr, nil) {
+				t.Errorf("received '%v' expecting '%v'", execErr, nil)
+			}
+		}
+	}()
+	for i, returnPercent := range []float64{5, 20, -10} {
+		release <- returnPercent
+		update := receive()
+		for update.FinishedRuns != uint64(i+1) {
+			update = receive()
+		}
+		if len(update.Entries) != i+1 {
+			t.Fatalf("received '%v' expecting '%v'", len(update.Entries), i+1)
+		}
+		if update.Final != (i == 2) {
+			t.Errorf("received '%v' expecting '%v'", update.Final, i == 2)
+		}
+		if i == 2 {
+			for j, expected := range []float64{20, 5, -10} {
+				if update.Entries[j].Rank != uint64(j+1) || math.Abs(update.Entries[j].Value-expected) > 1e-9 {
+					t.Errorf("received '%v' expecting rank '%v' with '%v'", update.Entries[j], j+1, expected)
+				}
+			}
+		}
+	}
+	wg.Wait()
+	if err = <-streamErr; !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestBenchmarkAgainstHold(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("ftx spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("ftx spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(112))
+			return nil
+		},
+		candleSource: func(_ string, pair currency.Pair, _ asset.Item, interval gctkline.Interval, _, _ time.Time) ([]gctkline.Candle, error) {
+			if interval != gctkline.OneDay {
+				return nil, errors.New("unexpected interval")
+			}
+			switch {
+			case pair.Base.Equal(currency.BTC):
+				return []gctkline.Candle{{Time: start, Close: 100}, {Time: start.Add(time.Hour), Close: 105}}, nil
+			case pair.Base.Equal(currency.DOGE):
+				return []gctkline.Candle{{Time: start, Close: 10}, {Time: start.Add(time.Hour), Close: 8}}, nil
+			}
+			return nil, errors.New("no data")
+		},
+	}
+	_, err := s.BenchmarkAgainstHold(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.BenchmarkAgainstHold(context.Background(), &btrpc.BenchmarkAgainstHoldRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	exec, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: filepath.Join("..", "config", "strategyexamples", "t2b2-api-candles-exchange-funding.strat"),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	resp, err := s.BenchmarkAgainstHold(context.Background(), &btrpc.BenchmarkAgainstHoldRequest{RunId: exec.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.StrategyReturnPercent != 12 {
+		t.Errorf("received '%v' expecting '%v'", resp.StrategyReturnPercent, 12)
+	}
+	if len(resp.Benchmarks) != 6 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Benchmarks), 6)
+	}
+	for _, b := range resp.Benchmarks {
+		switch b.Base {
+		case "BTC", "DOGE":
+			if b.Error != "" {
+				t.Errorf("received '%v' expecting no error", b.Error)
+			}
+			if math.Abs(b.AlphaPercent-(resp.StrategyReturnPercent-b.HoldReturnPercent)) > 1e-9 {
+				t.Errorf("received alpha '%v' expecting '%v'", b.AlphaPercent, resp.StrategyReturnPercent-b.HoldReturnPercent)
+			}
+		default:
+			if b.Error == "" {
+				t.Errorf("received no error for '%v' expecting a data error", b.Base)
+			}
+		}
+	}
+	if resp.Benchmarks[0].HoldReturnPercent != 5 || resp.Benchmarks[0].AlphaPercent != 7 {
+		t.Errorf("received '%v' expecting hold return '%v' and alpha '%v'", resp.Benchmarks[0], 5, 7)
+	}
+	if resp.Benchmarks[1].HoldReturnPercent != -20 || resp.Benchmarks[1].AlphaPercent != 32 {
+		t.Errorf("received '%v' expecting hold return '%v' and alpha '%v'", resp.Benchmarks[1], -20, 32)
+	}
+}
+
+func TestEstimateDataDownload(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	_, err := s.EstimateDataDownload(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	resp, err := s.EstimateDataDownload(context.Background(), &btrpc.EstimateDataDownloadRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	// 2021-08-01 to 2021-12-01 across a daylight saving change
+	if len(resp.Estimates) != 1 || resp.Estimates[0].Candles != 122 || resp.Estimates[0].Interval != uint64(gctkline.OneDay) {
+		t.Errorf("received '%v' expecting '%v' daily candles", resp.Estimates, 122)
+	}
+	if resp.TotalCandles != 122 || resp.TotalBytes != 122*estimatedCandleBytes {
+		t.Errorf("received '%v %v' expecting '%v %v'", resp.TotalCandles, resp.TotalBytes, 122, 122*estimatedCandleBytes)
+	}
+	if resp.Warning != "" {
+		t.Errorf("received '%v' expecting no warning", resp.Warning)
+	}
+}
+
+func TestVerifyDeterminism(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.event(&fill.Fill{
+				Base:          &event.Base{Exchange: "binance", Time: start, AssetType: asset.Spot},
+				Direction:     gctorder.Buy,
+				Amount:        decimal.NewFromInt(1),
+				PurchasePrice: decimal.NewFromInt(100),
+				ExchangeFee:   decimal.NewFromFloat(0.1),
+				Order:         &gctorder.Detail{Side: gctorder.Buy},
+			})
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(105))
+			return nil
+		},
+	}
+	_, err := s.VerifyDeterminism(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.VerifyDeterminism(context.Background(), &btrpc.VerifyDeterminismRequest{StrategyFilePath: dcaConfigPath, Runs: 1})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.VerifyDeterminism(context.Background(), &btrpc.VerifyDeterminismRequest{StrategyFilePath: dcaConfigPath, Runs: maxDeterminismRuns + 1})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	resp, err := s.VerifyDeterminism(context.Background(), &btrpc.VerifyDeterminismRequest{StrategyFilePath: dcaConfigPath, Runs: 3})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !resp.Deterministic || resp.DivergentRun != 0 {
+		t.Errorf("received '%v' expecting a deterministic result", resp)
+	}
+	if len(resp.Summaries) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Summaries), 3)
+	}
+	if resp.Summaries[2].Trades != 1 || resp.Summaries[2].ReturnPercent != 5 || resp.Summaries[2].Volume != 100 {
+		t.Errorf("received '%v' expecting 1 trade of 100 volume returning 5 percent", resp.Summaries[2])
+	}
+
+	progress, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: resp.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if progress.Status != RunStatusCompleted || progress.EventsProcessed != 3 {
+		t.Errorf("received '%v' expecting a completed run with 3 executions", progress)
+	}
+}
+
+func TestPauseResumeStrategy(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			close(started)
+			for i := int64(1); i <= 100; i++ {
+				hooks.gate.wait()
+				hooks.progress(i, 100)
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		},
+	}
+	_, err := s.PauseStrategy(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ResumeStrategy(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.PauseStrategy(context.Background(), &btrpc.PauseStrategyRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	executed := make(chan *btrpc.ExecuteStrategyResponse, 1)
+	go func() {
+		resp, execErr := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+		})
+		if !errors.Is(execErr, nil) {
+			t.Errorf("received '%v' expecting '%v'", execErr, nil)
+		}
+		executed <- resp
+	}()
+	<-started
+	s.runs.m.Lock()
+	runID := s.runs.runs[0].ID.String()
+	s.runs.m.Unlock()
+
+	_, err = s.PauseStrategy(context.Background(), &btrpc.PauseStrategyRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.PauseStrategy(context.Background(), &btrpc.PauseStrategyRequest{RunId: runID})
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+	// allow the event being processed when paused to complete
+	time.Sleep(time.Millisecond * 10)
+	paused, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !paused.Paused || paused.Status != RunStatusRunning || paused.EtaSeconds != nil {
+		t.Errorf("received '%v' expecting a paused running run without an eta", paused)
+	}
+	time.Sleep(time.Millisecond * 20)
+	stillPaused, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if stillPaused.EventsProcessed != paused.EventsProcessed {
+		t.Errorf("received '%v' expecting '%v' events processed while paused", stillPaused.EventsProcessed, paused.EventsProcessed)
+	}
+	runs, err := s.ListRuns(context.Background(), &btrpc.ListRunsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs.Runs) != 1 || !runs.Runs[0].Paused {
+		t.Errorf("received '%v' expecting a paused run", runs.Runs)
+	}
+
+	resumed, err := s.ResumeStrategy(context.Background(), &btrpc.ResumeStrategyRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resumed.Paused {
+		t.Error("expected run to be resumed")
+	}
+	select {
+	case <-executed:
+	case <-time.After(time.Second * 10):
+		t.Fatal("timed out waiting for resumed run to complete")
+	}
+	progress, err := s.GetRunProgress(context.Background(), &btrpc.GetRunProgressRequest{RunId: runID})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if progress.Status != RunStatusCompleted || progress.Paused || progress.EventsProcessed != 100 {
+		t.Errorf("received '%v' expecting a completed run with 100 events processed", progress)
+	}
+	_, err = s.ResumeStrategy(context.Background(), &btrpc.ResumeStrategyRequest{RunId: runID})
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+}
+
+type fakeDrawdownStream struct {
+	grpc.ServerStream
+	periods []*btrpc.DrawdownPeriod
+}
+
+func (f *fakeDrawdownStream) Send(resp *btrpc.DrawdownPeriod) error {
+	f.periods = append(f.periods, resp)
+	return nil
+}
+
+func (f *fakeDrawdownStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamDrawdownPeriods(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for i, equity := range []int64{100, 104, 91, 98, 110, 108} {
+				hooks.equity("binance spot BTC-USDT", start.Add(time.Duration(i)*time.Hour), decimal.NewFromInt(equity))
+			}
+			return nil
+		},
+	}
+	stream := &fakeDrawdownStream{}
+	err := s.StreamDrawdownPeriods(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = s.StreamDrawdownPeriods(&btrpc.StreamDrawdownPeriodsRequest{RunId: uuid.Nil.String(), MinDrawdownPercent: -1}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	err = s.StreamDrawdownPeriods(&btrpc.StreamDrawdownPeriodsRequest{RunId: uuid.Nil.String()}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = s.StreamDrawdownPeriods(&btrpc.StreamDrawdownPeriodsRequest{RunId: resp.RunId, MinDrawdownPercent: 5}, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.periods) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(stream.periods), 1)
+	}
+	p := stream.periods[0]
+	if p.PeakEquity != 104 || p.TroughEquity != 91 || !p.Recovered || p.DurationSeconds != 3*60*60 {
+		t.Errorf("received '%v' expecting a recovered fall from 104 to 91 lasting 3 hours", p)
+	}
+	if !p.RecoveryTime.AsTime().Equal(start.Add(time.Hour * 4)) {
+		t.Errorf("received '%v' expecting '%v'", p.RecoveryTime.AsTime(), start.Add(time.Hour*4))
+	}
+}
+
+func TestExecuteStrategyAcrossPairs(t *testing.T) {
+	t.Parallel()
+	var m sync.Mutex
+	traded := make(map[string]bool)
+	var active, peak int32
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(cfg *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			running := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				highest := atomic.LoadInt32(&peak)
+				if running <= highest || atomic.CompareAndSwapInt32(&peak, highest, running) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 10)
+			m.Lock()
+			traded[cfg.CurrencySettings[0].Base.String()+"-"+cfg.CurrencySettings[0].Quote.String()] = true
+			m.Unlock()
+			return nil
+		},
+	}
+	_, err := s.ExecuteStrategyAcrossPairs(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ExecuteStrategyAcrossPairs(context.Background(), &btrpc.ExecuteStrategyAcrossPairsRequest{StrategyFilePath: dcaConfigPath})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.ExecuteStrategyAcrossPairs(context.Background(), &btrpc.ExecuteStrategyAcrossPairsRequest{StrategyFilePath: dcaConfigPath, Pairs: []string{"BT"}})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.ExecuteStrategyAcrossPairs(context.Background(), &btrpc.ExecuteStrategyAcrossPairsRequest{
+		StrategyFilePath: filepath.Join("..", "config", "strategyexamples", "t2b2-api-candles-exchange-funding.strat"),
+		Pairs:            []string{"BTC-USDT"},
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	pairs := []string{"BTC-USDT", "ETH-USDT", "LTC-USDT"}
+	resp, err := s.ExecuteStrategyAcrossPairs(context.Background(), &btrpc.ExecuteStrategyAcrossPairsRequest{
+		StrategyFilePath: dcaConfigPath,
+		Pairs:            pairs,
+		MaxConcurrency:   2,
+		Labels:           map[string]string{"grid": "usdt"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Runs) != len(pairs) {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Runs), len(pairs))
+	}
+	for i := range resp.Runs {
+		if resp.Runs[i].Pair != pairs[i] || resp.Runs[i].RunId == "" || resp.Runs[i].Error != "" {
+			t.Errorf("received '%v' expecting a run of '%v'", resp.Runs[i], pairs[i])
+		}
+		if !traded[pairs[i]] {
+			t.Errorf("received '%v' expecting '%v'", traded[pairs[i]], true)
+		}
+	}
+	if peak := atomic.LoadInt32(&peak); peak > 2 {
+		t.Errorf("received '%v' expecting at most '%v'", peak, 2)
+	}
+	runs, err := s.ListRuns(context.Background(), &btrpc.ListRunsRequest{Labels: map[string]string{"grid": "usdt"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs.Runs) != len(pairs) {
+		t.Errorf("received '%v' expecting '%v'", len(runs.Runs), len(pairs))
+	}
+}
+
+func TestGetRollingMetrics(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for i := 0; i <= 90; i++ {
+				hooks.equity("binance spot BTC-USDT", start.AddDate(0, 0, i), decimal.NewFromInt(int64(1000+i)))
+			}
+			return nil
+		},
+	}
+	_, err := s.GetRollingMetrics(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetRollingMetrics(context.Background(), &btrpc.GetRollingMetricsRequest{RunId: uuid.Nil.String(), WindowSeconds: 60})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	run, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{
+		StrategyFilePath: dcaConfigPath,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.GetRollingMetrics(context.Background(), &btrpc.GetRollingMetricsRequest{RunId: run.RunId})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.GetRollingMetrics(context.Background(), &btrpc.GetRollingMetricsRequest{RunId: run.RunId, WindowSeconds: 1, StepSeconds: 1})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	day := int64(24 * time.Hour / time.Second)
+	resp, err := s.GetRollingMetrics(context.Background(), &btrpc.GetRollingMetricsRequest{RunId: run.RunId, WindowSeconds: 30 * day})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Windows) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Windows), 3)
+	}
+	if !resp.Windows[2].End.AsTime().Equal(start.AddDate(0, 0, 90)) || resp.Windows[2].Points != 31 {
+		t.Errorf("received '%v' expecting the final window to end on the last point", resp.Windows[2])
+	}
+	if resp.Windows[0].ReturnPercent != 3 || resp.Windows[0].MaxDrawdownPercent != 0 {
+		t.Errorf("received '%v' expecting a 3 percent return without drawdown", resp.Windows[0])
+	}
+
+	resp, err = s.GetRollingMetrics(context.Background(), &btrpc.GetRollingMetricsRequest{RunId: run.RunId, WindowSeconds: 30 * day, StepSeconds: day})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(resp.Windows) != 61 {
+		t.Errorf("received '%v' expecting '%v'", len(resp.Windows), 61)
+	}
+}
+
+func TestCompareRuns(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var executions int64
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			final := 100 + 10*atomic.AddInt64(&executions, 1)
+			hooks.equity("binance spot BTC-USDT", start, decimal.NewFromInt(100))
+			hooks.equity("binance spot BTC-USDT", start.Add(time.Hour), decimal.NewFromInt(final))
+			return nil
+		},
+	}
+	_, err := s.SetBaselineRun(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.CompareRuns(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.SetBaselineRun(context.Background(), &btrpc.SetBaselineRunRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	baseline, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	latest, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.CompareRuns(context.Background(), &btrpc.CompareRunsRequest{RunId: latest.RunId})
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("received '%v' expecting '%v'", err, codes.FailedPrecondition)
+	}
+
+	set, err := s.SetBaselineRun(context.Background(), &btrpc.SetBaselineRunRequest{RunId: baseline.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if set.RunId != baseline.RunId || set.PreviousRunId != "" {
+		t.Errorf("received '%v' expecting a first baseline of '%v'", set, baseline.RunId)
+	}
+	resp, err := s.CompareRuns(context.Background(), &btrpc.CompareRunsRequest{RunId: latest.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.OtherRunId != baseline.RunId || !resp.OtherIsBaseline {
+		t.Errorf("received '%v' expecting a comparison against baseline '%v'", resp.OtherRunId, baseline.RunId)
+	}
+	if resp.Run.FinalEquity != 120 || resp.Other.FinalEquity != 110 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", resp.Run.FinalEquity, resp.Other.FinalEquity, 120, 110)
+	}
+	if resp.Difference.ReturnPercent != 10 || resp.Difference.FinalEquity != 10 {
+		t.Errorf("received '%v' expecting a 10 percent improvement", resp.Difference)
+	}
+
+	resp, err = s.CompareRuns(context.Background(), &btrpc.CompareRunsRequest{RunId: baseline.RunId, OtherRunId: latest.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.OtherIsBaseline || resp.Difference.ReturnPercent != -10 {
+		t.Errorf("received '%v' expecting an explicit comparison 10 percent worse", resp)
+	}
+}
+
+type fakeCandleStream struct {
+	grpc.ServerStream
+	candles []*btrpc.RunCandle
+}
+
+func (f *fakeCandleStream) Send(resp *btrpc.RunCandle) error {
+	f.candles = append(f.candles, resp)
+	return nil
+}
+
+func (f *fakeCandleStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamRunCandles(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for _, hour := range []int{2, 0, 3, 1} {
+				for _, p := range []currency.Pair{currency.NewPair(currency.BTC, currency.USDT), currency.NewPair(currency.ETH, currency.USDT)} {
+					hooks.candle(&evkline.Kline{
+						Base: &event.Base{
+							Exchange:     "binance",
+							Time:         start.Add(time.Duration(hour) * time.Hour),
+							AssetType:    asset.Spot,
+							CurrencyPair: p,
+						},
+						Open:   decimal.NewFromInt(int64(hour)),
+						High:   decimal.NewFromInt(int64(hour + 2)),
+						Low:    decimal.NewFromInt(int64(hour - 1)),
+						Close:  decimal.NewFromInt(int64(hour + 1)),
+						Volume: decimal.NewFromInt(10),
+					})
+				}
+			}
+			return nil
+		},
+	}
+	stream := &fakeCandleStream{}
+	err := s.StreamRunCandles(nil, stream)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	request := &btrpc.StreamRunCandlesRequest{RunId: uuid.Nil.String(), ExchangeName: "binance", Asset: "spot", Base: "btc", Quote: "usdt"}
+	err = s.StreamRunCandles(&btrpc.StreamRunCandlesRequest{RunId: uuid.Nil.String()}, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	err = s.StreamRunCandles(request, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	request.RunId = resp.RunId
+	err = s.StreamRunCandles(request, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.candles) != 4 {
+		t.Fatalf("received '%v' expecting '%v'", len(stream.candles), 4)
+	}
+	for i := range stream.candles {
+		if !stream.candles[i].Time.AsTime().Equal(start.Add(time.Duration(i) * time.Hour)) {
+			t.Errorf("received '%v' expecting '%v'", stream.candles[i].Time.AsTime(), start.Add(time.Duration(i)*time.Hour))
+		}
+		if i > 0 && !stream.candles[i].Time.AsTime().After(stream.candles[i-1].Time.AsTime()) {
+			t.Errorf("received '%v' expecting candles ordered by time", stream.candles[i].Time.AsTime())
+		}
+	}
+	if c := stream.candles[1]; c.Open != 1 || c.High != 3 || c.Low != 0 || c.Close != 2 || c.Volume != 10 {
+		t.Errorf("received '%v' expecting the second hour's candle", c)
+	}
+
+	stream = &fakeCandleStream{}
+	request.StartDate = timestamppb.New(start.Add(time.Hour))
+	request.EndDate = timestamppb.New(start.Add(2 * time.Hour))
+	err = s.StreamRunCandles(request, stream)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.candles) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(stream.candles), 2)
+	}
+	request.StartDate, request.EndDate = request.EndDate, request.StartDate
+	err = s.StreamRunCandles(request, stream)
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+}
+
+func TestExecuteStrategyAbortOnDrawdown(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var handled int64
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			for i, equity := range []int64{100, 110, 95, 80, 70, 120} {
+				b := &event.Base{Exchange: "binance", Time: start.Add(time.Duration(i) * time.Hour), AssetType: asset.Spot}
+				hooks.equity("binance spot BTC-USDT", b.Time, decimal.NewFromInt(equity))
+				atomic.AddInt64(&handled, 1)
+				if !hooks.event(&evkline.Kline{Base: b}) {
+					break
+				}
+			}
+			return nil
+		},
+	}
+	_, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath, AbortOnDrawdownPct: -1})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	_, err = s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath, AbortOnDrawdownPct: 25})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Aborted {
+		t.Fatalf("received '%v' expecting '%v'", err, codes.Aborted)
+	}
+	if events := atomic.LoadInt64(&handled); events != 4 {
+		t.Errorf("received '%v' expecting the run to abort after '%v' events", events, 4)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(st.Details()), 1)
+	}
+	details, ok := st.Details()[0].(*btrpc.ExecuteStrategyResponse)
+	if !ok {
+		t.Fatalf("received '%T' expecting '%T'", st.Details()[0], details)
+	}
+	id, err := uuid.FromString(details.RunId)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	run, err := s.runs.GetRun(id)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if run.Status != RunStatusAborted || run.Error == "" {
+		t.Errorf("received '%v' '%v' expecting '%v' with a reason", run.Status, run.Error, RunStatusAborted)
+	}
+	if len(run.EquityCurve) != 4 {
+		t.Errorf("received '%v' expecting partial results of '%v' points", len(run.EquityCurve), 4)
+	}
+
+	atomic.StoreInt64(&handled, 0)
+	resp, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath, AbortOnDrawdownPct: 50})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !resp.Success || atomic.LoadInt64(&handled) != 6 {
+		t.Errorf("received '%v' expecting the run to complete all '%v' events", resp, 6)
+	}
+}
+
+func TestComputeRunCorrelation(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var executions int64
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, hooks *runHooks) error {
+			scale := atomic.AddInt64(&executions, 1)
+			for i, equity := range []int64{100, 105, 98, 112, 120} {
+				hooks.equity("binance spot BTC-USDT", start.Add(time.Duration(i)*time.Hour), decimal.NewFromInt(equity*scale))
+			}
+			return nil
+		},
+	}
+	_, err := s.ComputeRunCorrelation(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ComputeRunCorrelation(context.Background(), &btrpc.ComputeRunCorrelationRequest{RunId: uuid.Nil.String(), OtherRunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	first, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	second, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	resp, err := s.ComputeRunCorrelation(context.Background(), &btrpc.ComputeRunCorrelationRequest{RunId: first.RunId, OtherRunId: second.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if math.Abs(resp.Correlation-1) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", resp.Correlation, 1)
+	}
+	if resp.AlignedPoints != 5 || resp.RunId != first.RunId || resp.OtherRunId != second.RunId {
+		t.Errorf("received '%v' expecting 5 aligned points", resp)
+	}
+}
+
+func TestGetRunFeeSchedule(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			return nil
+		},
+	}
+	_, err := s.GetRunFeeSchedule(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetRunFeeSchedule(context.Background(), &btrpc.GetRunFeeScheduleRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	cfg, err := config.ReadStrategyConfigFromFile(dcaConfigPath)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	run, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	resp, err := s.GetRunFeeSchedule(context.Background(), &btrpc.GetRunFeeScheduleRequest{RunId: run.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.RunId != run.RunId {
+		t.Errorf("received '%v' expecting '%v'", resp.RunId, run.RunId)
+	}
+	if len(resp.Fees) != len(cfg.CurrencySettings) {
+		t.Fatalf("received '%v' expecting '%v'", len(resp.Fees), len(cfg.CurrencySettings))
+	}
+	for i := range cfg.CurrencySettings {
+		cs := cfg.CurrencySettings[i]
+		fee := resp.Fees[i]
+		if fee.ExchangeName != cs.ExchangeName || fee.Asset != cs.Asset.String() || fee.Base != cs.Base.String() || fee.Quote != cs.Quote.String() {
+			t.Errorf("received '%v' expecting '%v %v %v-%v'", fee, cs.ExchangeName, cs.Asset, cs.Base, cs.Quote)
+		}
+		if fee.MakerFee != cs.MakerFee.String() {
+			t.Errorf("received '%v' expecting '%v'", fee.MakerFee, cs.MakerFee)
+		}
+		if fee.TakerFee != cs.TakerFee.String() {
+			t.Errorf("received '%v' expecting '%v'", fee.TakerFee, cs.TakerFee)
+		}
+		if fee.UsingExchangeMakerFee || fee.UsingExchangeTakerFee {
+			t.Errorf("received '%v' expecting config fee overrides", fee)
+		}
+	}
+}
+
+func TestScheduleStrategy(t *testing.T) {
+	t.Parallel()
+	c := &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var executions int64
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			atomic.AddInt64(&executions, 1)
+			return nil
+		},
+		clock: c,
+	}
+	_, err := s.ScheduleStrategy(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.ScheduleStrategy(context.Background(), &btrpc.ScheduleStrategyRequest{StrategyFilePath: dcaConfigPath})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.ScheduleStrategy(context.Background(), &btrpc.ScheduleStrategyRequest{StrategyFilePath: dcaConfigPath, StartTime: timestamppb.New(c.now.Add(-time.Minute))})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+
+	scheduled, err := s.ScheduleStrategy(context.Background(), &btrpc.ScheduleStrategyRequest{
+		StrategyFilePath: dcaConfigPath,
+		StartTime:        timestamppb.New(c.now.Add(time.Hour)),
+		Labels:           map[string]string{"window": "off-peak"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	cancelled, err := s.ScheduleStrategy(context.Background(), &btrpc.ScheduleStrategyRequest{StrategyFilePath: dcaConfigPath, StartTime: timestamppb.New(c.now.Add(time.Hour))})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	resp, err := s.CancelStrategy(context.Background(), &btrpc.CancelStrategyRequest{ScheduledId: cancelled.ScheduledId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.ScheduledId != cancelled.ScheduledId || !resp.StartTime.AsTime().Equal(c.now.Add(time.Hour)) {
+		t.Errorf("received '%v' expecting '%v'", resp, cancelled)
+	}
+
+	c.advance(time.Minute * 30)
+	if atomic.LoadInt64(&executions) != 0 {
+		t.Errorf("received '%v' expecting '%v'", atomic.LoadInt64(&executions), 0)
+	}
+	c.advance(time.Minute * 30)
+	if atomic.LoadInt64(&executions) != 1 {
+		t.Errorf("received '%v' expecting '%v'", atomic.LoadInt64(&executions), 1)
+	}
+	runs, err := s.runs.ListRuns(map[string]string{"window": "off-peak"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 1 || runs[0].Status != RunStatusCompleted {
+		t.Errorf("received '%v' expecting one completed run", runs)
+	}
+
+	_, err = s.CancelStrategy(context.Background(), &btrpc.CancelStrategyRequest{ScheduledId: scheduled.ScheduledId})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	_, err = s.CancelStrategy(context.Background(), &btrpc.CancelStrategyRequest{ScheduledId: cancelled.ScheduledId})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+	_, err = s.CancelStrategy(context.Background(), &btrpc.CancelStrategyRequest{ScheduledId: "not-a-uuid"})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+}
+
+func TestRecordAndReplaySession(t *testing.T) {
+	t.Parallel()
+	var executions int64
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			atomic.AddInt64(&executions, 1)
+			return nil
+		},
+	}
+	_, err := s.RecordSession(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.RecordSession(context.Background(), &btrpc.RecordSessionRequest{Stop: true})
+	if st, _ := status.FromError(err); st.Code() != codes.InvalidArgument {
+		t.Errorf("received '%v' expecting '%v'", err, codes.InvalidArgument)
+	}
+	_, err = s.ReplaySession(context.Background(), &btrpc.ReplaySessionRequest{SessionId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	started, err := s.RecordSession(context.Background(), &btrpc.RecordSessionRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !started.Recording {
+		t.Errorf("received '%v' expecting '%v'", started.Recording, true)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(sessionMetadataKey, started.SessionId))
+	info := &grpc.UnaryServerInfo{FullMethod: "/btrpc.BacktesterService/ExecuteStrategyFromFile"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.ExecuteStrategyFromFile(ctx, req.(*btrpc.ExecuteStrategyFromFileRequest))
+	}
+	recordedRuns := make(map[string]bool)
+	for _, label := range []string{"first", "second"} {
+		resp, execErr := s.recordSessionCall(ctx, &btrpc.ExecuteStrategyFromFileRequest{
+			StrategyFilePath: dcaConfigPath,
+			Labels:           map[string]string{"call": label},
+		}, info, handler)
+		if !errors.Is(execErr, nil) {
+			t.Fatalf("received '%v' expecting '%v'", execErr, nil)
+		}
+		recordedRuns[resp.(*btrpc.ExecuteStrategyResponse).RunId] = true
+	}
+	// calls without the session metadata are not recorded
+	_, err = s.recordSessionCall(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath}, info, handler)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	// calls which change server state are not recorded so cannot be replayed
+	maxRuns := uint64(3)
+	_, err = s.recordSessionCall(ctx, &btrpc.UpdateServerConfigRequest{MaxConcurrentRuns: &maxRuns},
+		&grpc.UnaryServerInfo{FullMethod: "/btrpc.BacktesterService/UpdateServerConfig"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return s.UpdateServerConfig(ctx, req.(*btrpc.UpdateServerConfigRequest))
+		})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = s.replayCall(context.Background(), &recordedCall{method: "UpdateServerConfig"})
+	if !errors.Is(err, errMethodNotReplayed) {
+		t.Errorf("received '%v' expecting '%v'", err, errMethodNotReplayed)
+	}
+	stopped, err := s.RecordSession(context.Background(), &btrpc.RecordSessionRequest{SessionId: started.SessionId, Stop: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if stopped.Recording || stopped.Calls != 2 {
+		t.Errorf("received '%v' expecting 2 recorded calls", stopped)
+	}
+
+	replayed, err := s.ReplaySession(context.Background(), &btrpc.ReplaySessionRequest{SessionId: started.SessionId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(replayed.Calls) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(replayed.Calls), 2)
+	}
+	for i, label := range []string{"first", "second"} {
+		call := replayed.Calls[i]
+		if !call.Success || call.Method != "ExecuteStrategyFromFile" {
+			t.Fatalf("received '%v' expecting a successful ExecuteStrategyFromFile call", call)
+		}
+		resp := &btrpc.ExecuteStrategyResponse{}
+		if err = call.Response.UnmarshalTo(resp); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		if recordedRuns[resp.RunId] {
+			t.Errorf("received recorded run '%v' expecting a new run", resp.RunId)
+		}
+		id, idErr := uuid.FromString(resp.RunId)
+		if !errors.Is(idErr, nil) {
+			t.Fatalf("received '%v' expecting '%v'", idErr, nil)
+		}
+		run, runErr := s.runs.GetRun(id)
+		if !errors.Is(runErr, nil) {
+			t.Fatalf("received '%v' expecting '%v'", runErr, nil)
+		}
+		if run.Labels["call"] != label {
+			t.Errorf("received '%v' expecting '%v'", run.Labels["call"], label)
+		}
+	}
+	if atomic.LoadInt64(&executions) != 5 {
+		t.Errorf("received '%v' expecting '%v'", atomic.LoadInt64(&executions), 5)
+	}
+}
+
+func TestGetRunProvenance(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{
+		BacktesterConfig: &config.BacktesterConfig{},
+		strategyExecutor: func(_ *config.Config, _ *config.BacktesterConfig, _ *runHooks) error {
+			return nil
+		},
+	}
+	_, err := s.GetRunProvenance(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = s.GetRunProvenance(context.Background(), &btrpc.GetRunProvenanceRequest{RunId: uuid.Nil.String()})
+	if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+		t.Errorf("received '%v' expecting '%v'", err, codes.NotFound)
+	}
+
+	run, err := s.ExecuteStrategyFromFile(context.Background(), &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	info, err := s.GetServerInfo(context.Background(), &btrpc.GetServerInfoRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if info.Version == "" || info.GoVersion != runtime.Version() {
+		t.Errorf("received '%v' expecting the server build info", info)
+	}
+	resp, err := s.GetRunProvenance(context.Background(), &btrpc.GetRunProvenanceRequest{RunId: run.RunId})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.RunId != run.RunId {
+		t.Errorf("received '%v' expecting '%v'", resp.RunId, run.RunId)
+	}
+	if resp.Version != info.Version || resp.Commit != info.Commit || resp.GoVersion != info.GoVersion {
+		t.Errorf("received '%v' expecting '%v'", resp, info)
+	}
+	cfg, err := config.ReadStrategyConfigFromFile(dcaConfigPath)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	hash, err := HashConfig(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.ConfigHash != hash {
+		t.Errorf("received '%v' expecting '%v'", resp.ConfigHash, hash)
+	}
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+)
+
+// rankRuns ranks the completed runs by the metric, keeping at most limit
+// entries when limit is positive. Failed and cancelled runs count as finished
+// but are not ranked. Ties are ordered by start time
+func rankRuns(runs []*Run, metric string, limit int) (*leaderboard, error) {
+	if metric == "" {
+		metric = LeaderboardMetricReturn
+	}
+	value, ok := leaderboardMetrics[metric]
+	if !ok {
+		return nil, errUnknownMetric
+	}
+	board := &leaderboard{}
+	var completed []*Run
+	for i := range runs {
+		switch runs[i].Status {
+		case RunStatusRunning:
+			board.running++
+			continue
+		case RunStatusCompleted:
+			completed = append(completed, runs[i])
+		}
+		board.finished++
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[i].StartTime.Before(completed[j].StartTime)
+	})
+	board.entries = make([]leaderboardEntry, len(completed))
+	for i := range completed {
+		board.entries[i] = leaderboardEntry{
+			runID:    completed[i].ID,
+			strategy: completed[i].Strategy,
+			labels:   completed[i].Labels,
+			value:    value(completed[i]),
+		}
+	}
+	ascending := metric == LeaderboardMetricMaxDrawdown
+	sort.SliceStable(board.entries, func(i, j int) bool {
+		if ascending {
+			return board.entries[i].value < board.entries[j].value
+		}
+		return board.entries[i].value > board.entries[j].value
+	})
+	if limit > 0 && len(board.entries) > limit {
+		board.entries = board.entries[:limit]
+	}
+	for i := range board.entries {
+		board.entries[i].rank = i + 1
+	}
+	return board, nil
+}
+
+// leaderboardMetrics calculate the value of each metric for a run
+var leaderboardMetrics = map[string]func(*Run) float64{
+	LeaderboardMetricReturn: func(r *Run) float64 {
+		return equityReturnPercent(r.EquityCurve)
+	},
+	LeaderboardMetricMaxDrawdown: func(r *Run) float64 {
+		var peak, maxDrawdown float64
+		for i := range r.EquityCurve {
+			if r.EquityCurve[i].Equity > peak {
+				peak = r.EquityCurve[i].Equity
+			}
+			if peak == 0 {
+				continue
+			}
+			if drawdown := (peak - r.EquityCurve[i].Equity) / peak * 100; drawdown > maxDrawdown {
+				maxDrawdown = drawdown
+			}
+		}
+		return maxDrawdown
+	},
+	LeaderboardMetricRealisedPNL: func(r *Run) float64 {
+		var pnl float64
+		for i := range r.Trades {
+			pnl += r.Trades[i].RealisedPNL
+		}
+		return pnl
+	},
+	LeaderboardMetricTradeCount: func(r *Run) float64 {
+		return float64(len(r.Trades))
+	},
+}
+
+// equityReturnPercent returns the percentage change from the first to the last
+// point of the equity curve
+func equityReturnPercent(points []EquityPoint) float64 {
+	if len(points) == 0 || points[0].Equity == 0 {
+		return 0
+	}
+	first, last := points[0].Equity, points[len(points)-1].Equity
+	return (last - first) / first * 100
+}
+
+// toRPC converts the leaderboard to its GRPC representation
+func (l *leaderboard) toRPC(sweepID, metric string) *btrpc.SweepLeaderboardUpdate {
+	resp := &btrpc.SweepLeaderboardUpdate{
+		SweepId:      sweepID,
+		Metric:       metric,
+		Entries:      make([]*btrpc.LeaderboardEntry, len(l.entries)),
+		FinishedRuns: uint64(l.finished),
+		RunningRuns:  uint64(l.running),
+	}
+	for i := range l.entries {
+		resp.Entries[i] = &btrpc.LeaderboardEntry{
+			Rank:         uint64(l.entries[i].rank),
+			RunId:        l.entries[i].runID.String(),
+			StrategyName: l.entries[i].strategy,
+			Labels:       l.entries[i].labels,
+			Value:        l.entries[i].value,
+		}
+	}
+	return resp
+}
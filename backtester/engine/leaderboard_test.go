@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestRankRuns(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	newRun := func(runStatus string, offset time.Duration, equity ...float64) *Run {
+		r := &Run{ID: uuid.Must(uuid.NewV4()), Status: runStatus, StartTime: start.Add(offset)}
+		for i := range equity {
+			r.EquityCurve = append(r.EquityCurve, EquityPoint{Equity: equity[i]})
+		}
+		return r
+	}
+	runs := []*Run{
+		newRun(RunStatusCompleted, 0, 100, 80, 110),
+		newRun(RunStatusCompleted, time.Hour, 100, 120, 110),
+		newRun(RunStatusRunning, time.Hour, 100),
+		newRun(RunStatusFailed, time.Hour, 100),
+		newRun(RunStatusCompleted, time.Minute, 100, 90),
+	}
+
+	_, err := rankRuns(runs, "vibes", 0)
+	if !errors.Is(err, errUnknownMetric) {
+		t.Errorf("received '%v' expecting '%v'", err, errUnknownMetric)
+	}
+
+	board, err := rankRuns(runs, "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if board.finished != 4 || board.running != 1 {
+		t.Errorf("received '%v %v' expecting '%v %v'", board.finished, board.running, 4, 1)
+	}
+	// equal returns are ordered by start time
+	for i, expected := range []*Run{runs[0], runs[1], runs[4]} {
+		if board.entries[i].runID != expected.ID || board.entries[i].rank != i+1 {
+			t.Errorf("received '%v' expecting '%v' at rank '%v'", board.entries[i].runID, expected.ID, i+1)
+		}
+	}
+
+	board, err = rankRuns(runs, LeaderboardMetricMaxDrawdown, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(board.entries) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(board.entries), 2)
+	}
+	if board.entries[0].runID != runs[1].ID || board.entries[1].runID != runs[4].ID {
+		t.Errorf("received '%v %v' expecting '%v %v'", board.entries[0].runID, board.entries[1].runID, runs[1].ID, runs[4].ID)
+	}
+}
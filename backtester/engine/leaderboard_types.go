@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// SweepLabel is the run label grouping the runs of a parameter sweep,
+	// its value is the sweep ID
+	SweepLabel = "sweep"
+
+	// LeaderboardMetricReturn ranks runs by the percentage change of their
+	// equity curve, highest first
+	LeaderboardMetricReturn = "return"
+	// LeaderboardMetricMaxDrawdown ranks runs by the largest percentage fall
+	// of their equity curve from a peak, lowest first
+	LeaderboardMetricMaxDrawdown = "max-drawdown"
+	// LeaderboardMetricRealisedPNL ranks runs by the sum of the realised
+	// profit of their trades, highest first
+	LeaderboardMetricRealisedPNL = "realised-pnl"
+	// LeaderboardMetricTradeCount ranks runs by the number of trades they
+	// made, highest first
+	LeaderboardMetricTradeCount = "trade-count"
+)
+
+var (
+	errUnknownMetric = errors.New("unknown leaderboard metric")
+	errNoSweepID     = errors.New("sweep id required")
+)
+
+// leaderboardEntry is a completed run ranked by a metric
+type leaderboardEntry struct {
+	rank     int
+	runID    uuid.UUID
+	strategy string
+	labels   map[string]string
+	value    float64
+}
+
+// leaderboard holds the ranked completed runs of a sweep along with the
+// number of its runs which have finished or are still running
+type leaderboard struct {
+	entries  []leaderboardEntry
+	finished int
+	running  int
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+)
+
+// monteCarlo bootstrap resamples the net profit of each trade, drawing with
+// replacement as many trades as the run made, to build the distribution of
+// returns and maximum drawdowns from the starting equity
+func monteCarlo(trades []TradeRecord, startingEquity float64, iterations int, rng *rand.Rand) (*monteCarloResult, error) {
+	if len(trades) == 0 {
+		return nil, errNoTrades
+	}
+	if startingEquity <= 0 || math.IsNaN(startingEquity) || math.IsInf(startingEquity, 0) {
+		return nil, errInvalidStartingEquity
+	}
+	if iterations <= 0 {
+		return nil, errInvalidIterationAmount
+	}
+	profits := make([]float64, len(trades))
+	for i := range trades {
+		profits[i] = trades[i].RealisedPNL - trades[i].Fee
+	}
+	result := &monteCarloResult{}
+	result.actualReturnPercent, result.actualMaxDrawdownPercent = tradeOutcome(startingEquity, len(profits), func(i int) float64 {
+		return profits[i]
+	})
+
+	returns := make([]float64, iterations)
+	drawdowns := make([]float64, iterations)
+	for i := 0; i < iterations; i++ {
+		returns[i], drawdowns[i] = tradeOutcome(startingEquity, len(profits), func(int) float64 {
+			return profits[rng.Intn(len(profits))]
+		})
+	}
+	sort.Float64s(returns)
+	sort.Float64s(drawdowns)
+	result.percentiles = make([]monteCarloPercentile, len(monteCarloPercentiles))
+	for i := range monteCarloPercentiles {
+		result.percentiles[i] = monteCarloPercentile{
+			percentile:         monteCarloPercentiles[i],
+			returnPercent:      percentileOf(returns, monteCarloPercentiles[i]),
+			maxDrawdownPercent: percentileOf(drawdowns, monteCarloPercentiles[i]),
+		}
+	}
+	return result, nil
+}
+
+// tradeOutcome applies a sequence of trade profits to the starting equity and
+// returns the percentage return and the largest percentage fall from a peak
+func tradeOutcome(startingEquity float64, count int, profit func(int) float64) (returnPercent, maxDrawdownPercent float64) {
+	equity, peak := startingEquity, startingEquity
+	for i := 0; i < count; i++ {
+		equity += profit(i)
+		if equity > peak {
+			peak = equity
+		}
+		if drawdown := (peak - equity) / peak * 100; drawdown > maxDrawdownPercent {
+			maxDrawdownPercent = drawdown
+		}
+	}
+	return (equity - startingEquity) / startingEquity * 100, maxDrawdownPercent
+}
+
+// percentileOf returns the nearest rank percentile of sorted values
+func percentileOf(sorted []float64, percentile float64) float64 {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// toRPC converts the result to its GRPC representation
+func (m *monteCarloResult) toRPC() []*btrpc.MonteCarloPercentile {
+	resp := make([]*btrpc.MonteCarloPercentile, len(m.percentiles))
+	for i := range m.percentiles {
+		resp[i] = &btrpc.MonteCarloPercentile{
+			Percentile:         m.percentiles[i].percentile,
+			ReturnPercent:      m.percentiles[i].returnPercent,
+			MaxDrawdownPercent: m.percentiles[i].maxDrawdownPercent,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMonteCarlo(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test source
+	_, err := monteCarlo(nil, 100, 10, rng)
+	if !errors.Is(err, errNoTrades) {
+		t.Errorf("received '%v' expecting '%v'", err, errNoTrades)
+	}
+	trades := []TradeRecord{{RealisedPNL: 10}, {RealisedPNL: -19, Fee: 1}, {RealisedPNL: 5}}
+	_, err = monteCarlo(trades, 0, 10, rng)
+	if !errors.Is(err, errInvalidStartingEquity) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidStartingEquity)
+	}
+	_, err = monteCarlo(trades, 100, 0, rng)
+	if !errors.Is(err, errInvalidIterationAmount) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidIterationAmount)
+	}
+
+	result, err := monteCarlo(trades, 100, 500, rng)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if result.actualReturnPercent != -5 {
+		t.Errorf("received '%v' expecting '%v'", result.actualReturnPercent, -5)
+	}
+	if math.Abs(result.actualMaxDrawdownPercent-20.0/110*100) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", result.actualMaxDrawdownPercent, 20.0/110*100)
+	}
+	if len(result.percentiles) != len(monteCarloPercentiles) {
+		t.Fatalf("received '%v' expecting '%v'", len(result.percentiles), len(monteCarloPercentiles))
+	}
+	for i := 1; i < len(result.percentiles); i++ {
+		if result.percentiles[i].returnPercent < result.percentiles[i-1].returnPercent ||
+			result.percentiles[i].maxDrawdownPercent < result.percentiles[i-1].maxDrawdownPercent {
+			t.Errorf("received '%+v' expecting percentiles in ascending order", result.percentiles)
+		}
+	}
+	// every resample of identical trades has the same outcome
+	result, err = monteCarlo([]TradeRecord{{RealisedPNL: 2}, {RealisedPNL: 2}}, 100, 50, rng)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := range result.percentiles {
+		if result.percentiles[i].returnPercent != 4 || result.percentiles[i].maxDrawdownPercent != 0 {
+			t.Errorf("received '%+v' expecting return '%v' and drawdown '%v'", result.percentiles[i], 4, 0)
+		}
+	}
+}
+
+func TestPercentileOf(t *testing.T) {
+	t.Parallel()
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, tc := range []struct {
+		percentile, expected float64
+	}{
+		{percentile: 0, expected: 1},
+		{percentile: 5, expected: 1},
+		{percentile: 50, expected: 5},
+		{percentile: 95, expected: 10},
+		{percentile: 100, expected: 10},
+	} {
+		if v := percentileOf(sorted, tc.percentile); v != tc.expected {
+			t.Errorf("percentile %v received '%v' expecting '%v'", tc.percentile, v, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package engine
+
+import "errors"
+
+var (
+	errNoTrades               = errors.New("run has no trades")
+	errInvalidStartingEquity  = errors.New("starting equity must be positive")
+	errInvalidIterationAmount = errors.New("iterations must be greater than zero")
+)
+
+// monteCarloPercentiles are the percentiles reported from the distribution
+// of resampled outcomes
+var monteCarloPercentiles = []float64{5, 25, 50, 75, 95}
+
+// monteCarloPercentile holds the return and maximum drawdown at a percentile
+// of the resampled outcomes. Each distribution is sorted independently so
+// both values do not necessarily come from the same resample
+type monteCarloPercentile struct {
+	percentile         float64
+	returnPercent      float64
+	maxDrawdownPercent float64
+}
+
+// monteCarloResult holds the outcome of the original trade sequence and the
+// percentiles of the resampled outcomes
+type monteCarloResult struct {
+	actualReturnPercent      float64
+	actualMaxDrawdownPercent float64
+	percentiles              []monteCarloPercentile
+}
@@ -0,0 +1,19 @@
+package engine
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// configForPair returns a copy of a single currency config trading the
+// supplied pair in place of its own
+func configForPair(cfg *config.Config, pair currency.Pair) (*config.Config, error) {
+	if len(cfg.CurrencySettings) != 1 {
+		return nil, errSinglePairConfigRequired
+	}
+	c := *cfg
+	settings := cfg.CurrencySettings[0]
+	settings.Base, settings.Quote = pair.Base, pair.Quote
+	c.CurrencySettings = []config.CurrencySettings{settings}
+	return &c, nil
+}
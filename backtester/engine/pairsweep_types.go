@@ -0,0 +1,5 @@
+package engine
+
+import "errors"
+
+var errSinglePairConfigRequired = errors.New("config must have exactly one currency setting to run across pairs")
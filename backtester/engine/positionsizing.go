@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// sizePositions calculates the initial quote denominated position
+// size of every pair in a config from its funding and buy side limits. Exchange
+// level funding is split evenly between the pairs quoted in its currency. Fees
+// and prices are not known before running, so sizes are upper bounds
+func sizePositions(cfg *config.Config) ([]positionSize, []*fundingPool, error) {
+	if cfg == nil {
+		return nil, nil, fmt.Errorf("%w config", common.ErrNilArguments)
+	}
+	var pools []*fundingPool
+	findPool := func(exch string, a asset.Item, code currency.Code) *fundingPool {
+		for i := range pools {
+			if strings.EqualFold(pools[i].exchange, exch) &&
+				pools[i].asset == a &&
+				pools[i].currency.Equal(code) {
+				return pools[i]
+			}
+		}
+		return nil
+	}
+
+	// assign every pair its pool before sizing so shared funds can be split
+	pairPools := make([]*fundingPool, len(cfg.CurrencySettings))
+	if cfg.FundingSettings.UseExchangeLevelFunding {
+		for i := range cfg.FundingSettings.ExchangeLevelFunding {
+			f := cfg.FundingSettings.ExchangeLevelFunding[i]
+			pools = append(pools, &fundingPool{
+				exchange: f.ExchangeName,
+				asset:    f.Asset,
+				currency: f.Currency,
+				funds:    f.InitialFunds,
+			})
+		}
+		for i := range cfg.CurrencySettings {
+			cs := cfg.CurrencySettings[i]
+			pairPools[i] = findPool(cs.ExchangeName, cs.Asset, cs.Quote)
+		}
+	} else {
+		for i := range cfg.CurrencySettings {
+			cs := cfg.CurrencySettings[i]
+			if cs.SpotDetails == nil || cs.SpotDetails.InitialQuoteFunds == nil {
+				continue
+			}
+			pairPools[i] = &fundingPool{
+				exchange: cs.ExchangeName,
+				asset:    cs.Asset,
+				currency: cs.Quote,
+				funds:    *cs.SpotDetails.InitialQuoteFunds,
+			}
+			pools = append(pools, pairPools[i])
+		}
+	}
+	for i := range pairPools {
+		if pairPools[i] != nil {
+			pairPools[i].pairs++
+		}
+	}
+
+	positions := make([]positionSize, len(cfg.CurrencySettings))
+	for i := range cfg.CurrencySettings {
+		cs := cfg.CurrencySettings[i]
+		positions[i] = positionSize{
+			exchange: cs.ExchangeName,
+			asset:    cs.Asset,
+			pair:     currency.NewPair(cs.Base, cs.Quote),
+		}
+		pool := pairPools[i]
+		if pool == nil || !pool.funds.IsPositive() {
+			positions[i].limitedBy = SizeLimitNoFunds
+			continue
+		}
+		positions[i].available = pool.funds.Div(decimal.NewFromInt(pool.pairs))
+		positions[i].size = positions[i].available
+		if cs.BuySide.MaximumTotal.IsPositive() &&
+			positions[i].size.GreaterThan(cs.BuySide.MaximumTotal) {
+			positions[i].size = cs.BuySide.MaximumTotal
+			positions[i].limitedBy = SizeLimitCurrencyMaximumTotal
+		}
+		// global settings overrule individual currency settings
+		if cfg.PortfolioSettings.BuySide.MaximumTotal.IsPositive() &&
+			positions[i].size.GreaterThan(cfg.PortfolioSettings.BuySide.MaximumTotal) {
+			positions[i].size = cfg.PortfolioSettings.BuySide.MaximumTotal
+			positions[i].limitedBy = SizeLimitPortfolioMaximumTotal
+		}
+		pool.allocated = pool.allocated.Add(positions[i].size)
+	}
+	return positions, pools, nil
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSizePositions(t *testing.T) {
+	t.Parallel()
+	_, _, err := sizePositions(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	funds := decimal.NewFromInt(1000)
+	cfg := &config.Config{
+		CurrencySettings: []config.CurrencySettings{
+			{
+				ExchangeName: "binance",
+				Asset:        asset.Spot,
+				Base:         currency.BTC,
+				Quote:        currency.USDT,
+				SpotDetails:  &config.SpotDetails{InitialQuoteFunds: &funds},
+				BuySide:      config.MinMax{MaximumTotal: decimal.NewFromInt(400)},
+			},
+			{
+				ExchangeName: "binance",
+				Asset:        asset.Spot,
+				Base:         currency.ETH,
+				Quote:        currency.USDT,
+				SpotDetails:  &config.SpotDetails{InitialQuoteFunds: &funds},
+			},
+			{
+				ExchangeName: "binance",
+				Asset:        asset.Spot,
+				Base:         currency.LTC,
+				Quote:        currency.BTC,
+			},
+		},
+		PortfolioSettings: config.PortfolioSettings{
+			BuySide: config.MinMax{MaximumTotal: decimal.NewFromInt(800)},
+		},
+	}
+	positions, pools, err := sizePositions(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(positions) != 3 || len(pools) != 2 {
+		t.Fatalf("received '%v' positions '%v' pools expecting '%v' '%v'", len(positions), len(pools), 3, 2)
+	}
+	for i, tc := range []struct {
+		size      int64
+		limitedBy string
+	}{
+		{size: 400, limitedBy: SizeLimitCurrencyMaximumTotal},
+		{size: 800, limitedBy: SizeLimitPortfolioMaximumTotal},
+		{size: 0, limitedBy: SizeLimitNoFunds},
+	} {
+		if !positions[i].size.Equal(decimal.NewFromInt(tc.size)) || positions[i].limitedBy != tc.limitedBy {
+			t.Errorf("received '%v %v' expecting '%v %v'", positions[i].size, positions[i].limitedBy, tc.size, tc.limitedBy)
+		}
+	}
+
+	cfg.FundingSettings = config.FundingSettings{
+		UseExchangeLevelFunding: true,
+		ExchangeLevelFunding: []config.ExchangeLevelFunding{
+			{ExchangeName: "Binance", Asset: asset.Spot, Currency: currency.USDT, InitialFunds: decimal.NewFromInt(1000)},
+			{ExchangeName: "binance", Asset: asset.Spot, Currency: currency.BTC, InitialFunds: decimal.NewFromInt(3)},
+		},
+	}
+	positions, pools, err = sizePositions(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !positions[0].available.Equal(decimal.NewFromInt(500)) || !positions[0].size.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("received '%v %v' expecting '%v %v'", positions[0].available, positions[0].size, 500, 400)
+	}
+	if !positions[1].size.Equal(decimal.NewFromInt(500)) || positions[1].limitedBy != SizeLimitNone {
+		t.Errorf("received '%v %v' expecting '%v %v'", positions[1].size, positions[1].limitedBy, 500, SizeLimitNone)
+	}
+	if !positions[2].size.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v' expecting '%v'", positions[2].size, 3)
+	}
+	if !pools[0].allocated.Equal(decimal.NewFromInt(900)) || !pools[1].allocated.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v %v' expecting '%v %v'", pools[0].allocated, pools[1].allocated, 900, 3)
+	}
+}
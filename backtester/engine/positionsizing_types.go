@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+const (
+	// SizeLimitNone is a position sized using all of its available funds
+	SizeLimitNone = ""
+	// SizeLimitNoFunds is a position which has no funds available to it
+	SizeLimitNoFunds = "no-funds"
+	// SizeLimitCurrencyMaximumTotal is a position capped by the buy side
+	// maximum total of its currency settings
+	SizeLimitCurrencyMaximumTotal = "currency-maximum-total"
+	// SizeLimitPortfolioMaximumTotal is a position capped by the buy side
+	// maximum total of the portfolio settings
+	SizeLimitPortfolioMaximumTotal = "portfolio-maximum-total"
+)
+
+// fundingPool is an amount of a currency which can be allocated to the
+// positions of one or more pairs
+type fundingPool struct {
+	exchange  string
+	asset     asset.Item
+	currency  currency.Code
+	funds     decimal.Decimal
+	allocated decimal.Decimal
+	pairs     int64
+}
+
+// positionSize is the initial quote denominated position size of a pair
+type positionSize struct {
+	exchange  string
+	asset     asset.Item
+	pair      currency.Pair
+	available decimal.Decimal
+	size      decimal.Decimal
+	limitedBy string
+}
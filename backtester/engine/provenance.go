@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/thrasher-corp/gocryptotrader/core"
+)
+
+// currentBuildInfo returns the version, commit and Go version of the running
+// backtester
+func currentBuildInfo() BuildInfo {
+	version := fmt.Sprintf("v%s.%s", core.MajorVersion, core.MinorVersion)
+	if !core.IsRelease {
+		version += "-pre-release"
+	}
+	return BuildInfo{
+		Version:   version,
+		Commit:    BuildCommit,
+		GoVersion: runtime.Version(),
+	}
+}
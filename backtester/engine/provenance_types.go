@@ -0,0 +1,13 @@
+package engine
+
+// BuildCommit is the git commit the backtester was built from. It is empty
+// unless set at build time e.g.
+// go build -ldflags "-X github.com/thrasher-corp/gocryptotrader/backtester/engine.BuildCommit=$(git rev-parse HEAD)"
+var BuildCommit string
+
+// BuildInfo describes the build of the backtester which produced a run
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	GoVersion string `json:"go-version"`
+}
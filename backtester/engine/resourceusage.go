@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"runtime"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sampleResourceUsage returns the current goroutine count and heap usage
+// along with the heap growth since the run started
+func sampleResourceUsage(run *Run) *btrpc.ResourceUsageSample {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return &btrpc.ResourceUsageSample{
+		RunId:      run.ID.String(),
+		Timestamp:  timestamppb.Now(),
+		Goroutines: int64(runtime.NumGoroutine()),
+		HeapAlloc:  mem.HeapAlloc,
+		HeapDelta:  int64(mem.HeapAlloc) - int64(run.startHeapAlloc),
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	gctmath "github.com/thrasher-corp/gocryptotrader/common/math"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rollingWindowCount returns how many complete windows of the window length,
+// each starting step after the last, fit between the first and last points of
+// the equity curve
+func rollingWindowCount(points []EquityPoint, window, step time.Duration) (int, error) {
+	if window <= 0 || step <= 0 {
+		return 0, errInvalidWindow
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+	span := points[len(points)-1].Time.Sub(points[0].Time)
+	if span < window {
+		return 0, nil
+	}
+	return int((span-window)/step) + 1, nil
+}
+
+// rollingWindows calculates the performance of each complete window of the
+// equity curve. Windows start at the first point and advance by step, every
+// point inclusively within a window's start and end contributes to it
+func rollingWindows(points []EquityPoint, window, step time.Duration) ([]rollingWindow, error) {
+	count, err := rollingWindowCount(points, window, step)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	windows := make([]rollingWindow, count)
+	for i := range windows {
+		start := points[0].Time.Add(step * time.Duration(i))
+		end := start.Add(window)
+		lo := sort.Search(len(points), func(j int) bool {
+			return !points[j].Time.Before(start)
+		})
+		hi := sort.Search(len(points), func(j int) bool {
+			return points[j].Time.After(end)
+		})
+		windows[i] = windowMetrics(points[lo:hi])
+		windows[i].start, windows[i].end = start, end
+	}
+	return windows, nil
+}
+
+// windowMetrics calculates the return, maximum drawdown and unannualised
+// sharpe ratio of the points in a window. The sharpe ratio uses the
+// fractional change between consecutive points with no risk free rate
+func windowMetrics(points []EquityPoint) rollingWindow {
+	w := rollingWindow{
+		points:             len(points),
+		returnPercent:      equityReturnPercent(points),
+		maxDrawdownPercent: maxDrawdownPercent(points),
+	}
+	returns := make([]float64, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		if points[i-1].Equity == 0 {
+			continue
+		}
+		returns = append(returns, (points[i].Equity-points[i-1].Equity)/points[i-1].Equity)
+	}
+	if len(returns) == 0 {
+		return w
+	}
+	mean, err := gctmath.ArithmeticMean(returns)
+	if err != nil {
+		return w
+	}
+	w.sharpeRatio, err = gctmath.SharpeRatio(returns, 0, mean)
+	if err != nil {
+		w.sharpeRatio = 0
+	}
+	return w
+}
+
+// toRPC converts the rolling window to its GRPC representation
+func (w *rollingWindow) toRPC() *btrpc.RollingMetricsWindow {
+	return &btrpc.RollingMetricsWindow{
+		Start:              timestamppb.New(w.start),
+		End:                timestamppb.New(w.end),
+		Points:             uint64(w.points),
+		ReturnPercent:      w.returnPercent,
+		MaxDrawdownPercent: w.maxDrawdownPercent,
+		SharpeRatio:        w.sharpeRatio,
+	}
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRollingWindows(t *testing.T) {
+	t.Parallel()
+	_, err := rollingWindows(nil, 0, time.Hour)
+	if !errors.Is(err, errInvalidWindow) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidWindow)
+	}
+	windows, err := rollingWindows(nil, time.Hour, time.Hour)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if windows != nil {
+		t.Errorf("received '%v' expecting '%v'", windows, nil)
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var points []EquityPoint
+	for i, equity := range []float64{100, 110, 99, 110, 121, 121, 100} {
+		points = append(points, EquityPoint{Time: start.Add(time.Duration(i) * time.Hour), Equity: equity})
+	}
+	windows, err = rollingWindows(points, 2*time.Hour, 2*time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(windows), 3)
+	}
+	if !windows[1].start.Equal(start.Add(2*time.Hour)) || !windows[1].end.Equal(start.Add(4*time.Hour)) || windows[1].points != 3 {
+		t.Errorf("received '%v' expecting a window of 3 points from the second to fourth hour", windows[1])
+	}
+	if math.Abs(windows[0].returnPercent+1) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", windows[0].returnPercent, -1)
+	}
+	if math.Abs(windows[0].maxDrawdownPercent-10) > 1e-9 {
+		t.Errorf("received '%v' expecting '%v'", windows[0].maxDrawdownPercent, 10)
+	}
+	if math.Abs(windows[1].returnPercent-100.0*22/99) > 1e-9 || windows[1].maxDrawdownPercent != 0 {
+		t.Errorf("received '%v' expecting a drawdown free window", windows[1])
+	}
+	if windows[1].sharpeRatio <= 0 || windows[2].sharpeRatio >= 0 {
+		t.Errorf("received '%v' '%v' expecting a positive then negative sharpe ratio", windows[1].sharpeRatio, windows[2].sharpeRatio)
+	}
+
+	windows, err = rollingWindows(points, 2*time.Hour, time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(windows) != 5 {
+		t.Errorf("received '%v' expecting '%v'", len(windows), 5)
+	}
+	windows, err = rollingWindows(points, 7*time.Hour, time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(windows) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(windows), 0)
+	}
+}
@@ -0,0 +1,19 @@
+package engine
+
+import (
+	"errors"
+	"time"
+)
+
+var errInvalidWindow = errors.New("window and step must be greater than zero")
+
+// rollingWindow holds the performance of a run's equity curve between the
+// start and end of a window
+type rollingWindow struct {
+	start              time.Time
+	end                time.Time
+	points             int
+	returnPercent      float64
+	maxDrawdownPercent float64
+	sharpeRatio        float64
+}
@@ -0,0 +1,1308 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HashConfig returns a hex encoded SHA256 hash of the strategy config
+// contents, allowing identical configs to be recognised
+func HashConfig(cfg *config.Config) (string, error) {
+	if cfg == nil {
+		return "", fmt.Errorf("%w config", common.ErrNilArguments)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	hash, err := crypto.GetSHA256(data)
+	if err != nil {
+		return "", err
+	}
+	return crypto.HexEncodeToString(hash), nil
+}
+
+// StartRun registers a new run using the config hash, strategy and labels of
+// the supplied run. When rejectDuplicate is set and a run with the same config
+// hash is still running, the active run is returned alongside an error
+func (r *RunManager) StartRun(run *Run, rejectDuplicate bool) (*Run, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("%w run", common.ErrNilArguments)
+	}
+	routine := goroutineID()
+	var dropped []RunEvent
+	defer func() { logDroppedEvents(dropped) }()
+	r.m.Lock()
+	defer r.m.Unlock()
+	if rejectDuplicate {
+		for i := range r.runs {
+			if r.runs[i].Status == RunStatusRunning &&
+				r.runs[i].ConfigHash == run.ConfigHash {
+				return r.runs[i].clone(), fmt.Errorf("%w as run %v", errRunAlreadyActive, r.runs[i].ID)
+			}
+		}
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	newRun := run.clone()
+	newRun.ID = id
+	newRun.Status = RunStatusRunning
+	newRun.Error = ""
+	newRun.StartTime = time.Now()
+	newRun.EndTime = time.Time{}
+	newRun.done = make(chan struct{})
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	newRun.startHeapAlloc = mem.HeapAlloc
+	newRun.logRoutine = routine
+	r.runs = append(r.runs, newRun)
+	if r.logRoutines == nil {
+		r.logRoutines = make(map[uint64]*Run)
+	}
+	r.logRoutines[routine] = newRun
+	dropped = r.publish(RunEvent{
+		Event:    RunEventStarted,
+		RunID:    newRun.ID,
+		Strategy: newRun.Strategy,
+		Time:     newRun.StartTime,
+	})
+	return newRun.clone(), nil
+}
+
+// FinishRun marks a run as completed, or failed if an error is supplied
+func (r *RunManager) FinishRun(id uuid.UUID, runErr error) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	var dropped []RunEvent
+	defer func() { logDroppedEvents(dropped) }()
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].Status == RunStatusRunning && r.runs[i].done != nil {
+			close(r.runs[i].done)
+		}
+		if r.logRoutines[r.runs[i].logRoutine] == r.runs[i] {
+			delete(r.logRoutines, r.runs[i].logRoutine)
+		}
+		r.runs[i].EndTime = time.Now()
+		r.runs[i].Paused = false
+		switch {
+		case errors.Is(runErr, errRunCancelled):
+			r.runs[i].Status = RunStatusCancelled
+		case errors.Is(runErr, errRunAborted):
+			r.runs[i].Status = RunStatusAborted
+			r.runs[i].Error = runErr.Error()
+		case runErr != nil:
+			r.runs[i].Status = RunStatusFailed
+			r.runs[i].Error = runErr.Error()
+		default:
+			r.runs[i].Status = RunStatusCompleted
+		}
+		r.evictLogs()
+		r.evictCandles()
+		dropped = r.publish(RunEvent{
+			Event:    r.runs[i].Status,
+			RunID:    r.runs[i].ID,
+			Strategy: r.runs[i].Strategy,
+			Time:     r.runs[i].EndTime,
+		})
+		r.evictRuns()
+		return nil
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// UpdateProgress sets the amount of processed and total data events for a run
+func (r *RunManager) UpdateProgress(id uuid.UUID, processed, total int64) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID == id {
+			r.runs[i].EventsProcessed = processed
+			r.runs[i].EventsTotal = total
+			r.notifyProgress(id)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// RecordTrade appends a filled order to the trades of a run, calculating the
+// profit realised when it reduces the open position of its pair. A side of
+// order.ClosePosition closes whatever position is open
+func (r *RunManager) RecordTrade(id uuid.UUID, trade *TradeRecord) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	if trade == nil {
+		return fmt.Errorf("%w trade", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		run := r.runs[i]
+		if run.positions == nil {
+			run.positions = make(map[string]*tradePosition)
+		}
+		key := trade.Exchange + " " + trade.Asset + " " + trade.Pair
+		pos, ok := run.positions[key]
+		if !ok {
+			pos = &tradePosition{}
+			run.positions[key] = pos
+		}
+		record := *trade
+		record.RealisedPNL = pos.apply(record.Side, record.Price, record.Amount)
+		run.Trades = append(run.Trades, record)
+		return nil
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// RecordCandle appends a candle consumed by a run. Only the latest
+// maxRunCandles candles are kept for each run
+func (r *RunManager) RecordCandle(id uuid.UUID, candle *candleRecord) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	if candle == nil {
+		return fmt.Errorf("%w candle", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	// candles are recorded by running runs, which are the most recent
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].candlesEvicted {
+			return fmt.Errorf("%w for run %v", errCandlesEvicted, id)
+		}
+		if len(r.runs[i].candles) >= maxRunCandles {
+			r.runs[i].candles = r.runs[i].candles[1:]
+		}
+		r.runs[i].candles = append(r.runs[i].candles, *candle)
+		return nil
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// GetCandles returns the candles a run consumed for an exchange, asset and
+// pair ordered by time. Zero start or end times leave that side of the range
+// unbounded
+func (r *RunManager) GetCandles(id uuid.UUID, exchange string, a asset.Item, pair currency.Pair, start, end time.Time) ([]candleRecord, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].candlesEvicted {
+			return nil, fmt.Errorf("%w for run %v", errCandlesEvicted, id)
+		}
+		var candles []candleRecord
+		for j := range r.runs[i].candles {
+			c := &r.runs[i].candles[j]
+			if !strings.EqualFold(c.exchange, exchange) || c.asset != a || !c.pair.Equal(pair) {
+				continue
+			}
+			if (!start.IsZero() && c.time.Before(start)) || (!end.IsZero() && c.time.After(end)) {
+				continue
+			}
+			candles = append(candles, *c)
+		}
+		sort.SliceStable(candles, func(x, y int) bool {
+			return candles[x].time.Before(candles[y].time)
+		})
+		return candles, nil
+	}
+	return nil, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// toRPC converts the candle to its GRPC representation
+func (c *candleRecord) toRPC() *btrpc.RunCandle {
+	return &btrpc.RunCandle{
+		Time:   timestamppb.New(c.time),
+		Open:   c.open,
+		High:   c.high,
+		Low:    c.low,
+		Close:  c.close,
+		Volume: c.volume,
+	}
+}
+
+// GetTrades returns up to limit trades of a run starting from offset, along
+// with the total amount of trades. A limit of zero returns all remaining trades
+func (r *RunManager) GetTrades(id uuid.UUID, offset, limit int) ([]TradeRecord, int, error) {
+	if r == nil {
+		return nil, 0, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		total := len(r.runs[i].Trades)
+		if offset >= total {
+			return nil, total, nil
+		}
+		end := total
+		if limit > 0 && offset+limit < total {
+			end = offset + limit
+		}
+		trades := make([]TradeRecord, end-offset)
+		copy(trades, r.runs[i].Trades[offset:end])
+		return trades, total, nil
+	}
+	return nil, 0, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// apply updates the position with a trade and returns the profit realised
+// by any part of the trade which reduces the position
+func (p *tradePosition) apply(side string, price, amount float64) float64 {
+	var signed float64
+	switch side {
+	case gctorder.Buy.String(), gctorder.Bid.String(), gctorder.Long.String():
+		signed = amount
+	case gctorder.Sell.String(), gctorder.Ask.String(), gctorder.Short.String():
+		signed = -amount
+	case gctorder.ClosePosition.String():
+		if p.amount > 0 {
+			signed = -math.Min(amount, p.amount)
+		} else {
+			signed = math.Min(amount, -p.amount)
+		}
+	default:
+		return 0
+	}
+	if p.amount == 0 || (p.amount > 0) == (signed > 0) {
+		total := math.Abs(p.amount) + math.Abs(signed)
+		if total > 0 {
+			p.entryPrice = (math.Abs(p.amount)*p.entryPrice + math.Abs(signed)*price) / total
+		}
+		p.amount += signed
+		return 0
+	}
+	closing := math.Min(math.Abs(signed), math.Abs(p.amount))
+	realised := closing * (price - p.entryPrice)
+	if p.amount < 0 {
+		realised = -realised
+	}
+	remaining := p.amount + signed
+	switch {
+	case remaining == 0:
+		p.entryPrice = 0
+	case (remaining > 0) != (p.amount > 0):
+		// the position has flipped direction, the excess opens at this price
+		p.entryPrice = price
+	}
+	p.amount = remaining
+	return realised
+}
+
+// UpdateSettings sends custom strategy settings to a running run and waits for
+// the result of them being applied
+func (r *RunManager) UpdateSettings(ctx context.Context, id uuid.UUID, settings map[string]interface{}) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	run, err := r.GetRun(id)
+	if err != nil {
+		return err
+	}
+	if run.Status != RunStatusRunning {
+		return fmt.Errorf("%w %v", errRunNotRunning, id)
+	}
+	if run.settingsUpdates == nil {
+		return fmt.Errorf("run %v %w", id, errLiveUpdatesUnsupported)
+	}
+	u := &settingsUpdate{
+		settings: settings,
+		result:   make(chan error, 1),
+	}
+	select {
+	case run.settingsUpdates <- u:
+	case <-run.done:
+		return fmt.Errorf("%w %v", errRunNotRunning, id)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err = <-u.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RecordEquity updates the holdings value of an exchange, asset and pair for
+// a run and appends the summed equity to its equity curve. Values sharing the
+// timestamp of the latest point update that point
+func (r *RunManager) RecordEquity(id uuid.UUID, key string, t time.Time, value float64) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		run := r.runs[i]
+		if run.latestEquity == nil {
+			run.latestEquity = make(map[string]float64)
+		}
+		run.latestEquity[key] = value
+		var equity float64
+		for _, v := range run.latestEquity {
+			equity += v
+		}
+		last := len(run.EquityCurve) - 1
+		if last >= 0 && !t.After(run.EquityCurve[last].Time) {
+			// keep timestamps monotonic
+			run.EquityCurve[last].Equity = equity
+			return nil
+		}
+		run.EquityCurve = append(run.EquityCurve, EquityPoint{Time: t, Equity: equity})
+		return nil
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// GetRun returns a copy of the run matching the ID
+func (r *RunManager) GetRun(id uuid.UUID) (*Run, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID == id {
+			return r.runs[i].clone(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// ListRuns returns copies of all runs which contain every supplied label
+func (r *RunManager) ListRuns(labels map[string]string) ([]*Run, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	runs := make([]*Run, 0, len(r.runs))
+	for i := range r.runs {
+		if !r.runs[i].hasLabels(labels) {
+			continue
+		}
+		runs = append(runs, r.runs[i].clone())
+	}
+	return runs, nil
+}
+
+// RecentCompletedRuns returns copies of the most recently completed runs,
+// newest first, up to the supplied limit
+func (r *RunManager) RecentCompletedRuns(limit int) ([]*Run, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	var runs []*Run
+	for i := range r.runs {
+		if r.runs[i].Status == RunStatusCompleted {
+			runs = append(runs, r.runs[i].clone())
+		}
+	}
+	r.m.Unlock()
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].EndTime.After(runs[j].EndTime)
+	})
+	if limit >= 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// SubscribeRunEvents returns a channel which receives an event whenever a run
+// starts or finishes, along with copies of the runs active at the time of
+// subscribing. The channel must be released via UnsubscribeRunEvents
+func (r *RunManager) SubscribeRunEvents() (chan RunEvent, []*Run, error) {
+	if r == nil {
+		return nil, nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[chan RunEvent]struct{})
+	}
+	ch := make(chan RunEvent, runEventBufferSize)
+	r.subscribers[ch] = struct{}{}
+	var active []*Run
+	for i := range r.runs {
+		if r.runs[i].Status == RunStatusRunning {
+			active = append(active, r.runs[i].clone())
+		}
+	}
+	return ch, active, nil
+}
+
+// UnsubscribeRunEvents stops and closes a channel returned by
+// SubscribeRunEvents
+func (r *RunManager) UnsubscribeRunEvents(ch chan RunEvent) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if _, ok := r.subscribers[ch]; !ok {
+		return errSubscriberNotFound
+	}
+	delete(r.subscribers, ch)
+	close(ch)
+	return nil
+}
+
+// WatchProgress returns a channel which is signalled whenever the progress of
+// the run changes. Signals are coalesced, so a slow reader receives a single
+// signal for any number of updates. The channel must be released via
+// UnwatchProgress
+func (r *RunManager) WatchProgress(id uuid.UUID) (chan struct{}, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.progressWatchers == nil {
+			r.progressWatchers = make(map[uuid.UUID]map[chan struct{}]struct{})
+		}
+		if r.progressWatchers[id] == nil {
+			r.progressWatchers[id] = make(map[chan struct{}]struct{})
+		}
+		ch := make(chan struct{}, 1)
+		r.progressWatchers[id][ch] = struct{}{}
+		return ch, nil
+	}
+	return nil, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// UnwatchProgress stops a channel returned by WatchProgress from receiving
+// progress signals
+func (r *RunManager) UnwatchProgress(id uuid.UUID, ch chan struct{}) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if _, ok := r.progressWatchers[id][ch]; !ok {
+		return errWatcherNotFound
+	}
+	delete(r.progressWatchers[id], ch)
+	if len(r.progressWatchers[id]) == 0 {
+		delete(r.progressWatchers, id)
+	}
+	return nil
+}
+
+// PauseRun blocks a running run from processing further events until it is
+// resumed via ResumeRun
+func (r *RunManager) PauseRun(id uuid.UUID) error {
+	return r.setPaused(id, true)
+}
+
+// ResumeRun releases a run paused via PauseRun
+func (r *RunManager) ResumeRun(id uuid.UUID) error {
+	return r.setPaused(id, false)
+}
+
+// setPaused pauses or resumes a running run, notifying its progress watchers
+// of the change
+func (r *RunManager) setPaused(id uuid.UUID, paused bool) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		switch {
+		case r.runs[i].Status != RunStatusRunning:
+			return fmt.Errorf("%w %v", errRunNotRunning, id)
+		case r.runs[i].gate == nil:
+			return fmt.Errorf("%w %v", errRunNotPausable, id)
+		case paused && r.runs[i].Paused:
+			return fmt.Errorf("%w %v", errRunAlreadyPaused, id)
+		case !paused && !r.runs[i].Paused:
+			return fmt.Errorf("%w %v", errRunNotPaused, id)
+		}
+		r.runs[i].Paused = paused
+		r.runs[i].gate.setPaused(paused)
+		r.notifyProgress(id)
+		return nil
+	}
+	return fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// SetBaseline marks a completed run as the baseline which comparisons default
+// to, returning the ID of the previous baseline
+func (r *RunManager) SetBaseline(id uuid.UUID) (previous uuid.UUID, err error) {
+	if r == nil {
+		return uuid.Nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].Status != RunStatusCompleted {
+			return uuid.Nil, fmt.Errorf("%w %v", errRunNotCompleted, id)
+		}
+		previous, r.baseline = r.baseline, id
+		return previous, nil
+	}
+	return uuid.Nil, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// Baseline returns the ID of the baseline run
+func (r *RunManager) Baseline() (uuid.UUID, error) {
+	if r == nil {
+		return uuid.Nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.baseline.IsNil() {
+		return uuid.Nil, errNoBaseline
+	}
+	return r.baseline, nil
+}
+
+// notifyProgress signals the progress watchers of a run. Must be called with
+// the lock held
+func (r *RunManager) notifyProgress(id uuid.UUID) {
+	for ch := range r.progressWatchers[id] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// a notification is already pending
+		}
+	}
+}
+
+// newRunGate returns a gate which does not block until paused
+func newRunGate() *runGate {
+	g := &runGate{}
+	g.cond = sync.NewCond(&g.m)
+	return g
+}
+
+// setPaused blocks or releases callers of wait
+func (g *runGate) setPaused(paused bool) {
+	g.m.Lock()
+	g.paused = paused
+	g.m.Unlock()
+	if !paused {
+		g.cond.Broadcast()
+	}
+}
+
+// wait blocks while the gate is paused
+func (g *runGate) wait() {
+	g.m.Lock()
+	for g.paused {
+		g.cond.Wait()
+	}
+	g.m.Unlock()
+}
+
+// publish sends the event to all subscribers. Subscribers which are not
+// keeping up miss the event rather than blocking the run, the event is
+// returned once for each subscriber it was dropped for. It must be called with
+// the lock held and dropped events must only be logged once it is released, as
+// logs are written back into the run manager
+func (r *RunManager) publish(ev RunEvent) []RunEvent {
+	var dropped []RunEvent
+	for ch := range r.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			dropped = append(dropped, ev)
+		}
+	}
+	return dropped
+}
+
+// logDroppedEvents warns of events which could not be sent to a subscriber,
+// it must be called without the run manager lock held
+func logDroppedEvents(dropped []RunEvent) {
+	for i := range dropped {
+		log.Warnf(common.Backtester, "run event subscriber is full, dropping %v event for run %v", dropped[i].Event, dropped[i].RunID)
+	}
+}
+
+// PercentComplete returns how far through its data events the run is.
+// Completed runs are always 100 percent complete
+func (r *Run) PercentComplete() float64 {
+	if r.Status == RunStatusCompleted {
+		return 100
+	}
+	if r.EventsTotal <= 0 {
+		return 0
+	}
+	return float64(r.EventsProcessed) / float64(r.EventsTotal) * 100
+}
+
+// ReturnPercent returns the percentage change in equity from the first to the
+// last point of the run's equity curve. False is returned when there is not
+// enough equity data to determine a return
+func (r *Run) ReturnPercent() (float64, bool) {
+	if len(r.EquityCurve) < 2 || r.EquityCurve[0].Equity == 0 {
+		return 0, false
+	}
+	first := r.EquityCurve[0].Equity
+	last := r.EquityCurve[len(r.EquityCurve)-1].Equity
+	return (last - first) / first * 100, true
+}
+
+// EstimateTimeRemaining extrapolates the time remaining for a running run from
+// its elapsed time and percentage complete. False is returned when no estimate
+// can be made, such as when no progress has been made
+func (r *Run) EstimateTimeRemaining(now time.Time) (time.Duration, bool) {
+	if r.Status != RunStatusRunning {
+		return 0, false
+	}
+	pct := r.PercentComplete()
+	if pct <= 0 {
+		return 0, false
+	}
+	elapsed := now.Sub(r.StartTime)
+	if elapsed < 0 {
+		return 0, false
+	}
+	return time.Duration(float64(elapsed) * (100 - pct) / pct), true
+}
+
+// Export returns the run encoded in the supplied format
+func (r *Run) Export(format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case ExportFormatJSON:
+		return json.Marshal(r)
+	case ExportFormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		err := w.Write([]string{"time", "equity"})
+		if err != nil {
+			return nil, err
+		}
+		for i := range r.EquityCurve {
+			err = w.Write([]string{
+				r.EquityCurve[i].Time.UTC().Format(time.RFC3339),
+				strconv.FormatFloat(r.EquityCurve[i].Equity, 'f', -1, 64),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	default:
+		return nil, fmt.Errorf("%w '%v'", errUnsupportedFormat, format)
+	}
+}
+
+// hasLabels returns whether the run contains all labels with matching values
+func (r *Run) hasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if val, ok := r.Labels[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a copy of the run which is safe to hand out of the manager
+func (r *Run) clone() *Run {
+	run := *r
+	if r.Labels != nil {
+		run.Labels = make(map[string]string, len(r.Labels))
+		for k, v := range r.Labels {
+			run.Labels[k] = v
+		}
+	}
+	if r.EquityCurve != nil {
+		run.EquityCurve = make([]EquityPoint, len(r.EquityCurve))
+		copy(run.EquityCurve, r.EquityCurve)
+	}
+	if r.Trades != nil {
+		run.Trades = make([]TradeRecord, len(r.Trades))
+		copy(run.Trades, r.Trades)
+	}
+	run.latestEquity = nil
+	run.positions = nil
+	run.candles = nil
+	run.logs = nil
+	return &run
+}
+
+// downsampleEquity returns at most maxPoints evenly spaced points from the
+// equity curve, always retaining the first and last points. A maxPoints of
+// zero or less returns the full curve
+func downsampleEquity(points []EquityPoint, maxPoints int) []EquityPoint {
+	if maxPoints <= 0 || len(points) <= maxPoints {
+		return points
+	}
+	if maxPoints == 1 {
+		return points[len(points)-1:]
+	}
+	resp := make([]EquityPoint, maxPoints)
+	for i := range resp {
+		resp[i] = points[i*(len(points)-1)/(maxPoints-1)]
+	}
+	return resp
+}
+
+// toRPC converts the run event to its GRPC representation
+func (e *RunEvent) toRPC() *btrpc.RunEvent {
+	return &btrpc.RunEvent{
+		Event:        e.Event,
+		RunId:        e.RunID.String(),
+		StrategyName: e.Strategy,
+		Timestamp:    timestamppb.New(e.Time),
+	}
+}
+
+// toRPC converts the equity point to its GRPC representation
+func (e *EquityPoint) toRPC() *btrpc.EquityPoint {
+	return &btrpc.EquityPoint{
+		Timestamp: timestamppb.New(e.Time),
+		Equity:    e.Equity,
+	}
+}
+
+// toRPC converts the trade to its GRPC representation
+func (t *TradeRecord) toRPC() *btrpc.Trade {
+	return &btrpc.Trade{
+		Timestamp:    timestamppb.New(t.Time),
+		ExchangeName: t.Exchange,
+		Asset:        t.Asset,
+		Pair:         t.Pair,
+		Side:         t.Side,
+		Price:        t.Price,
+		Amount:       t.Amount,
+		Fee:          t.Fee,
+		RealisedPnl:  t.RealisedPNL,
+	}
+}
+
+// toRPCSummary converts the run to its GRPC representation
+func (r *Run) toRPCSummary() *btrpc.RunSummary {
+	summary := &btrpc.RunSummary{
+		Id:           r.ID.String(),
+		StrategyName: r.Strategy,
+		Status:       r.Status,
+		Error:        r.Error,
+		StartTime:    timestamppb.New(r.StartTime),
+		Labels:       r.Labels,
+		TradeCount:   uint64(len(r.Trades)),
+		Paused:       r.Paused,
+	}
+	if !r.EndTime.IsZero() {
+		summary.EndTime = timestamppb.New(r.EndTime)
+	}
+	return summary
+}
+
+// CancelRun requests a running run stops and waits for it to finish
+func (r *RunManager) CancelRun(ctx context.Context, id uuid.UUID) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	var done chan struct{}
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].Status != RunStatusRunning {
+			r.m.Unlock()
+			return fmt.Errorf("%w %v", errRunNotRunning, id)
+		}
+		if r.runs[i].cancel == nil {
+			r.m.Unlock()
+			return fmt.Errorf("%w %v", errRunNotCancellable, id)
+		}
+		if !r.runs[i].cancelled {
+			r.runs[i].cancelled = true
+			close(r.runs[i].cancel)
+		}
+		if r.runs[i].Paused {
+			// a paused backtest must be released to observe the cancellation
+			r.runs[i].Paused = false
+			r.runs[i].gate.setPaused(false)
+		}
+		done = r.runs[i].done
+		break
+	}
+	r.m.Unlock()
+	if done == nil {
+		return fmt.Errorf("%w %v", errRunNotFound, id)
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ExportRegistry serialises all finished runs and their strategy configs so
+// they can be imported into another run manager. Running runs are excluded as
+// they cannot be resumed
+func (r *RunManager) ExportRegistry() ([]byte, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	snapshot := registrySnapshot{
+		Version: registryVersion,
+		Runs:    []registryRun{},
+	}
+	r.m.Lock()
+	for i := range r.runs {
+		if r.runs[i].Status == RunStatusRunning {
+			continue
+		}
+		snapshot.Runs = append(snapshot.Runs, registryRun{
+			Run:    r.runs[i].clone(),
+			Config: r.runs[i].config,
+		})
+	}
+	r.m.Unlock()
+	return json.Marshal(snapshot)
+}
+
+// ImportRegistry adds the finished runs from an exported registry, along with
+// their strategy configs. Runs which already exist are skipped. Imported runs
+// have no buffered logs
+func (r *RunManager) ImportRegistry(data []byte) (imported, skipped int, err error) {
+	if r == nil {
+		return 0, 0, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	var snapshot registrySnapshot
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w %v", errInvalidRegistry, err)
+	}
+	if snapshot.Version != registryVersion {
+		return 0, 0, fmt.Errorf("%w %v, expected %v", errRegistryVersion, snapshot.Version, registryVersion)
+	}
+	for i := range snapshot.Runs {
+		switch {
+		case snapshot.Runs[i].Run == nil:
+			return 0, 0, fmt.Errorf("%w nil run", errInvalidRegistry)
+		case snapshot.Runs[i].ID.IsNil():
+			return 0, 0, fmt.Errorf("%w run without id", errInvalidRegistry)
+		case snapshot.Runs[i].Status != RunStatusCompleted &&
+			snapshot.Runs[i].Status != RunStatusFailed &&
+			snapshot.Runs[i].Status != RunStatusCancelled &&
+			snapshot.Runs[i].Status != RunStatusAborted:
+			return 0, 0, fmt.Errorf("%w run %v has status '%v'", errInvalidRegistry, snapshot.Runs[i].ID, snapshot.Runs[i].Status)
+		}
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	existing := make(map[uuid.UUID]bool, len(r.runs))
+	for i := range r.runs {
+		existing[r.runs[i].ID] = true
+	}
+	for i := range snapshot.Runs {
+		if existing[snapshot.Runs[i].ID] {
+			skipped++
+			continue
+		}
+		existing[snapshot.Runs[i].ID] = true
+		run := snapshot.Runs[i].clone()
+		run.config = snapshot.Runs[i].Config
+		run.logsEvicted = true
+		run.candlesEvicted = true
+		r.runs = append(r.runs, run)
+		imported++
+	}
+	r.evictRuns()
+	return imported, skipped, nil
+}
+
+// AppendLog adds a log record to the run being executed by the calling
+// goroutine. Records logged outside of a running run are discarded
+func (r *RunManager) AppendLog(record LogRecord) {
+	r.appendLog(goroutineID(), record)
+}
+
+// appendLog adds a log record to the run being executed by the goroutine
+func (r *RunManager) appendLog(routine uint64, record LogRecord) {
+	if r == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	run := r.logRoutines[routine]
+	if run == nil {
+		return
+	}
+	if len(run.logs) >= maxRunLogLines {
+		run.logs = run.logs[1:]
+	}
+	run.logs = append(run.logs, record)
+}
+
+// GetLogs returns the buffered logs for a run, optionally filtered by level.
+// When limit is above zero, only the latest limit records are returned
+func (r *RunManager) GetLogs(id uuid.UUID, level string, limit int) ([]LogRecord, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.runs {
+		if r.runs[i].ID != id {
+			continue
+		}
+		if r.runs[i].logsEvicted {
+			return nil, fmt.Errorf("%w for run %v", errLogsEvicted, id)
+		}
+		var logs []LogRecord
+		for j := range r.runs[i].logs {
+			if level != "" && !strings.EqualFold(r.runs[i].logs[j].Level, level) {
+				continue
+			}
+			logs = append(logs, r.runs[i].logs[j])
+		}
+		if limit > 0 && len(logs) > limit {
+			logs = logs[len(logs)-limit:]
+		}
+		return logs, nil
+	}
+	return nil, fmt.Errorf("%w %v", errRunNotFound, id)
+}
+
+// SetLogRetention sets the number of finished runs which keep their buffered
+// logs, evicting logs immediately when lowered
+func (r *RunManager) SetLogRetention(retention int) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.logRetention = retention
+	r.evictLogs()
+	return nil
+}
+
+// LogRetention returns the number of finished runs which keep their buffered
+// logs
+func (r *RunManager) LogRetention() int {
+	if r == nil {
+		return 0
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.getLogRetention()
+}
+
+// getLogRetention returns the log retention, it must be called with the lock
+// held
+func (r *RunManager) getLogRetention() int {
+	if r.logRetention > 0 {
+		return r.logRetention
+	}
+	return defaultBufferedRunLogs
+}
+
+// evictLogs drops the buffered logs of finished runs beyond the latest log
+// retention, it must be called with the lock held
+func (r *RunManager) evictLogs() {
+	retention := r.getLogRetention()
+	var finished int
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].Status == RunStatusRunning || r.runs[i].logsEvicted {
+			continue
+		}
+		finished++
+		if finished > retention {
+			r.runs[i].logs = nil
+			r.runs[i].logsEvicted = true
+		}
+	}
+}
+
+// SetCandleRetention sets the number of finished runs which keep their
+// buffered candles, evicting candles immediately when lowered
+func (r *RunManager) SetCandleRetention(retention int) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.candleRetention = retention
+	r.evictCandles()
+	return nil
+}
+
+// CandleRetention returns the number of finished runs which keep their
+// buffered candles
+func (r *RunManager) CandleRetention() int {
+	if r == nil {
+		return 0
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.getCandleRetention()
+}
+
+// getCandleRetention returns the candle retention, it must be called with the
+// lock held
+func (r *RunManager) getCandleRetention() int {
+	if r.candleRetention > 0 {
+		return r.candleRetention
+	}
+	return defaultBufferedRunCandles
+}
+
+// evictCandles drops the buffered candles of finished runs beyond the latest
+// candle retention, it must be called with the lock held
+func (r *RunManager) evictCandles() {
+	retention := r.getCandleRetention()
+	var finished int
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].Status == RunStatusRunning || r.runs[i].candlesEvicted {
+			continue
+		}
+		finished++
+		if finished > retention {
+			r.runs[i].candles = nil
+			r.runs[i].candlesEvicted = true
+		}
+	}
+}
+
+// SetRunRetention sets the number of finished runs which are kept, removing
+// older finished runs immediately when lowered
+func (r *RunManager) SetRunRetention(retention int) error {
+	if r == nil {
+		return fmt.Errorf("%w run manager", common.ErrNilArguments)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.runRetention = retention
+	r.evictRuns()
+	return nil
+}
+
+// RunRetention returns the number of finished runs which are kept
+func (r *RunManager) RunRetention() int {
+	if r == nil {
+		return 0
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.getRunRetention()
+}
+
+// getRunRetention returns the run retention, it must be called with the lock
+// held
+func (r *RunManager) getRunRetention() int {
+	if r.runRetention > 0 {
+		return r.runRetention
+	}
+	return defaultRetainedRuns
+}
+
+// evictRuns removes finished runs beyond the latest run retention, along with
+// their equity curves and trades. The baseline run is always kept. It must be
+// called with the lock held
+func (r *RunManager) evictRuns() {
+	retention := r.getRunRetention()
+	var finished int
+	evict := make(map[uuid.UUID]bool)
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].Status == RunStatusRunning || r.runs[i].ID == r.baseline {
+			continue
+		}
+		finished++
+		if finished > retention {
+			evict[r.runs[i].ID] = true
+		}
+	}
+	if len(evict) == 0 {
+		return
+	}
+	kept := r.runs[:0]
+	for i := range r.runs {
+		if !evict[r.runs[i].ID] {
+			kept = append(kept, r.runs[i])
+		}
+	}
+	for i := len(kept); i < len(r.runs); i++ {
+		r.runs[i] = nil
+	}
+	r.runs = kept
+}
+
+// register adds the run manager to the managers receiving captured logs,
+// teeing the writer into the backtester sub loggers on first use
+func (w *runLogWriter) register(r *RunManager) {
+	w.install.Do(w.tee)
+	w.m.Lock()
+	defer w.m.Unlock()
+	for i := range w.managers {
+		if w.managers[i] == r {
+			return
+		}
+	}
+	w.managers = append(w.managers, r)
+}
+
+// tee adds the writer to the output of the backtester sub loggers
+func (w *runLogWriter) tee() {
+	for _, sl := range []*log.SubLogger{
+		common.Backtester,
+		common.Setup,
+		common.Strategy,
+		common.Config,
+		common.Portfolio,
+		common.Exchange,
+		common.Fill,
+		common.Report,
+		common.Statistics,
+		common.CurrencyStatistics,
+		common.FundingStatistics,
+		common.Holdings,
+		common.Data,
+	} {
+		if sl == nil {
+			continue
+		}
+		if output := sl.GetOutput(); output != nil {
+			sl.SetOutput(io.MultiWriter(output, w))
+		} else {
+			sl.SetOutput(w)
+		}
+	}
+}
+
+// Write splits log output into lines and appends them to the run executing on
+// the calling goroutine
+func (w *runLogWriter) Write(p []byte) (int, error) {
+	now := time.Now()
+	routine := goroutineID()
+	w.m.RLock()
+	defer w.m.RUnlock()
+	for _, line := range strings.Split(string(p), "\n") {
+		if line == "" {
+			continue
+		}
+		record := LogRecord{
+			Time:    now,
+			Level:   parseLogLevel(line),
+			Message: line,
+		}
+		for i := range w.managers {
+			w.managers[i].appendLog(routine, record)
+		}
+	}
+	return len(p), nil
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from the
+// "goroutine <id> [" header of its stack trace
+func goroutineID() uint64 {
+	var buf [64]byte
+	fields := bytes.Fields(buf[:runtime.Stack(buf[:], false)])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// parseLogLevel determines the level of a log line from its header
+func parseLogLevel(line string) string {
+	log.RWM.RLock()
+	cfg := log.GlobalLogConfig
+	log.RWM.RUnlock()
+	if cfg == nil {
+		return LogLevelInfo
+	}
+	h := cfg.AdvancedSettings.Headers
+	for _, header := range []struct {
+		prefix, level string
+	}{
+		{h.Info, LogLevelInfo},
+		{h.Warn, LogLevelWarn},
+		{h.Debug, LogLevelDebug},
+		{h.Error, LogLevelError},
+	} {
+		if header.prefix != "" && strings.HasPrefix(line, header.prefix) {
+			return header.level
+		}
+	}
+	return LogLevelInfo
+}
+
+// toRPC converts a log record to its RPC representation
+func (l *LogRecord) toRPC() *btrpc.LogRecord {
+	return &btrpc.LogRecord{
+		Timestamp: timestamppb.New(l.Time),
+		Level:     l.Level,
+		Message:   l.Message,
+	}
+}
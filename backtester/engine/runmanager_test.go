@@ -0,0 +1,1481 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func TestHashConfig(t *testing.T) {
+	t.Parallel()
+	_, err := HashConfig(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	cfg, err := config.ReadStrategyConfigFromFile(dcaConfigPath)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	hash, err := HashConfig(cfg)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	hash2, err := HashConfig(cfg)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if hash != hash2 {
+		t.Errorf("received '%v' expecting '%v'", hash2, hash)
+	}
+	cfg.Nickname = "changed"
+	hash2, err = HashConfig(cfg)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if hash == hash2 {
+		t.Error("expected different hashes for different configs")
+	}
+}
+
+func TestRunManagerStartRun(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	_, err = r.StartRun(nil, false)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, true)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if run.Status != RunStatusRunning {
+		t.Errorf("received '%v' expecting '%v'", run.Status, RunStatusRunning)
+	}
+
+	_, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+
+	active, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, true)
+	if !errors.Is(err, errRunAlreadyActive) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunAlreadyActive)
+	}
+	if active.ID != run.ID {
+		t.Errorf("received '%v' expecting '%v'", active.ID, run.ID)
+	}
+}
+
+func TestRunManagerFinishRun(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.FinishRun(uuid.Nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.FinishRun(uuid.Nil, nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, true)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.FinishRun(run.ID, errRunNotFound)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	run, err = r.GetRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if run.Status != RunStatusFailed {
+		t.Errorf("received '%v' expecting '%v'", run.Status, RunStatusFailed)
+	}
+
+	_, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, true)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+}
+
+func TestRunManagerGetRun(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.GetRun(uuid.Nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	_, err = r.GetRun(uuid.Nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	resp, err := r.GetRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Strategy != "strat" {
+		t.Errorf("received '%v' expecting '%v'", resp.Strategy, "strat")
+	}
+}
+
+func TestRunManagerListRuns(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.ListRuns(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	labels := map[string]string{"experiment": "1337"}
+	labelled, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat", Labels: labels}, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+
+	runs, err := r.ListRuns(nil)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(runs), 2)
+	}
+
+	runs, err = r.ListRuns(labels)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(runs), 1)
+	}
+	if runs[0].ID != labelled.ID {
+		t.Errorf("received '%v' expecting '%v'", runs[0].ID, labelled.ID)
+	}
+
+	runs, err = r.ListRuns(map[string]string{"experiment": "420"})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(runs), 0)
+	}
+
+	// ensure returned runs cannot modify the stored run
+	labels["experiment"] = "420"
+	runs, err = r.ListRuns(map[string]string{"experiment": "1337"})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 1 {
+		t.Errorf("received '%v' expecting '%v'", len(runs), 1)
+	}
+}
+
+func TestRunManagerUpdateProgress(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.UpdateProgress(uuid.Nil, 1, 2)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.UpdateProgress(uuid.Nil, 1, 2)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.UpdateProgress(run.ID, 1, 2)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	run, err = r.GetRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if run.EventsProcessed != 1 || run.EventsTotal != 2 {
+		t.Errorf("received '%v/%v' expecting '%v/%v'", run.EventsProcessed, run.EventsTotal, 1, 2)
+	}
+}
+
+func TestRunPercentComplete(t *testing.T) {
+	t.Parallel()
+	r := &Run{Status: RunStatusRunning}
+	if pct := r.PercentComplete(); pct != 0 {
+		t.Errorf("received '%v' expecting '%v'", pct, 0)
+	}
+	r.EventsProcessed = 1
+	r.EventsTotal = 4
+	if pct := r.PercentComplete(); pct != 25 {
+		t.Errorf("received '%v' expecting '%v'", pct, 25)
+	}
+	r.Status = RunStatusCompleted
+	if pct := r.PercentComplete(); pct != 100 {
+		t.Errorf("received '%v' expecting '%v'", pct, 100)
+	}
+}
+
+func TestRunEstimateTimeRemaining(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	r := &Run{
+		Status:      RunStatusRunning,
+		StartTime:   now.Add(-time.Minute),
+		EventsTotal: 100,
+	}
+	if _, ok := r.EstimateTimeRemaining(now); ok {
+		t.Error("expected no estimate at zero percent")
+	}
+
+	r.EventsProcessed = 50
+	eta, ok := r.EstimateTimeRemaining(now)
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if eta != time.Minute {
+		t.Errorf("received '%v' expecting '%v'", eta, time.Minute)
+	}
+
+	r.Status = RunStatusCompleted
+	if _, ok = r.EstimateTimeRemaining(now); ok {
+		t.Error("expected no estimate for a completed run")
+	}
+}
+
+func TestRunManagerRecordEquity(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	tt := time.Now()
+	err := r.RecordEquity(uuid.Nil, "key", tt, 1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.RecordEquity(uuid.Nil, "key", tt, 1)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.RecordEquity(run.ID, "key", tt, 1)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.RecordEquity(run.ID, "key2", tt, 2)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.RecordEquity(run.ID, "key", tt.Add(time.Minute), 3)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	// earlier timestamps update the latest point rather than going backwards
+	err = r.RecordEquity(run.ID, "key2", tt, 4)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+
+	run, err = r.GetRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(run.EquityCurve) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(run.EquityCurve), 2)
+	}
+	if run.EquityCurve[0].Equity != 3 {
+		t.Errorf("received '%v' expecting '%v'", run.EquityCurve[0].Equity, 3)
+	}
+	if run.EquityCurve[1].Equity != 7 {
+		t.Errorf("received '%v' expecting '%v'", run.EquityCurve[1].Equity, 7)
+	}
+	if !run.EquityCurve[1].Time.Equal(tt.Add(time.Minute)) {
+		t.Errorf("received '%v' expecting '%v'", run.EquityCurve[1].Time, tt.Add(time.Minute))
+	}
+}
+
+func TestDownsampleEquity(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	points := make([]EquityPoint, 101)
+	for i := range points {
+		points[i] = EquityPoint{Time: tt.Add(time.Duration(i) * time.Minute), Equity: float64(i)}
+	}
+	if resp := downsampleEquity(points, 0); len(resp) != 101 {
+		t.Errorf("received '%v' expecting '%v'", len(resp), 101)
+	}
+	if resp := downsampleEquity(points, 1000); len(resp) != 101 {
+		t.Errorf("received '%v' expecting '%v'", len(resp), 101)
+	}
+	resp := downsampleEquity(points, 1)
+	if len(resp) != 1 || resp[0].Equity != 100 {
+		t.Errorf("received '%v' expecting '%v'", resp, points[100:])
+	}
+	resp = downsampleEquity(points, 11)
+	if len(resp) != 11 {
+		t.Fatalf("received '%v' expecting '%v'", len(resp), 11)
+	}
+	for i := range resp {
+		if resp[i].Equity != float64(i*10) {
+			t.Errorf("received '%v' expecting '%v'", resp[i].Equity, i*10)
+		}
+	}
+}
+
+func TestRunManagerSubscribeRunEvents(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, _, err := r.SubscribeRunEvents()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = r.UnsubscribeRunEvents(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.UnsubscribeRunEvents(make(chan RunEvent))
+	if !errors.Is(err, errSubscriberNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errSubscriberNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ch, active, err := r.SubscribeRunEvents()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(active) != 1 || active[0].ID != run.ID {
+		t.Errorf("received '%v' expecting '%v'", active, run.ID)
+	}
+
+	err = r.FinishRun(run.ID, errRunNotFound)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ev := <-ch
+	if ev.Event != RunStatusFailed || ev.RunID != run.ID {
+		t.Errorf("received '%v %v' expecting '%v %v'", ev.Event, ev.RunID, RunStatusFailed, run.ID)
+	}
+
+	run, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ev = <-ch
+	if ev.Event != RunEventStarted || ev.RunID != run.ID || ev.Strategy != "strat" {
+		t.Errorf("received '%v %v %v' expecting '%v %v %v'", ev.Event, ev.RunID, ev.Strategy, RunEventStarted, run.ID, "strat")
+	}
+
+	err = r.UnsubscribeRunEvents(ch)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	t.Parallel()
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Run{
+		Strategy:    "strat",
+		EquityCurve: []EquityPoint{{Time: tt, Equity: 1}, {Time: tt.Add(time.Minute), Equity: 2.5}},
+	}
+	_, err := r.Export("xml")
+	if !errors.Is(err, errUnsupportedFormat) {
+		t.Errorf("received '%v' expecting '%v'", err, errUnsupportedFormat)
+	}
+
+	data, err := r.Export(ExportFormatCSV)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	expected := "time,equity\n2022-01-01T00:00:00Z,1\n2022-01-01T00:01:00Z,2.5\n"
+	if string(data) != expected {
+		t.Errorf("received '%v' expecting '%v'", string(data), expected)
+	}
+
+	data, err = r.Export("JSON")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	var resp Run
+	err = json.Unmarshal(data, &resp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if resp.Strategy != "strat" || len(resp.EquityCurve) != 2 {
+		t.Errorf("received '%v' expecting '%v'", resp, r)
+	}
+}
+
+func TestRunManagerRecentCompletedRuns(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.RecentCompletedRuns(1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	first, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	second, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.FinishRun(first.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.FinishRun(second.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	r.m.Lock()
+	r.runs[0].EndTime = time.Now().Add(time.Hour)
+	r.m.Unlock()
+
+	runs, err := r.RecentCompletedRuns(10)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(runs), 2)
+	}
+	if runs[0].ID != first.ID || runs[1].ID != second.ID {
+		t.Errorf("received '%v, %v' expecting '%v, %v'", runs[0].ID, runs[1].ID, first.ID, second.ID)
+	}
+
+	runs, err = r.RecentCompletedRuns(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 1 || runs[0].ID != first.ID {
+		t.Errorf("received '%v' expecting '%v'", runs, first.ID)
+	}
+}
+
+func TestRunReturnPercent(t *testing.T) {
+	t.Parallel()
+	r := &Run{}
+	if _, ok := r.ReturnPercent(); ok {
+		t.Error("expected no return without equity data")
+	}
+	r.EquityCurve = []EquityPoint{{Equity: 0}, {Equity: 10}}
+	if _, ok := r.ReturnPercent(); ok {
+		t.Error("expected no return when starting equity is zero")
+	}
+	r.EquityCurve = []EquityPoint{{Equity: 200}, {Equity: 250}, {Equity: 150}}
+	ret, ok := r.ReturnPercent()
+	if !ok {
+		t.Fatal("expected a return")
+	}
+	if ret != -25 {
+		t.Errorf("received '%v' expecting '%v'", ret, -25)
+	}
+}
+
+func TestRunManagerUpdateSettings(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.UpdateSettings(context.Background(), uuid.Nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.UpdateSettings(context.Background(), uuid.Nil, nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	unsupported, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.UpdateSettings(context.Background(), unsupported.ID, nil)
+	if !errors.Is(err, errLiveUpdatesUnsupported) {
+		t.Errorf("received '%v' expecting '%v'", err, errLiveUpdatesUnsupported)
+	}
+
+	updates := make(chan *settingsUpdate)
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat", settingsUpdates: updates}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	go func() {
+		u := <-updates
+		u.result <- errRunAlreadyActive
+	}()
+	err = r.UpdateSettings(context.Background(), run.ID, map[string]interface{}{"key": 1.0})
+	if !errors.Is(err, errRunAlreadyActive) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunAlreadyActive)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = r.UpdateSettings(ctx, run.ID, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("received '%v' expecting '%v'", err, context.Canceled)
+	}
+
+	err = r.FinishRun(run.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.UpdateSettings(context.Background(), run.ID, nil)
+	if !errors.Is(err, errRunNotRunning) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotRunning)
+	}
+}
+
+func TestRunManagerGetLogs(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.GetLogs(uuid.Nil, "", 0)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	// appending to a nil manager is a no-op
+	r.AppendLog(LogRecord{})
+
+	r = &RunManager{}
+	_, err = r.GetLogs(uuid.Nil, "", 0)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	r.AppendLog(LogRecord{Level: LogLevelInfo, Message: "one"})
+	r.AppendLog(LogRecord{Level: LogLevelError, Message: "two"})
+	// lines logged by other goroutines are not attributed to the run
+	logged := make(chan struct{})
+	go func() {
+		r.AppendLog(LogRecord{Level: LogLevelInfo, Message: "elsewhere"})
+		close(logged)
+	}()
+	<-logged
+	r.AppendLog(LogRecord{Level: LogLevelInfo, Message: "three"})
+	err = r.FinishRun(run.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	// finished runs no longer receive logs
+	r.AppendLog(LogRecord{Level: LogLevelInfo, Message: "four"})
+
+	logs, err := r.GetLogs(run.ID, "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(logs), 3)
+	}
+	logs, err = r.GetLogs(run.ID, "INFO", 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs) != 1 || logs[0].Message != "three" {
+		t.Errorf("received '%v' expecting '%v'", logs, "three")
+	}
+
+	for i := 0; i < defaultBufferedRunLogs; i++ {
+		var next *Run
+		next, err = r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		err = r.FinishRun(next.ID, nil)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	_, err = r.GetLogs(run.ID, "", 0)
+	if !errors.Is(err, errLogsEvicted) {
+		t.Errorf("received '%v' expecting '%v'", err, errLogsEvicted)
+	}
+}
+
+func TestRunManagerAppendLogLimit(t *testing.T) {
+	t.Parallel()
+	r := &RunManager{}
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := 0; i <= maxRunLogLines; i++ {
+		r.AppendLog(LogRecord{Message: strconv.Itoa(i)})
+	}
+	logs, err := r.GetLogs(run.ID, "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs) != maxRunLogLines {
+		t.Fatalf("received '%v' expecting '%v'", len(logs), maxRunLogLines)
+	}
+	if logs[0].Message != "1" {
+		t.Errorf("received '%v' expecting '%v'", logs[0].Message, "1")
+	}
+}
+
+func TestRunManagerDroppedEventLogging(t *testing.T) {
+	// not parallel as the backtester sub logger output is replaced
+	log.RWM.Lock()
+	previous := log.GlobalLogConfig
+	log.GlobalLogConfig = log.GenDefaultSettings()
+	log.RWM.Unlock()
+	backtester := common.Backtester
+	defer func() {
+		common.Backtester = backtester
+		log.RWM.Lock()
+		log.GlobalLogConfig = previous
+		log.RWM.Unlock()
+	}()
+	sl, err := log.NewSubLogger("DroppedEventTest")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	common.Backtester = sl
+
+	r := &RunManager{}
+	sl.SetOutput(&runLogWriter{managers: []*RunManager{r}})
+	sl.SetLevels(log.Levels{Warn: true, Error: true})
+	ch, _, err := r.SubscribeRunEvents()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := 0; i < cap(ch); i++ {
+		if _, err = r.StartRun(&Run{}, false); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+
+	// the subscriber is full, so the next event is dropped and its warning is
+	// logged back into the run manager
+	done := make(chan *Run)
+	go func() {
+		run, startErr := r.StartRun(&Run{}, false)
+		if startErr != nil {
+			t.Error(startErr)
+		}
+		if finishErr := r.FinishRun(run.ID, nil); finishErr != nil {
+			t.Error(finishErr)
+		}
+		done <- run
+	}()
+	var run *Run
+	select {
+	case run = <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("run manager deadlocked logging a dropped event")
+	}
+	logs, err := r.GetLogs(run.ID, "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0].Message, "dropping started event for run "+run.ID.String()) {
+		t.Errorf("received '%v' expecting a dropped started event warning", logs)
+	}
+}
+
+func TestRunLogWriter(t *testing.T) {
+	log.RWM.Lock()
+	previous := log.GlobalLogConfig
+	log.GlobalLogConfig = log.GenDefaultSettings()
+	log.RWM.Unlock()
+	defer func() {
+		log.RWM.Lock()
+		log.GlobalLogConfig = previous
+		log.RWM.Unlock()
+	}()
+
+	r := &RunManager{}
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	w := &runLogWriter{managers: []*RunManager{r}}
+	output := []byte("[WARN] | warning\n[ERROR] | failure\n\nno header\n")
+	n, err := w.Write(output)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if n != len(output) {
+		t.Errorf("received '%v' expecting '%v'", n, len(output))
+	}
+	logs, err := r.GetLogs(run.ID, "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	expected := []string{LogLevelWarn, LogLevelError, LogLevelInfo}
+	if len(logs) != len(expected) {
+		t.Fatalf("received '%v' expecting '%v'", len(logs), len(expected))
+	}
+	for i := range expected {
+		if logs[i].Level != expected[i] {
+			t.Errorf("received '%v' expecting '%v'", logs[i].Level, expected[i])
+		}
+	}
+}
+
+func TestRunLogWriterRegister(t *testing.T) {
+	// not parallel as the backtester sub logger output is replaced
+	backtester := common.Backtester
+	defer func() {
+		common.Backtester = backtester
+	}()
+	sl, err := log.NewSubLogger("RunLogWriterRegisterTest")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	common.Backtester = sl
+
+	w := &runLogWriter{}
+	first, second := &RunManager{}, &RunManager{}
+	w.register(first)
+	output := sl.GetOutput()
+	w.register(first)
+	w.register(second)
+	if sl.GetOutput() != output {
+		t.Error("expected the sub logger output to only be wrapped once")
+	}
+	if len(w.managers) != 2 || w.managers[0] != first || w.managers[1] != second {
+		t.Errorf("received '%v' expecting '%v'", w.managers, []*RunManager{first, second})
+	}
+}
+
+func TestRunManagerImportRegistry(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.ExportRegistry()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, _, err = r.ImportRegistry(nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	_, _, err = r.ImportRegistry([]byte("{"))
+	if !errors.Is(err, errInvalidRegistry) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidRegistry)
+	}
+	_, _, err = r.ImportRegistry([]byte(`{"version":1337,"runs":[]}`))
+	if !errors.Is(err, errRegistryVersion) {
+		t.Errorf("received '%v' expecting '%v'", err, errRegistryVersion)
+	}
+	_, _, err = r.ImportRegistry([]byte(`{"version":2,"runs":[null]}`))
+	if !errors.Is(err, errInvalidRegistry) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidRegistry)
+	}
+	_, _, err = r.ImportRegistry([]byte(`{"version":2,"runs":[{"status":"completed"}]}`))
+	if !errors.Is(err, errInvalidRegistry) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidRegistry)
+	}
+	id, err := uuid.NewV4()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, _, err = r.ImportRegistry([]byte(`{"version":2,"runs":[{"id":"` + id.String() + `","status":"running"}]}`))
+	if !errors.Is(err, errInvalidRegistry) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidRegistry)
+	}
+
+	running, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	finished, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.FinishRun(finished.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	data, err := r.ExportRegistry()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	// runs which already exist are skipped
+	imported, skipped, err := r.ImportRegistry(data)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if imported != 0 || skipped != 1 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", imported, skipped, 0, 1)
+	}
+
+	other := &RunManager{}
+	imported, skipped, err = other.ImportRegistry(data)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if imported != 1 || skipped != 0 {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", imported, skipped, 1, 0)
+	}
+	_, err = other.GetRun(running.ID)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	_, err = other.GetLogs(finished.ID, "", 0)
+	if !errors.Is(err, errLogsEvicted) {
+		t.Errorf("received '%v' expecting '%v'", err, errLogsEvicted)
+	}
+}
+
+func TestRunManagerSetLogRetention(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.SetLogRetention(1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	if retention := r.LogRetention(); retention != 0 {
+		t.Errorf("received '%v' expecting '%v'", retention, 0)
+	}
+
+	r = &RunManager{}
+	if retention := r.LogRetention(); retention != defaultBufferedRunLogs {
+		t.Errorf("received '%v' expecting '%v'", retention, defaultBufferedRunLogs)
+	}
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		err = r.FinishRun(run.ID, nil)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		ids = append(ids, run.ID)
+	}
+	err = r.SetLogRetention(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if retention := r.LogRetention(); retention != 1 {
+		t.Errorf("received '%v' expecting '%v'", retention, 1)
+	}
+	for i, id := range ids {
+		_, err = r.GetLogs(id, "", 0)
+		var expected error
+		if i < len(ids)-1 {
+			expected = errLogsEvicted
+		}
+		if !errors.Is(err, expected) {
+			t.Errorf("received '%v' expecting '%v'", err, expected)
+		}
+	}
+}
+
+func TestRunManagerSetRunRetention(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.SetRunRetention(1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	if retention := r.RunRetention(); retention != 0 {
+		t.Errorf("received '%v' expecting '%v'", retention, 0)
+	}
+
+	r = &RunManager{}
+	if retention := r.RunRetention(); retention != defaultRetainedRuns {
+		t.Errorf("received '%v' expecting '%v'", retention, defaultRetainedRuns)
+	}
+	running, err := r.StartRun(&Run{ConfigHash: "running", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	var ids []uuid.UUID
+	for i := 0; i < 4; i++ {
+		run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		err = r.FinishRun(run.ID, nil)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		ids = append(ids, run.ID)
+	}
+	_, err = r.SetBaseline(ids[0])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.SetRunRetention(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if retention := r.RunRetention(); retention != 2 {
+		t.Errorf("received '%v' expecting '%v'", retention, 2)
+	}
+	// the running run and baseline are kept alongside the latest two runs
+	for _, id := range []uuid.UUID{running.ID, ids[0], ids[2], ids[3]} {
+		if _, err = r.GetRun(id); !errors.Is(err, nil) {
+			t.Errorf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	if _, err = r.GetRun(ids[1]); !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	// finishing another run evicts the oldest retained run
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.FinishRun(run.ID, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if _, err = r.GetRun(ids[2]); !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	runs, err := r.ListRuns(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(runs) != 4 {
+		t.Errorf("received '%v' expecting '%v'", len(runs), 4)
+	}
+}
+
+func TestRunManagerSetCandleRetention(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.SetCandleRetention(1)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	if retention := r.CandleRetention(); retention != 0 {
+		t.Errorf("received '%v' expecting '%v'", retention, 0)
+	}
+
+	r = &RunManager{}
+	if retention := r.CandleRetention(); retention != defaultBufferedRunCandles {
+		t.Errorf("received '%v' expecting '%v'", retention, defaultBufferedRunCandles)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		err = r.RecordCandle(run.ID, &candleRecord{exchange: "binance", asset: asset.Spot, pair: pair})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		err = r.FinishRun(run.ID, nil)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		ids = append(ids, run.ID)
+	}
+	err = r.SetCandleRetention(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if retention := r.CandleRetention(); retention != 1 {
+		t.Errorf("received '%v' expecting '%v'", retention, 1)
+	}
+	for i, id := range ids {
+		candles, err := r.GetCandles(id, "binance", asset.Spot, pair, time.Time{}, time.Time{})
+		if i < len(ids)-1 {
+			if !errors.Is(err, errCandlesEvicted) {
+				t.Errorf("received '%v' expecting '%v'", err, errCandlesEvicted)
+			}
+			continue
+		}
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+		if len(candles) != 1 {
+			t.Errorf("received '%v' expecting '%v'", len(candles), 1)
+		}
+	}
+
+	// runs keep only their latest candles
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= maxRunCandles; i++ {
+		err = r.RecordCandle(run.ID, &candleRecord{time: start.Add(time.Duration(i) * time.Minute), exchange: "binance", asset: asset.Spot, pair: pair})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	candles, err := r.GetCandles(run.ID, "binance", asset.Spot, pair, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(candles) != maxRunCandles || !candles[0].time.Equal(start.Add(time.Minute)) {
+		t.Errorf("received '%v' candles from '%v' expecting '%v' from '%v'", len(candles), candles[0].time, maxRunCandles, start.Add(time.Minute))
+	}
+}
+
+func TestRunManagerCancelRun(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.CancelRun(context.Background(), uuid.Nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.CancelRun(context.Background(), uuid.Nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	uncancellable, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.CancelRun(context.Background(), uncancellable.ID)
+	if !errors.Is(err, errRunNotCancellable) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotCancellable)
+	}
+
+	cancel := make(chan struct{})
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat", cancel: cancel}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	// the run does not finish before the context is done
+	err = r.CancelRun(ctx, run.ID)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("received '%v' expecting '%v'", err, context.Canceled)
+	}
+	go func() {
+		<-cancel
+		if finishErr := r.FinishRun(run.ID, errRunCancelled); finishErr != nil {
+			t.Error(finishErr)
+		}
+	}()
+	err = r.CancelRun(context.Background(), run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	cancelled, err := r.GetRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if cancelled.Status != RunStatusCancelled || cancelled.Error != "" {
+		t.Errorf("received '%v' expecting '%v'", cancelled.Status, RunStatusCancelled)
+	}
+	err = r.CancelRun(context.Background(), run.ID)
+	if !errors.Is(err, errRunNotRunning) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotRunning)
+	}
+}
+
+func TestRunManagerWatchProgress(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.WatchProgress(uuid.Nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = r.UnwatchProgress(uuid.Nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	_, err = r.WatchProgress(uuid.Nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	run, err := r.StartRun(&Run{ConfigHash: "hash"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	ch, err := r.WatchProgress(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for i := int64(1); i <= 5; i++ {
+		err = r.UpdateProgress(run.ID, i, 5)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	<-ch
+	select {
+	case <-ch:
+		t.Error("expected progress signals to be coalesced")
+	default:
+	}
+
+	err = r.UnwatchProgress(run.ID, ch)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.UnwatchProgress(run.ID, ch)
+	if !errors.Is(err, errWatcherNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errWatcherNotFound)
+	}
+}
+
+func TestRunManagerRecordTrade(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.RecordTrade(uuid.Nil, &TradeRecord{})
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, _, err = r.GetTrades(uuid.Nil, 0, 0)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.RecordTrade(uuid.Nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = r.RecordTrade(uuid.Nil, &TradeRecord{})
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	_, _, err = r.GetTrades(uuid.Nil, 0, 0)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for _, tc := range []struct {
+		pair     string
+		side     gctorder.Side
+		price    float64
+		amount   float64
+		expected float64
+	}{
+		{pair: "BTCUSDT", side: gctorder.Buy, price: 100, amount: 1},
+		{pair: "BTCUSDT", side: gctorder.Buy, price: 200, amount: 1},
+		// entry price is averaged to 150
+		{pair: "BTCUSDT", side: gctorder.Sell, price: 170, amount: 1, expected: 20},
+		// closes the remaining long and opens a short at 120
+		{pair: "BTCUSDT", side: gctorder.Sell, price: 120, amount: 2, expected: -30},
+		{pair: "BTCUSDT", side: gctorder.ClosePosition, price: 100, amount: 5, expected: 20},
+		// other pairs are tracked separately
+		{pair: "ETHUSDT", side: gctorder.Short, price: 10, amount: 3},
+		{pair: "ETHUSDT", side: gctorder.Long, price: 12, amount: 1, expected: -2},
+	} {
+		err = r.RecordTrade(run.ID, &TradeRecord{
+			Pair:   tc.pair,
+			Side:   tc.side.String(),
+			Price:  tc.price,
+			Amount: tc.amount,
+		})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+
+	trades, total, err := r.GetTrades(run.ID, 0, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if total != 7 || len(trades) != 7 {
+		t.Fatalf("received '%v' '%v' expecting '%v'", total, len(trades), 7)
+	}
+	for i, expected := range []float64{0, 0, 20, -30, 20, 0, -2} {
+		if trades[i].RealisedPNL != expected {
+			t.Errorf("trade %v received '%v' expecting '%v'", i, trades[i].RealisedPNL, expected)
+		}
+	}
+
+	trades, total, err = r.GetTrades(run.ID, 5, 10)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if total != 7 || len(trades) != 2 || trades[0].Pair != "ETHUSDT" {
+		t.Errorf("received '%v' '%v' expecting '%v' '%v'", total, trades, 7, 2)
+	}
+}
+
+func TestRunManagerPauseRun(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.PauseRun(uuid.Nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.PauseRun(uuid.Nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	unpausable, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.PauseRun(unpausable.ID)
+	if !errors.Is(err, errRunNotPausable) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotPausable)
+	}
+
+	gate := newRunGate()
+	cancel := make(chan struct{})
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat", cancel: cancel, gate: gate}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.ResumeRun(run.ID)
+	if !errors.Is(err, errRunNotPaused) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotPaused)
+	}
+	err = r.PauseRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = r.PauseRun(run.ID)
+	if !errors.Is(err, errRunAlreadyPaused) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunAlreadyPaused)
+	}
+	if paused, _ := r.GetRun(run.ID); !paused.Paused {
+		t.Error("expected run to be paused")
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		gate.wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("expected gate to block while paused")
+	case <-time.After(time.Millisecond * 20):
+	}
+	err = r.ResumeRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	select {
+	case <-waited:
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected gate to be released on resume")
+	}
+
+	// cancelling a paused run releases it so the cancellation is observed
+	err = r.PauseRun(run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	go func() {
+		gate.wait()
+		<-cancel
+		if finishErr := r.FinishRun(run.ID, errRunCancelled); finishErr != nil {
+			t.Error(finishErr)
+		}
+	}()
+	err = r.CancelRun(context.Background(), run.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	finished, _ := r.GetRun(run.ID)
+	if finished.Paused || finished.Status != RunStatusCancelled {
+		t.Errorf("received '%v %v' expecting '%v %v'", finished.Paused, finished.Status, false, RunStatusCancelled)
+	}
+	err = r.ResumeRun(run.ID)
+	if !errors.Is(err, errRunNotRunning) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotRunning)
+	}
+}
+
+func TestRunManagerSetBaseline(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	_, err := r.SetBaseline(uuid.Nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = r.Baseline()
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	_, err = r.Baseline()
+	if !errors.Is(err, errNoBaseline) {
+		t.Errorf("received '%v' expecting '%v'", err, errNoBaseline)
+	}
+	_, err = r.SetBaseline(uuid.Nil)
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	first, err := r.StartRun(&Run{ConfigHash: "first", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	_, err = r.SetBaseline(first.ID)
+	if !errors.Is(err, errRunNotCompleted) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotCompleted)
+	}
+	second, err := r.StartRun(&Run{ConfigHash: "second", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		if err = r.FinishRun(id, nil); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+
+	previous, err := r.SetBaseline(first.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !previous.IsNil() {
+		t.Errorf("received '%v' expecting '%v'", previous, uuid.Nil)
+	}
+	previous, err = r.SetBaseline(second.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if previous != first.ID {
+		t.Errorf("received '%v' expecting '%v'", previous, first.ID)
+	}
+	baseline, err := r.Baseline()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if baseline != second.ID {
+		t.Errorf("received '%v' expecting '%v'", baseline, second.ID)
+	}
+}
+
+func TestRunManagerGetCandles(t *testing.T) {
+	t.Parallel()
+	var r *RunManager
+	err := r.RecordCandle(uuid.Nil, &candleRecord{})
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	_, err = r.GetCandles(uuid.Nil, "", asset.Spot, currency.EMPTYPAIR, time.Time{}, time.Time{})
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+
+	r = &RunManager{}
+	err = r.RecordCandle(uuid.Nil, nil)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	err = r.RecordCandle(uuid.Nil, &candleRecord{})
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+	_, err = r.GetCandles(uuid.Nil, "", asset.Spot, currency.EMPTYPAIR, time.Time{}, time.Time{})
+	if !errors.Is(err, errRunNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errRunNotFound)
+	}
+
+	run, err := r.StartRun(&Run{ConfigHash: "hash", Strategy: "strat"}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	for _, c := range []candleRecord{
+		{time: start.Add(time.Hour), exchange: "binance", asset: asset.Spot, pair: pair, close: 2},
+		{time: start, exchange: "binance", asset: asset.Spot, pair: pair, close: 1},
+		{time: start, exchange: "binance", asset: asset.Spot, pair: currency.NewPair(currency.ETH, currency.USDT)},
+		{time: start, exchange: "binance", asset: asset.Futures, pair: pair},
+		{time: start, exchange: "ftx", asset: asset.Spot, pair: pair},
+		{time: start.Add(2 * time.Hour), exchange: "binance", asset: asset.Spot, pair: pair, close: 3},
+	} {
+		c := c
+		if err = r.RecordCandle(run.ID, &c); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	candles, err := r.GetCandles(run.ID, "Binance", asset.Spot, currency.NewPairWithDelimiter("btc", "usdt", "-"), time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(candles) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(candles), 3)
+	}
+	for i := range candles {
+		if candles[i].close != float64(i+1) {
+			t.Errorf("received '%v' expecting '%v'", candles[i].close, i+1)
+		}
+	}
+	candles, err = r.GetCandles(run.ID, "binance", asset.Spot, pair, start.Add(time.Hour), start.Add(time.Hour))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(candles) != 1 || candles[0].close != 2 {
+		t.Errorf("received '%v' expecting the single candle within range", candles)
+	}
+}
@@ -0,0 +1,266 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+const (
+	// RunStatusRunning is the status of a run which is currently executing
+	RunStatusRunning = "running"
+	// RunStatusCompleted is the status of a run which finished without error
+	RunStatusCompleted = "completed"
+	// RunStatusFailed is the status of a run which finished with an error
+	RunStatusFailed = "failed"
+	// RunStatusCancelled is the status of a run which was cancelled before
+	// finishing
+	RunStatusCancelled = "cancelled"
+	// RunStatusAborted is the status of a run which was stopped early after
+	// breaching a guardrail, such as its maximum drawdown
+	RunStatusAborted = "aborted"
+	// RunEventStarted is the event sent to subscribers when a run starts.
+	// Finished runs send their final status as the event
+	RunEventStarted = "started"
+
+	// ExportFormatJSON exports a run's details and equity curve as JSON
+	ExportFormatJSON = "json"
+	// ExportFormatCSV exports a run's equity curve as CSV
+	ExportFormatCSV = "csv"
+
+	runEventBufferSize = 100
+	// maxRecentRuns is the most completed runs returned by GetRecentRuns
+	maxRecentRuns = 100
+	// maxExportResultsSize is the largest combined size of exports returned
+	// by ExportResults, keeping responses under the default GRPC message
+	// size limit
+	maxExportResultsSize = 4 * 1024 * 1024
+	// maxRunLogLines is the most log lines buffered for an individual run,
+	// older lines are dropped first
+	maxRunLogLines = 10000
+	// defaultBufferedRunLogs is the number of finished runs which keep their
+	// buffered logs when no retention is set, older finished runs have their
+	// logs evicted
+	defaultBufferedRunLogs = 10
+	// maxRunCandles is the most candles buffered for an individual run, older
+	// candles are dropped first
+	maxRunCandles = 100000
+	// defaultBufferedRunCandles is the number of finished runs which keep
+	// their buffered candles when no retention is set, older finished runs
+	// have their candles evicted
+	defaultBufferedRunCandles = 10
+	// defaultRetainedRuns is the number of finished runs kept when no run
+	// retention is set, older finished runs are removed
+	defaultRetainedRuns = 1000
+	// registryVersion is the version of exported run registries, it must be
+	// incremented whenever the Run format changes incompatibly
+	registryVersion = 2
+
+	// LogLevelInfo is the level of captured info logs
+	LogLevelInfo = "info"
+	// LogLevelWarn is the level of captured warning logs
+	LogLevelWarn = "warn"
+	// LogLevelDebug is the level of captured debug logs
+	LogLevelDebug = "debug"
+	// LogLevelError is the level of captured error logs
+	LogLevelError = "error"
+)
+
+var (
+	errRunNotFound        = errors.New("run not found")
+	errRunAlreadyActive   = errors.New("config is already being run")
+	errSubscriberNotFound = errors.New("run event subscriber not found")
+	errWatcherNotFound    = errors.New("run progress watcher not found")
+	errUnsupportedFormat  = errors.New("unsupported export format")
+	errRunNotRunning      = errors.New("run is not running")
+	errLogsEvicted        = errors.New("run logs have been evicted")
+	errCandlesEvicted     = errors.New("run candles have been evicted")
+	errInvalidLogLevel    = errors.New("invalid log level")
+	errRegistryVersion    = errors.New("unsupported registry version")
+	errInvalidRegistry    = errors.New("invalid registry data")
+	errRunNotCancellable  = errors.New("run cannot be cancelled")
+	errRunCancelled       = errors.New("run cancelled")
+	errNoStoredConfig     = errors.New("run has no stored config")
+	errRunNotPausable     = errors.New("run cannot be paused")
+	errRunAlreadyPaused   = errors.New("run is already paused")
+	errRunNotPaused       = errors.New("run is not paused")
+	errRunNotCompleted    = errors.New("run is not completed")
+	errNoBaseline         = errors.New("no baseline run is set")
+	errRunAborted         = errors.New("run aborted")
+)
+
+// RunManager keeps track of all strategy runs executed by the GRPC server
+type RunManager struct {
+	m           sync.Mutex
+	runs        []*Run
+	subscribers map[chan RunEvent]struct{}
+	// progressWatchers are notified whenever the progress of the run they
+	// watch changes
+	progressWatchers map[uuid.UUID]map[chan struct{}]struct{}
+	// logRetention is the number of finished runs which keep their
+	// buffered logs, defaultBufferedRunLogs is used when unset
+	logRetention int
+	// candleRetention is the number of finished runs which keep their
+	// buffered candles, defaultBufferedRunCandles is used when unset
+	candleRetention int
+	// runRetention is the number of finished runs kept, defaultRetainedRuns
+	// is used when unset
+	runRetention int
+	// baseline is the completed run which comparisons default to, unset
+	// when uuid.Nil
+	baseline uuid.UUID
+	// logRoutines maps the goroutine executing each running run to the run,
+	// attributing captured log lines to the run which wrote them
+	logRoutines map[uint64]*Run
+}
+
+// RunEvent is sent to subscribers whenever a run starts or finishes
+type RunEvent struct {
+	Event    string
+	RunID    uuid.UUID
+	Strategy string
+	Time     time.Time
+}
+
+// Run holds the details of an individual strategy execution
+type Run struct {
+	ID         uuid.UUID         `json:"id"`
+	ConfigHash string            `json:"config-hash"`
+	Strategy   string            `json:"strategy"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	StartTime  time.Time         `json:"start-time"`
+	EndTime    time.Time         `json:"end-time"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	// BuildInfo is the build of the backtester which executed the run
+	BuildInfo BuildInfo `json:"build-info"`
+	// Paused is set while a running run is blocked from processing events
+	Paused bool `json:"paused,omitempty"`
+	// EventsProcessed and EventsTotal track the progression of the run
+	// through its loaded data events
+	EventsProcessed int64 `json:"events-processed"`
+	EventsTotal     int64 `json:"events-total"`
+	// EquityCurve holds the total value of all holdings over time
+	EquityCurve []EquityPoint `json:"equity-curve"`
+	// Trades holds every order filled during the run
+	Trades []TradeRecord `json:"trades,omitempty"`
+	// latestEquity holds the latest holdings value for each exchange,
+	// asset and pair so they can be summed into the equity curve
+	latestEquity map[string]float64
+	// positions holds the open position of each exchange, asset and pair so
+	// the realised profit of trades can be calculated
+	positions map[string]*tradePosition
+	// candles holds every candle loaded by the backtest, in the order they
+	// were consumed
+	candles        []candleRecord
+	candlesEvicted bool
+	// settingsUpdates delivers custom strategy settings to the running
+	// backtest, it is nil when the run does not accept updates
+	settingsUpdates chan *settingsUpdate
+	// done is closed when the run finishes
+	done chan struct{}
+	// cancel is closed to request the run stops early, it is nil when the
+	// run cannot be cancelled
+	cancel    chan struct{}
+	cancelled bool
+	// gate blocks the running backtest while it is paused, it is nil when
+	// the run cannot be paused
+	gate *runGate
+	// config is the strategy config the run was executed with
+	config *config.Config
+	// startHeapAlloc is the heap allocated by the process when the run
+	// started, allowing heap growth during the run to be sampled
+	startHeapAlloc uint64
+	// logs holds log lines written while the run was executing
+	logs        []LogRecord
+	logsEvicted bool
+	// logRoutine is the goroutine which started and executes the run, only
+	// lines it logs are captured into logs
+	logRoutine uint64
+}
+
+// runGate blocks a running backtest between events while it is paused
+type runGate struct {
+	m      sync.Mutex
+	cond   *sync.Cond
+	paused bool
+}
+
+// LogRecord is a log line captured during a run
+type LogRecord struct {
+	Time    time.Time
+	Level   string
+	Message string
+}
+
+// registrySnapshot is the exported form of all finished runs, allowing the
+// run history to be moved between servers
+type registrySnapshot struct {
+	Version int           `json:"version"`
+	Runs    []registryRun `json:"runs"`
+}
+
+// registryRun is an exported run along with the strategy config it was
+// executed with, so imported runs can be restarted and benchmarked
+type registryRun struct {
+	*Run
+	Config *config.Config `json:"config,omitempty"`
+}
+
+// EquityPoint is the total value of a run's holdings at a point in time
+type EquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+// candleRecord is a candle consumed by a run
+type candleRecord struct {
+	time     time.Time
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
+	open     float64
+	high     float64
+	low      float64
+	close    float64
+	volume   float64
+}
+
+// TradeRecord is an order filled during a run. RealisedPNL is the profit made
+// by the trade closing an existing position, calculated at the average entry
+// price of the position and excluding fees
+type TradeRecord struct {
+	Time        time.Time `json:"time"`
+	Exchange    string    `json:"exchange"`
+	Asset       string    `json:"asset"`
+	Pair        string    `json:"pair"`
+	Side        string    `json:"side"`
+	Price       float64   `json:"price"`
+	Amount      float64   `json:"amount"`
+	Fee         float64   `json:"fee"`
+	RealisedPNL float64   `json:"realised-pnl"`
+}
+
+// tradePosition is the open position of a pair. Amount is negative for
+// short positions
+type tradePosition struct {
+	amount     float64
+	entryPrice float64
+}
+
+// runLogWriter captures backtester log output into the buffered logs of the
+// run executing on the logging goroutine. It is teed into the backtester sub
+// loggers once and run managers register with it
+type runLogWriter struct {
+	install  sync.Once
+	m        sync.RWMutex
+	managers []*RunManager
+}
+
+// runLogs is the log writer shared by all GRPC servers
+var runLogs runLogWriter
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Now returns the current local time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc calls f once d has elapsed using a time.Timer
+func (systemClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// schedule registers launch to be called by the clock at the start time,
+// returning the ID which can be used to cancel it beforehand
+func (r *scheduledRuns) schedule(c clock, startTime time.Time, launch func()) (uuid.UUID, error) {
+	delay := startTime.Sub(c.Now())
+	if delay <= 0 {
+		return uuid.Nil, fmt.Errorf("%w, received %v", errScheduleInPast, startTime)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[uuid.UUID]*scheduledRun)
+	}
+	sr := &scheduledRun{startTime: startTime}
+	r.runs[id] = sr
+	// the timer callback blocks on the lock until stop has been set
+	sr.stop = c.AfterFunc(delay, func() {
+		if r.begin(id) {
+			launch()
+		}
+	})
+	return id, nil
+}
+
+// begin removes the scheduled run as it starts, returning false when it has
+// been cancelled or already started
+func (r *scheduledRuns) begin(id uuid.UUID) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if _, ok := r.runs[id]; !ok {
+		return false
+	}
+	delete(r.runs, id)
+	return true
+}
+
+// cancel stops a scheduled run which has not yet started, returning the time
+// it was scheduled to start
+func (r *scheduledRuns) cancel(id uuid.UUID) (time.Time, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	sr, ok := r.runs[id]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w %v", errScheduleNotFound, id)
+	}
+	sr.stop()
+	delete(r.runs, id)
+	return sr.startTime, nil
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// fakeClock is a clock whose time only moves when advanced, timers which
+// become due are called synchronously by advance
+type fakeClock struct {
+	m      sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return func() bool {
+		c.m.Lock()
+		defer c.m.Unlock()
+		wasActive := !timer.stopped
+		timer.stopped = true
+		return wasActive
+	}
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.m.Lock()
+	c.now = c.now.Add(d)
+	var due []func()
+	for _, timer := range c.timers {
+		if !timer.stopped && !timer.at.After(c.now) {
+			timer.stopped = true
+			due = append(due, timer.f)
+		}
+	}
+	c.m.Unlock()
+	for _, f := range due {
+		f()
+	}
+}
+
+func TestScheduledRuns(t *testing.T) {
+	t.Parallel()
+	c := &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var r scheduledRuns
+	_, err := r.schedule(c, c.now, func() {})
+	if !errors.Is(err, errScheduleInPast) {
+		t.Errorf("received '%v' expecting '%v'", err, errScheduleInPast)
+	}
+
+	var launched int
+	first, err := r.schedule(c, c.now.Add(time.Hour), func() { launched++ })
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	second, err := r.schedule(c, c.now.Add(time.Hour), func() { launched++ })
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	startTime, err := r.cancel(second)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !startTime.Equal(c.now.Add(time.Hour)) {
+		t.Errorf("received '%v' expecting '%v'", startTime, c.now.Add(time.Hour))
+	}
+	_, err = r.cancel(second)
+	if !errors.Is(err, errScheduleNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errScheduleNotFound)
+	}
+	_, err = r.cancel(uuid.Nil)
+	if !errors.Is(err, errScheduleNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errScheduleNotFound)
+	}
+
+	c.advance(time.Minute * 59)
+	if launched != 0 {
+		t.Errorf("received '%v' expecting '%v'", launched, 0)
+	}
+	c.advance(time.Minute)
+	if launched != 1 {
+		t.Errorf("received '%v' expecting '%v'", launched, 1)
+	}
+	// started runs are no longer held
+	_, err = r.cancel(first)
+	if !errors.Is(err, errScheduleNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errScheduleNotFound)
+	}
+	if r.begin(first) {
+		t.Error("expected a started run to not begin again")
+	}
+	if len(r.runs) != 0 {
+		t.Errorf("received '%v' expecting '%v'", len(r.runs), 0)
+	}
+}
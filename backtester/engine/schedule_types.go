@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+var (
+	errScheduleNotFound = errors.New("scheduled run not found or already started")
+	errScheduleInPast   = errors.New("scheduled start time must be in the future")
+)
+
+// clock provides the current time and timers so that scheduling can be
+// driven by a fake clock in tests
+type clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed, the returned
+	// func stops the timer and reports whether it was stopped before firing
+	AfterFunc(d time.Duration, f func()) func() bool
+}
+
+// systemClock is the clock backed by the time package
+type systemClock struct{}
+
+// scheduledRuns holds strategy configs waiting for their start time
+type scheduledRuns struct {
+	m    sync.Mutex
+	runs map[uuid.UUID]*scheduledRun
+}
+
+// scheduledRun is a strategy config waiting for its start time. It is removed
+// once started or cancelled
+type scheduledRun struct {
+	startTime time.Time
+	stop      func() bool
+}
@@ -0,0 +1,40 @@
+package engine
+
+import "fmt"
+
+// acquireRun reserves a slot for a run to execute, it must be released via
+// releaseRun once the run finishes
+func (l *serverLimits) acquireRun() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.maxConcurrentRuns > 0 && l.activeRuns >= l.maxConcurrentRuns {
+		return fmt.Errorf("%w of %v", errConcurrentRunLimit, l.maxConcurrentRuns)
+	}
+	l.activeRuns++
+	return nil
+}
+
+// releaseRun frees a slot reserved by acquireRun
+func (l *serverLimits) releaseRun() {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.activeRuns > 0 {
+		l.activeRuns--
+	}
+}
+
+// setMaxConcurrentRuns changes the most runs which can execute at once.
+// Runs already executing are unaffected when the limit is lowered, new runs
+// are rejected until enough have finished
+func (l *serverLimits) setMaxConcurrentRuns(limit int) {
+	l.m.Lock()
+	l.maxConcurrentRuns = limit
+	l.m.Unlock()
+}
+
+// getMaxConcurrentRuns returns the most runs which can execute at once
+func (l *serverLimits) getMaxConcurrentRuns() int {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.maxConcurrentRuns
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestServerLimits(t *testing.T) {
+	t.Parallel()
+	l := &serverLimits{}
+	for i := 0; i < 3; i++ {
+		if err := l.acquireRun(); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+
+	// lowering the limit does not affect active runs but blocks new ones
+	l.setMaxConcurrentRuns(2)
+	if limit := l.getMaxConcurrentRuns(); limit != 2 {
+		t.Errorf("received '%v' expecting '%v'", limit, 2)
+	}
+	if err := l.acquireRun(); !errors.Is(err, errConcurrentRunLimit) {
+		t.Errorf("received '%v' expecting '%v'", err, errConcurrentRunLimit)
+	}
+	l.releaseRun()
+	if err := l.acquireRun(); !errors.Is(err, errConcurrentRunLimit) {
+		t.Errorf("received '%v' expecting '%v'", err, errConcurrentRunLimit)
+	}
+	l.releaseRun()
+	if err := l.acquireRun(); !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+
+	for i := 0; i < 5; i++ {
+		l.releaseRun()
+	}
+	if l.activeRuns != 0 {
+		t.Errorf("received '%v' expecting '%v'", l.activeRuns, 0)
+	}
+}
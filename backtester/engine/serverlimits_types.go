@@ -0,0 +1,17 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+)
+
+var errConcurrentRunLimit = errors.New("maximum concurrent runs reached")
+
+// serverLimits holds the runtime adjustable limits of the GRPC server
+type serverLimits struct {
+	m sync.Mutex
+	// maxConcurrentRuns is the most runs which can execute at once, zero
+	// is unlimited
+	maxConcurrentRuns int
+	activeRuns        int
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// start creates a new session which records calls until stopped, evicting
+// the oldest session once maxSessions are held
+func (s *sessions) start() (uuid.UUID, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.sessions == nil {
+		s.sessions = make(map[uuid.UUID]*session)
+	}
+	s.sessions[id] = &session{recording: true}
+	s.order = append(s.order, id)
+	if len(s.order) > maxSessions {
+		delete(s.sessions, s.order[0])
+		s.order = s.order[1:]
+	}
+	return id, nil
+}
+
+// stop ends recording for the session, returning the number of calls it holds
+func (s *sessions) stop(id uuid.UUID) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return 0, fmt.Errorf("%w %v", errSessionNotFound, id)
+	}
+	sess.recording = false
+	return len(sess.calls), nil
+}
+
+// record appends the call to the session when it is recording, calls against
+// stopped sessions are ignored. At most maxSessionCalls calls are recorded
+func (s *sessions) record(id uuid.UUID, method string, request proto.Message) error {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return fmt.Errorf("%w %v", errSessionNotFound, id)
+	}
+	if !sess.recording {
+		return nil
+	}
+	if len(sess.calls) >= maxSessionCalls {
+		return fmt.Errorf("%w for session %v", errSessionFull, id)
+	}
+	sess.calls = append(sess.calls, recordedCall{method: method, request: data})
+	return nil
+}
+
+// getCalls returns a copy of the calls recorded for the session
+func (s *sessions) getCalls(id uuid.UUID) ([]recordedCall, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w %v", errSessionNotFound, id)
+	}
+	calls := make([]recordedCall, len(sess.calls))
+	copy(calls, sess.calls)
+	return calls, nil
+}
+
+// recordSessionCall is a unary interceptor which records the call against the
+// session named in its metadata before handling it. Only replayable methods
+// are recorded and a call naming an unknown session is still handled
+func (s *GRPCServer) recordSessionCall(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	md, _ := metadata.FromIncomingContext(ctx)
+	ids := md.Get(sessionMetadataKey)
+	msg, ok := req.(proto.Message)
+	if len(ids) == 0 || !ok || !replayableMethods[method] {
+		return handler(ctx, req)
+	}
+	id, err := uuid.FromString(ids[0])
+	if err == nil {
+		err = s.sessions.record(id, method, msg)
+	}
+	if err != nil {
+		log.Errorf(common.Backtester, "could not record %v call for session '%v': %v", method, ids[0], err)
+	}
+	return handler(ctx, req)
+}
+
+// replayCall decodes the recorded request and passes it to the server's
+// handler for the recorded method
+func (s *GRPCServer) replayCall(ctx context.Context, call *recordedCall) (proto.Message, error) {
+	if !replayableMethods[call.method] {
+		return nil, fmt.Errorf("%w %v", errMethodNotReplayed, call.method)
+	}
+	for i := range btrpc.BacktesterService_ServiceDesc.Methods {
+		desc := &btrpc.BacktesterService_ServiceDesc.Methods[i]
+		if desc.MethodName != call.method {
+			continue
+		}
+		resp, err := desc.Handler(s, ctx, func(v interface{}) error {
+			msg, ok := v.(proto.Message)
+			if !ok {
+				return gctcommon.GetAssertError("proto.Message", v)
+			}
+			return proto.Unmarshal(call.request, msg)
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return nil, gctcommon.GetAssertError("proto.Message", resp)
+		}
+		return msg, nil
+	}
+	return nil, fmt.Errorf("%w %v", errUnknownMethod, call.method)
+}
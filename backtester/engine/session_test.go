@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+	var s sessions
+	req := &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath}
+	err := s.record(uuid.Nil, "ExecuteStrategyFromFile", req)
+	if !errors.Is(err, errSessionNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errSessionNotFound)
+	}
+	_, err = s.stop(uuid.Nil)
+	if !errors.Is(err, errSessionNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errSessionNotFound)
+	}
+	_, err = s.getCalls(uuid.Nil)
+	if !errors.Is(err, errSessionNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errSessionNotFound)
+	}
+
+	id, err := s.start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	for _, method := range []string{"ExecuteStrategyFromFile", "ListRuns"} {
+		if err = s.record(id, method, req); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	recorded, err := s.stop(id)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if recorded != 2 {
+		t.Errorf("received '%v' expecting '%v'", recorded, 2)
+	}
+	if err = s.record(id, "GetServerInfo", req); !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	calls, err := s.getCalls(id)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(calls) != 2 || calls[0].method != "ExecuteStrategyFromFile" || calls[1].method != "ListRuns" {
+		t.Errorf("received '%v' expecting calls to ExecuteStrategyFromFile and ListRuns", calls)
+	}
+}
+
+func TestSessionsLimits(t *testing.T) {
+	t.Parallel()
+	var s sessions
+	first, err := s.start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	req := &btrpc.ExecuteStrategyFromFileRequest{StrategyFilePath: dcaConfigPath}
+	for i := 0; i < maxSessionCalls; i++ {
+		if err = s.record(first, "ExecuteStrategyFromFile", req); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	err = s.record(first, "ExecuteStrategyFromFile", req)
+	if !errors.Is(err, errSessionFull) {
+		t.Errorf("received '%v' expecting '%v'", err, errSessionFull)
+	}
+
+	for i := 0; i < maxSessions; i++ {
+		if _, err = s.start(); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	_, err = s.getCalls(first)
+	if !errors.Is(err, errSessionNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errSessionNotFound)
+	}
+	if len(s.sessions) != maxSessions || len(s.order) != maxSessions {
+		t.Errorf("received '%v' '%v' expecting '%v'", len(s.sessions), len(s.order), maxSessions)
+	}
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// sessionMetadataKey is the GRPC metadata key clients set to the ID
+	// returned by RecordSession to have their calls recorded
+	sessionMetadataKey = "backtester-session-id"
+	// maxSessions is the most sessions held, the oldest session is evicted
+	// when another is started
+	maxSessions = 100
+	// maxSessionCalls is the most calls recorded for a session
+	maxSessionCalls = 1000
+)
+
+var (
+	errSessionNotFound   = errors.New("session not found")
+	errSessionFull       = errors.New("session call limit reached")
+	errUnknownMethod     = errors.New("unknown rpc method")
+	errMethodNotReplayed = errors.New("rpc method is not replayable")
+)
+
+// replayableMethods are the run executing RPCs which sessions record. Calls
+// which change server state, such as UpdateServerConfig or ImportRegistry,
+// are never recorded so a replay cannot apply them again
+var replayableMethods = map[string]bool{
+	"ExecuteStrategyFromFile":     true,
+	"ExecuteStrategyFromConfig":   true,
+	"ExecuteStrategyFromTemplate": true,
+	"ExecuteWalkForward":          true,
+	"ExecuteStrategyAcrossPairs":  true,
+	"VerifyDeterminism":           true,
+	"PreviewStrategy":             true,
+}
+
+// sessions holds the ordered unary RPC calls recorded for each session
+type sessions struct {
+	m        sync.Mutex
+	sessions map[uuid.UUID]*session
+	// order holds session IDs from oldest to newest for eviction
+	order []uuid.UUID
+}
+
+// session is the calls recorded against a session ID, calls are only
+// appended while recording is set
+type session struct {
+	recording bool
+	calls     []recordedCall
+}
+
+// recordedCall is the method name and serialised request of a recorded call
+type recordedCall struct {
+	method  string
+	request []byte
+}
@@ -882,6 +882,12 @@ func loadLiveData(cfg *config.Config, base *gctexchange.Base) error {
 
 // ExecuteStrategy executes the strategy using the provided configs
 func ExecuteStrategy(strategyCfg *config.Config, backtesterCfg *config.BacktesterConfig) error {
+	return executeStrategy(strategyCfg, backtesterCfg, nil)
+}
+
+// executeStrategy executes the strategy using the provided configs and
+// reports the backtest's progression to any supplied hooks
+func executeStrategy(strategyCfg *config.Config, backtesterCfg *config.BacktesterConfig, hooks *runHooks) error {
 	if err := strategyCfg.Validate(); err != nil {
 		return err
 	}
@@ -893,6 +899,7 @@ func ExecuteStrategy(strategyCfg *config.Config, backtesterCfg *config.Backteste
 	if err != nil {
 		return err
 	}
+	bt.hooks = hooks
 	if strategyCfg.DataSettings.LiveData != nil {
 		go func() {
 			err = bt.RunLive()
@@ -907,6 +914,12 @@ func ExecuteStrategy(strategyCfg *config.Config, backtesterCfg *config.Backteste
 	} else {
 		bt.Run()
 	}
+	if bt.isCancelled() {
+		return errRunCancelled
+	}
+	if hooks != nil && hooks.preview {
+		return nil
+	}
 
 	err = bt.Statistic.CalculateAllResults()
 	if err != nil {
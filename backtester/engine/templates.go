@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+)
+
+// save stores the config under the template name, exposing the supplied
+// variables. Existing templates are only replaced when overwrite is set, the
+// returned bool reports whether a template was replaced
+func (t *configTemplates) save(name string, cfg *config.Config, variables map[string]string, overwrite bool) (bool, error) {
+	if cfg == nil {
+		return false, fmt.Errorf("%w config", common.ErrNilArguments)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, fmt.Errorf("%w name cannot be empty", errInvalidTemplateName)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return false, err
+	}
+	tmpl := &configTemplate{
+		variables: make(map[string]string, len(variables)),
+	}
+	err = json.Unmarshal(data, &tmpl.config)
+	if err != nil {
+		return false, err
+	}
+	for k, v := range variables {
+		if k == "" {
+			return false, fmt.Errorf("%w variable name cannot be empty", errInvalidVariablePath)
+		}
+		if _, err = lookupPath(tmpl.config, v); err != nil {
+			return false, fmt.Errorf("variable %v %w", k, err)
+		}
+		tmpl.variables[k] = v
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	_, exists := t.templates[name]
+	if exists && !overwrite {
+		return false, fmt.Errorf("%w %v", errTemplateExists, name)
+	}
+	if t.templates == nil {
+		t.templates = make(map[string]*configTemplate)
+	}
+	t.templates[name] = tmpl
+	return exists, nil
+}
+
+// render returns the config of a template with its variables set to the
+// supplied values. Values are parsed as JSON, falling back to a plain string,
+// and variables without a value keep the value they were saved with
+func (t *configTemplates) render(name string, values map[string]string) (*config.Config, error) {
+	t.m.Lock()
+	tmpl, ok := t.templates[strings.TrimSpace(name)]
+	var data []byte
+	var err error
+	if ok {
+		// copy the config so rendering cannot alter the template
+		data, err = json.Marshal(tmpl.config)
+	}
+	t.m.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %v", errTemplateNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		path, ok := tmpl.variables[k]
+		if !ok {
+			return nil, fmt.Errorf("%w %v", errUnknownVariable, k)
+		}
+		var value interface{}
+		if json.Unmarshal([]byte(v), &value) != nil {
+			value = v
+		}
+		err = setPath(doc, path, value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %v %w", k, err)
+		}
+	}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var cfg config.Config
+	err = json.Unmarshal(data, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", config.ErrInvalidConfigData, err)
+	}
+	return &cfg, nil
+}
+
+// lookupPath returns the value found at the dot separated path within a
+// generic JSON document
+func lookupPath(doc interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w path cannot be empty", errInvalidVariablePath)
+	}
+	current := doc
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("%w %v, key %v not found", errInvalidVariablePath, path, segment)
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("%w %v, index %v out of range", errInvalidVariablePath, path, segment)
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("%w %v, cannot descend into %v", errInvalidVariablePath, path, segment)
+		}
+	}
+	return current, nil
+}
+
+// setPath replaces the value found at the dot separated path within a
+// generic JSON document
+func setPath(doc interface{}, path string, value interface{}) error {
+	parent, last := doc, path
+	if idx := strings.LastIndex(path, "."); idx >= 0 {
+		var err error
+		parent, err = lookupPath(doc, path[:idx])
+		if err != nil {
+			return err
+		}
+		last = path[idx+1:]
+	}
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := node[last]; !ok {
+			return fmt.Errorf("%w %v, key %v not found", errInvalidVariablePath, path, last)
+		}
+		node[last] = value
+	case []interface{}:
+		i, err := strconv.Atoi(last)
+		if err != nil || i < 0 || i >= len(node) {
+			return fmt.Errorf("%w %v, index %v out of range", errInvalidVariablePath, path, last)
+		}
+		node[i] = value
+	default:
+		return fmt.Errorf("%w %v, cannot descend into %v", errInvalidVariablePath, path, last)
+	}
+	return nil
+}
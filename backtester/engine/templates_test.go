@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+)
+
+func TestConfigTemplates(t *testing.T) {
+	t.Parallel()
+	var tmpls configTemplates
+	_, err := tmpls.save("dca", nil, nil, false)
+	if !errors.Is(err, common.ErrNilArguments) {
+		t.Errorf("received '%v' expecting '%v'", err, common.ErrNilArguments)
+	}
+	cfg := &config.Config{
+		Nickname: "original",
+		CurrencySettings: []config.CurrencySettings{
+			{ExchangeName: "binance", BuySide: config.MinMax{MaximumTotal: decimal.NewFromInt(100)}},
+		},
+	}
+	_, err = tmpls.save(" ", cfg, nil, false)
+	if !errors.Is(err, errInvalidTemplateName) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidTemplateName)
+	}
+	for _, path := range []string{"", "bruh", "currency-settings.1.exchange-name", "nickname.value", "currency-settings.x"} {
+		_, err = tmpls.save("dca", cfg, map[string]string{"v": path}, false)
+		if !errors.Is(err, errInvalidVariablePath) {
+			t.Errorf("%v received '%v' expecting '%v'", path, err, errInvalidVariablePath)
+		}
+	}
+
+	variables := map[string]string{
+		"nickname": "nickname",
+		"maxTotal": "currency-settings.0.buy-side.maximum-total",
+	}
+	overwritten, err := tmpls.save("dca", cfg, variables, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if overwritten {
+		t.Error("expected new template not to be overwritten")
+	}
+	_, err = tmpls.save("dca", cfg, variables, false)
+	if !errors.Is(err, errTemplateExists) {
+		t.Errorf("received '%v' expecting '%v'", err, errTemplateExists)
+	}
+	overwritten, err = tmpls.save("dca", cfg, variables, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if !overwritten {
+		t.Error("expected existing template to be overwritten")
+	}
+	// changes to the source config do not alter the template
+	cfg.Nickname = "changed"
+
+	_, err = tmpls.render("bruh", nil)
+	if !errors.Is(err, errTemplateNotFound) {
+		t.Errorf("received '%v' expecting '%v'", err, errTemplateNotFound)
+	}
+	_, err = tmpls.render("dca", map[string]string{"bruh": "1"})
+	if !errors.Is(err, errUnknownVariable) {
+		t.Errorf("received '%v' expecting '%v'", err, errUnknownVariable)
+	}
+	_, err = tmpls.render("dca", map[string]string{"nickname": "[1]"})
+	if !errors.Is(err, config.ErrInvalidConfigData) {
+		t.Errorf("received '%v' expecting '%v'", err, config.ErrInvalidConfigData)
+	}
+
+	rendered, err := tmpls.render("dca", nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if rendered.Nickname != "original" || !rendered.CurrencySettings[0].BuySide.MaximumTotal.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v' expecting the saved config", rendered)
+	}
+	rendered, err = tmpls.render("dca", map[string]string{
+		"nickname": "tuned",
+		"maxTotal": "1337",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if rendered.Nickname != "tuned" {
+		t.Errorf("received '%v' expecting '%v'", rendered.Nickname, "tuned")
+	}
+	if !rendered.CurrencySettings[0].BuySide.MaximumTotal.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v' expecting '%v'", rendered.CurrencySettings[0].BuySide.MaximumTotal, 1337)
+	}
+	rendered, err = tmpls.render("dca", nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if rendered.Nickname != "original" {
+		t.Errorf("received '%v' expecting rendering not to alter the template", rendered.Nickname)
+	}
+}
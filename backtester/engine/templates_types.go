@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	errTemplateExists      = errors.New("template already exists")
+	errTemplateNotFound    = errors.New("template not found")
+	errInvalidTemplateName = errors.New("invalid template name")
+	errInvalidVariablePath = errors.New("invalid template variable path")
+	errUnknownVariable     = errors.New("unknown template variable")
+)
+
+// configTemplates holds named strategy configs which can be executed with
+// some of their fields replaced
+type configTemplates struct {
+	m         sync.Mutex
+	templates map[string]*configTemplate
+}
+
+// configTemplate is a strategy config in its generic JSON form along with
+// the variables which can be set when it is executed. Variables map a name to
+// a dot separated path of JSON keys and array indexes within the config e.g.
+// currency-settings.0.buy-side.maximum-total
+type configTemplate struct {
+	config    interface{}
+	variables map[string]string
+}
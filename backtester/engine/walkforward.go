@@ -0,0 +1,210 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// configDateRange returns the date range of a config's API or database data
+func configDateRange(cfg *config.Config) (start, end time.Time, err error) {
+	switch {
+	case cfg.DataSettings.APIData != nil:
+		return cfg.DataSettings.APIData.StartDate, cfg.DataSettings.APIData.EndDate, nil
+	case cfg.DataSettings.DatabaseData != nil:
+		return cfg.DataSettings.DatabaseData.StartDate, cfg.DataSettings.DatabaseData.EndDate, nil
+	}
+	return time.Time{}, time.Time{}, errNoDateRange
+}
+
+// withDateRange returns a copy of the config using the supplied date range and
+// custom settings in place of its own
+func withDateRange(cfg *config.Config, start, end time.Time, settings map[string]interface{}) *config.Config {
+	c := *cfg
+	switch {
+	case c.DataSettings.APIData != nil:
+		apiData := *c.DataSettings.APIData
+		apiData.StartDate, apiData.EndDate, apiData.InclusiveEndDate = start, end, false
+		c.DataSettings.APIData = &apiData
+	case c.DataSettings.DatabaseData != nil:
+		dbData := *c.DataSettings.DatabaseData
+		dbData.StartDate, dbData.EndDate, dbData.InclusiveEndDate = start, end, false
+		c.DataSettings.DatabaseData = &dbData
+	}
+	c.StrategySettings.CustomSettings = make(map[string]interface{}, len(cfg.StrategySettings.CustomSettings)+len(settings))
+	for k, v := range cfg.StrategySettings.CustomSettings {
+		c.StrategySettings.CustomSettings[k] = v
+	}
+	for k, v := range settings {
+		c.StrategySettings.CustomSettings[k] = v
+	}
+	return &c
+}
+
+// splitWalkForwardWindows splits the date range into consecutive windows of
+// equal length, each divided into an in sample and out of sample period.
+// Window boundaries are aligned to the interval
+func splitWalkForwardWindows(start, end time.Time, interval time.Duration, windows int, inSampleRatio float64) ([]walkForwardWindow, error) {
+	if windows <= 0 {
+		return nil, fmt.Errorf("%w, at least one window is required", errWindowTooSmall)
+	}
+	if inSampleRatio <= 0 || inSampleRatio >= 1 {
+		return nil, fmt.Errorf("%w, in sample ratio %v must be between 0 and 1", errWindowTooSmall, inSampleRatio)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w interval", common.ErrNilArguments)
+	}
+	length := end.Sub(start) / time.Duration(windows) / interval * interval
+	if length < interval*2 {
+		return nil, fmt.Errorf("%w, %v windows of %v between %v and %v", errWindowTooSmall, windows, interval, start, end)
+	}
+	inSample := time.Duration(float64(length)*inSampleRatio) / interval * interval
+	if inSample < interval {
+		inSample = interval
+	}
+	if inSample > length-interval {
+		inSample = length - interval
+	}
+	resp := make([]walkForwardWindow, windows)
+	for i := range resp {
+		windowStart := start.Add(length * time.Duration(i))
+		resp[i] = walkForwardWindow{
+			inSampleStart:    windowStart,
+			inSampleEnd:      windowStart.Add(inSample),
+			outOfSampleStart: windowStart.Add(inSample),
+			outOfSampleEnd:   windowStart.Add(length),
+		}
+	}
+	return resp, nil
+}
+
+// parameterCombinations returns every combination of the parameter values,
+// parsing numeric values as float64 in the same way as custom settings
+func parameterCombinations(params []*btrpc.WalkForwardParameter) ([]map[string]interface{}, error) {
+	combinations := []map[string]interface{}{{}}
+	for i := range params {
+		if params[i] == nil || len(params[i].Values) == 0 {
+			continue
+		}
+		if len(combinations)*len(params[i].Values) > maxWalkForwardCombinations {
+			return nil, fmt.Errorf("%w, limit is %v", errTooManyParameters, maxWalkForwardCombinations)
+		}
+		next := make([]map[string]interface{}, 0, len(combinations)*len(params[i].Values))
+		for _, combination := range combinations {
+			for j := range params[i].Values {
+				settings := customSettingsToMap([]*btrpc.CustomSettings{{
+					KeyField: params[i].Key,
+					KeyValue: params[i].Values[j],
+				}})
+				for k, v := range combination {
+					settings[k] = v
+				}
+				next = append(next, settings)
+			}
+		}
+		combinations = next
+	}
+	return combinations, nil
+}
+
+// record updates the holdings value of an exchange, asset and pair. Values
+// are summed in key order so repeated backtests produce identical totals
+func (e *equityTracker) record(key string, t time.Time, value decimal.Decimal) {
+	if e.latest == nil {
+		e.latest = make(map[string]float64)
+		e.firstTime = t
+	}
+	if _, ok := e.latest[key]; !ok {
+		e.keys = append(e.keys, key)
+		sort.Strings(e.keys)
+	}
+	e.latest[key] = value.InexactFloat64()
+	var total float64
+	for i := range e.keys {
+		total += e.latest[e.keys[i]]
+	}
+	if !t.After(e.firstTime) {
+		e.first = total
+	}
+	e.last = total
+}
+
+// returnPercent returns the percentage change of the total equity from the
+// first timestamp to the last
+func (e *equityTracker) returnPercent() float64 {
+	if e.first == 0 {
+		return 0
+	}
+	return (e.last - e.first) / e.first * 100
+}
+
+// walkForward optimises the settings of each window over its in sample period
+// and evaluates the best settings over its out of sample period. progress is
+// called after every backtest executed
+func walkForward(cfg *config.Config, btCfg *config.BacktesterConfig, executor func(*config.Config, *config.BacktesterConfig, *runHooks) error, windows []walkForwardWindow, combinations []map[string]interface{}, cancel <-chan struct{}, progress func()) ([]walkForwardResult, error) {
+	backtest := func(start, end time.Time, settings map[string]interface{}) (float64, error) {
+		var tracker equityTracker
+		err := executor(withDateRange(cfg, start, end, settings), btCfg, &runHooks{
+			equity:  tracker.record,
+			preview: true,
+			cancel:  cancel,
+		})
+		if err != nil {
+			return 0, err
+		}
+		progress()
+		return tracker.returnPercent(), nil
+	}
+	results := make([]walkForwardResult, len(windows))
+	for i := range windows {
+		results[i].window = windows[i]
+		for j := range combinations {
+			r, err := backtest(windows[i].inSampleStart, windows[i].inSampleEnd, combinations[j])
+			if err != nil {
+				return nil, fmt.Errorf("window %v in sample: %w", i+1, err)
+			}
+			if j == 0 || r > results[i].inSampleReturn {
+				results[i].inSampleReturn = r
+				results[i].settings = combinations[j]
+			}
+		}
+		r, err := backtest(windows[i].outOfSampleStart, windows[i].outOfSampleEnd, results[i].settings)
+		if err != nil {
+			return nil, fmt.Errorf("window %v out of sample: %w", i+1, err)
+		}
+		results[i].outOfSampleReturn = r
+	}
+	return results, nil
+}
+
+// toRPC converts the walk forward result to its GRPC representation
+func (w *walkForwardResult) toRPC(window int) *btrpc.WalkForwardWindow {
+	keys := make([]string, 0, len(w.settings))
+	for k := range w.settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	settings := make([]*btrpc.CustomSettings, len(keys))
+	for i := range keys {
+		settings[i] = &btrpc.CustomSettings{
+			KeyField: keys[i],
+			KeyValue: fmt.Sprint(w.settings[keys[i]]),
+		}
+	}
+	return &btrpc.WalkForwardWindow{
+		Window:                   uint64(window),
+		InSampleStart:            timestamppb.New(w.window.inSampleStart),
+		InSampleEnd:              timestamppb.New(w.window.inSampleEnd),
+		OutOfSampleStart:         timestamppb.New(w.window.outOfSampleStart),
+		OutOfSampleEnd:           timestamppb.New(w.window.outOfSampleEnd),
+		BestSettings:             settings,
+		InSampleReturnPercent:    w.inSampleReturn,
+		OutOfSampleReturnPercent: w.outOfSampleReturn,
+	}
+}
@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/btrpc"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+)
+
+func TestSplitWalkForwardWindows(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 21)
+	for _, tc := range []struct {
+		windows int
+		ratio   float64
+	}{
+		{windows: 0, ratio: 0.5},
+		{windows: 2, ratio: 0},
+		{windows: 2, ratio: 1},
+		{windows: 11, ratio: 0.5},
+	} {
+		_, err := splitWalkForwardWindows(start, end, time.Hour, tc.windows, tc.ratio)
+		if !errors.Is(err, errWindowTooSmall) {
+			t.Errorf("%v windows %v ratio received '%v' expecting '%v'", tc.windows, tc.ratio, err, errWindowTooSmall)
+		}
+	}
+
+	windows, err := splitWalkForwardWindows(start, end, time.Hour, 2, 0.7)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(windows) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(windows), 2)
+	}
+	// 21 hours split in two gives 10 hour windows with a 7 hour in sample
+	for i, expected := range []walkForwardWindow{
+		{
+			inSampleStart:    start,
+			inSampleEnd:      start.Add(time.Hour * 7),
+			outOfSampleStart: start.Add(time.Hour * 7),
+			outOfSampleEnd:   start.Add(time.Hour * 10),
+		},
+		{
+			inSampleStart:    start.Add(time.Hour * 10),
+			inSampleEnd:      start.Add(time.Hour * 17),
+			outOfSampleStart: start.Add(time.Hour * 17),
+			outOfSampleEnd:   start.Add(time.Hour * 20),
+		},
+	} {
+		if windows[i] != expected {
+			t.Errorf("received '%+v' expecting '%+v'", windows[i], expected)
+		}
+	}
+
+	// tiny ratios still leave an interval in each period
+	windows, err = splitWalkForwardWindows(start, end, time.Hour, 2, 0.01)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if windows[0].inSampleEnd.Sub(windows[0].inSampleStart) != time.Hour {
+		t.Errorf("received '%v' expecting '%v'", windows[0].inSampleEnd.Sub(windows[0].inSampleStart), time.Hour)
+	}
+}
+
+func TestParameterCombinations(t *testing.T) {
+	t.Parallel()
+	combinations, err := parameterCombinations(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(combinations) != 1 || len(combinations[0]) != 0 {
+		t.Errorf("received '%v' expecting a single empty combination", combinations)
+	}
+
+	combinations, err = parameterCombinations([]*btrpc.WalkForwardParameter{
+		{Key: "rsi-low", Values: []string{"20", "30"}},
+		{Key: "mode", Values: []string{"fast", "slow", "steady"}},
+		{Key: "empty"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(combinations) != 6 {
+		t.Fatalf("received '%v' expecting '%v'", len(combinations), 6)
+	}
+	if combinations[0]["rsi-low"] != float64(20) || combinations[0]["mode"] != "fast" {
+		t.Errorf("received '%v' expecting rsi-low 20 and mode fast", combinations[0])
+	}
+	if combinations[5]["rsi-low"] != float64(30) || combinations[5]["mode"] != "steady" {
+		t.Errorf("received '%v' expecting rsi-low 30 and mode steady", combinations[5])
+	}
+
+	values := make([]string, 11)
+	for i := range values {
+		values[i] = "1"
+	}
+	_, err = parameterCombinations([]*btrpc.WalkForwardParameter{
+		{Key: "a", Values: values},
+		{Key: "b", Values: values},
+	})
+	if !errors.Is(err, errTooManyParameters) {
+		t.Errorf("received '%v' expecting '%v'", err, errTooManyParameters)
+	}
+}
+
+func TestEquityTracker(t *testing.T) {
+	t.Parallel()
+	var e equityTracker
+	if e.returnPercent() != 0 {
+		t.Errorf("received '%v' expecting '%v'", e.returnPercent(), 0)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.record("btc", start, decimal.NewFromInt(60))
+	e.record("eth", start, decimal.NewFromInt(40))
+	e.record("btc", start.Add(time.Hour), decimal.NewFromInt(80))
+	e.record("eth", start.Add(time.Hour), decimal.NewFromInt(30))
+	if e.returnPercent() != 10 {
+		t.Errorf("received '%v' expecting '%v'", e.returnPercent(), 10)
+	}
+}
+
+func TestWithDateRange(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		StrategySettings: config.StrategySettings{CustomSettings: map[string]interface{}{"a": 1.0}},
+		DataSettings: config.DataSettings{
+			APIData: &config.APIData{StartDate: start, EndDate: start.Add(time.Hour * 10), InclusiveEndDate: true},
+		},
+	}
+	c := withDateRange(cfg, start.Add(time.Hour), start.Add(time.Hour*2), map[string]interface{}{"b": 2.0})
+	if !c.DataSettings.APIData.StartDate.Equal(start.Add(time.Hour)) ||
+		!c.DataSettings.APIData.EndDate.Equal(start.Add(time.Hour*2)) ||
+		c.DataSettings.APIData.InclusiveEndDate {
+		t.Errorf("received '%+v' expecting the new date range", c.DataSettings.APIData)
+	}
+	if len(c.StrategySettings.CustomSettings) != 2 {
+		t.Errorf("received '%v' expecting '%v'", len(c.StrategySettings.CustomSettings), 2)
+	}
+	if !cfg.DataSettings.APIData.EndDate.Equal(start.Add(time.Hour*10)) || len(cfg.StrategySettings.CustomSettings) != 1 {
+		t.Error("expected original config to be unchanged")
+	}
+}
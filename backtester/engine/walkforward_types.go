@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"errors"
+	"time"
+)
+
+// maxWalkForwardCombinations is the most parameter combinations which can be
+// optimised over in each walk forward window
+const maxWalkForwardCombinations = 100
+
+var (
+	errNoDateRange       = errors.New("config has no api or database date range")
+	errWindowTooSmall    = errors.New("walk forward window too small")
+	errTooManyParameters = errors.New("too many walk forward parameter combinations")
+)
+
+// walkForwardWindow is a slice of a date range which is optimised over its in
+// sample period and evaluated over its out of sample period
+type walkForwardWindow struct {
+	inSampleStart    time.Time
+	inSampleEnd      time.Time
+	outOfSampleStart time.Time
+	outOfSampleEnd   time.Time
+}
+
+// walkForwardResult holds the best settings found for a window and the
+// returns they achieved in and out of sample
+type walkForwardResult struct {
+	window            walkForwardWindow
+	settings          map[string]interface{}
+	inSampleReturn    float64
+	outOfSampleReturn float64
+}
+
+// equityTracker sums the holdings values reported by a backtest into the
+// total equity at each timestamp
+type equityTracker struct {
+	latest    map[string]float64
+	keys      []string
+	first     float64
+	last      float64
+	firstTime time.Time
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// validateWebhookURL ensures the webhook URL is an absolute HTTP URL
+func validateWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("%w %v", errInvalidWebhookURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w '%v' must be an absolute http or https url", errInvalidWebhookURL, webhookURL)
+	}
+	return nil
+}
+
+// register adds a webhook URL for the run. A nil run ID registers the URL for
+// all runs
+func (w *completionWebhooks) register(runID uuid.UUID, webhookURL string) error {
+	err := validateWebhookURL(webhookURL)
+	if err != nil {
+		return err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if runID.IsNil() {
+		w.global = append(w.global, webhookURL)
+		return nil
+	}
+	if w.runs == nil {
+		w.runs = make(map[uuid.UUID][]string)
+	}
+	w.runs[runID] = append(w.runs[runID], webhookURL)
+	return nil
+}
+
+// take returns the webhook URLs to notify when the run finishes, removing
+// any which were registered specifically for the run
+func (w *completionWebhooks) take(runID uuid.UUID) []string {
+	w.m.Lock()
+	defer w.m.Unlock()
+	urls := make([]string, 0, len(w.global)+len(w.runs[runID]))
+	urls = append(urls, w.global...)
+	urls = append(urls, w.runs[runID]...)
+	delete(w.runs, runID)
+	return urls
+}
+
+// remove unregisters a webhook URL from the run, returning whether it was
+// still registered and had not yet been taken
+func (w *completionWebhooks) remove(runID uuid.UUID, webhookURL string) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	urls := w.runs[runID]
+	for i := range urls {
+		if urls[i] != webhookURL {
+			continue
+		}
+		urls = append(urls[:i], urls[i+1:]...)
+		if len(urls) == 0 {
+			delete(w.runs, runID)
+		} else {
+			w.runs[runID] = urls
+		}
+		return true
+	}
+	return false
+}
+
+// notify posts the run summary to each of the webhook URLs. Each webhook is
+// sent in its own routine and retried with an exponential backoff on failure
+func (w *completionWebhooks) notify(run *Run, urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+	payload, err := protojson.Marshal(run.toRPCSummary())
+	if err != nil {
+		log.Errorf(common.Backtester, "unable to marshal run %v summary for webhooks: %v", run.ID, err)
+		return
+	}
+	for i := range urls {
+		go func(webhookURL string) {
+			if sendErr := w.send(webhookURL, payload); sendErr != nil {
+				log.Errorf(common.Backtester, "run %v completion webhook %v: %v", run.ID, webhookURL, sendErr)
+			}
+		}(urls[i])
+	}
+}
+
+// send posts the payload to the webhook URL, retrying with an exponential
+// backoff until it succeeds or the maximum attempts are reached
+func (w *completionWebhooks) send(webhookURL string, payload []byte) error {
+	client := w.client
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	maxAttempts := w.maxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	backoff := w.initialBackoff
+	if backoff <= 0 {
+		backoff = defaultWebhookInitialBackoff
+	}
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = w.post(client, webhookURL, payload)
+		if err == nil {
+			return nil
+		}
+		if attempt < maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("failed after %d attempts: %w", maxAttempts, err)
+}
+
+// post sends a single webhook request, treating non 2XX responses as errors
+func (w *completionWebhooks) post(client *http.Client, webhookURL string, payload []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %v", errWebhookStatus, resp.Status)
+	}
+	return nil
+}
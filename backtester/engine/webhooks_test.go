@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		url      string
+		expected error
+	}{
+		{url: "https://example.com/hook", expected: nil},
+		{url: "http://localhost:1337", expected: nil},
+		{url: "", expected: errInvalidWebhookURL},
+		{url: "example.com/hook", expected: errInvalidWebhookURL},
+		{url: "ftp://example.com", expected: errInvalidWebhookURL},
+		{url: "http://[::1", expected: errInvalidWebhookURL},
+	} {
+		if err := validateWebhookURL(tc.url); !errors.Is(err, tc.expected) {
+			t.Errorf("%v received '%v' expecting '%v'", tc.url, err, tc.expected)
+		}
+	}
+}
+
+func TestCompletionWebhooksRegister(t *testing.T) {
+	t.Parallel()
+	w := &completionWebhooks{}
+	err := w.register(uuid.Nil, "bad")
+	if !errors.Is(err, errInvalidWebhookURL) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidWebhookURL)
+	}
+	err = w.register(uuid.Nil, "https://global.com")
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	id, err := uuid.NewV4()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	err = w.register(id, "https://run.com")
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+
+	urls := w.take(id)
+	if len(urls) != 2 || urls[0] != "https://global.com" || urls[1] != "https://run.com" {
+		t.Errorf("received '%v' expecting '%v'", urls, []string{"https://global.com", "https://run.com"})
+	}
+	// run webhooks are only notified once
+	urls = w.take(id)
+	if len(urls) != 1 || urls[0] != "https://global.com" {
+		t.Errorf("received '%v' expecting '%v'", urls, []string{"https://global.com"})
+	}
+}
+
+func TestCompletionWebhooksRemove(t *testing.T) {
+	t.Parallel()
+	w := &completionWebhooks{}
+	id, err := uuid.NewV4()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expecting '%v'", err, nil)
+	}
+	if w.remove(id, "https://run.com") {
+		t.Error("expected an unregistered url to not be removed")
+	}
+	for _, u := range []string{"https://run.com", "https://other.com"} {
+		err = w.register(id, u)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expecting '%v'", err, nil)
+		}
+	}
+	if !w.remove(id, "https://run.com") {
+		t.Error("expected a registered url to be removed")
+	}
+	if urls := w.take(id); len(urls) != 1 || urls[0] != "https://other.com" {
+		t.Errorf("received '%v' expecting '%v'", urls, []string{"https://other.com"})
+	}
+	// a url taken by a finished run cannot be removed and notified again
+	if w.remove(id, "https://other.com") {
+		t.Error("expected a taken url to not be removed")
+	}
+	if _, ok := w.runs[id]; ok {
+		t.Error("expected no webhooks to remain for the run")
+	}
+}
+
+func TestCompletionWebhooksSend(t *testing.T) {
+	t.Parallel()
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil || string(body) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	w := &completionWebhooks{initialBackoff: time.Millisecond, maxAttempts: 3}
+	err := w.send(srv.URL, []byte("payload"))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if received := atomic.LoadInt32(&attempts); received != 3 {
+		t.Errorf("received '%v' expecting '%v'", received, 3)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	w.maxAttempts = 2
+	err = w.send(srv.URL, []byte("payload"))
+	if !errors.Is(err, errWebhookStatus) {
+		t.Errorf("received '%v' expecting '%v'", err, errWebhookStatus)
+	}
+}
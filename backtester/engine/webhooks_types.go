@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	defaultWebhookMaxAttempts    = 5
+	defaultWebhookInitialBackoff = time.Second
+	defaultWebhookTimeout        = time.Second * 10
+)
+
+var (
+	errInvalidWebhookURL = errors.New("invalid webhook url")
+	errWebhookStatus     = errors.New("unexpected webhook response status")
+)
+
+// completionWebhooks holds the URLs which are sent a run's summary when it
+// finishes. Global URLs are notified for every run, while run URLs are only
+// notified for the run they are registered to
+type completionWebhooks struct {
+	m      sync.Mutex
+	global []string
+	runs   map[uuid.UUID][]string
+	// client, maxAttempts and initialBackoff use defaults when unset
+	client         *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+}
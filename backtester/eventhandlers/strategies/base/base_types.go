@@ -2,6 +2,9 @@ package base
 
 import "errors"
 
+// ParameterTypeNumber is a custom setting which takes a numeric value
+const ParameterTypeNumber = "number"
+
 var (
 	// ErrCustomSettingsUnsupported used when custom settings are found in the strategy config when they shouldn't be
 	ErrCustomSettingsUnsupported = errors.New("custom settings not supported")
@@ -17,3 +20,13 @@ var (
 	// ErrTooMuchBadData used when there is too much missing data
 	ErrTooMuchBadData = errors.New("backtesting cannot continue as there is too much invalid data. Please review your dataset")
 )
+
+// Parameter describes a custom setting accepted by a strategy
+type Parameter struct {
+	Name        string
+	Type        string
+	Default     interface{}
+	Description string
+	// Positive is set when numeric values must be greater than zero
+	Positive bool
+}
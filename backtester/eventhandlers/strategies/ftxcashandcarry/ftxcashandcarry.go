@@ -14,6 +14,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -27,6 +28,18 @@ func (s *Strategy) Description() string {
 	return description
 }
 
+// RequiredDataTypes returns the data types the strategy can operate on.
+// Comparing spot and futures prices requires candle data for both
+func (s *Strategy) RequiredDataTypes() []string {
+	return []string{common.CandleStr}
+}
+
+// SupportedIntervals returns the intervals the strategy can operate on, all
+// intervals are supported
+func (s *Strategy) SupportedIntervals() []kline.Interval {
+	return nil
+}
+
 // OnSignal handles a data event and returns what action the strategy believes should occur
 // For rsi, this means returning a buy signal when rsi is at or below a certain level, and a
 // sell signal when it is at or above a certain level
@@ -221,6 +234,14 @@ func (s *Strategy) SetCustomSettings(customSettings map[string]interface{}) erro
 	return nil
 }
 
+// Parameters describes the custom settings of the strategy
+func (s *Strategy) Parameters() []base.Parameter {
+	return []base.Parameter{
+		{Name: openShortDistancePercentageString, Type: base.ParameterTypeNumber, Default: 0.0, Description: "percentage difference between the spot and futures price required to open a short", Positive: true},
+		{Name: closeShortDistancePercentageString, Type: base.ParameterTypeNumber, Default: 0.0, Description: "percentage difference between the spot and futures price at which the short is closed", Positive: true},
+	}
+}
+
 // SetDefaults sets default values for overridable custom settings
 func (s *Strategy) SetDefaults() {
 	s.openShortDistancePercentage = decimal.Zero
@@ -37,6 +37,17 @@ func TestDescription(t *testing.T) {
 	}
 }
 
+func TestRequiredDataTypes(t *testing.T) {
+	t.Parallel()
+	d := Strategy{}
+	if dataTypes := d.RequiredDataTypes(); len(dataTypes) != 1 || dataTypes[0] != common.CandleStr {
+		t.Errorf("received '%v' expecting '%v'", dataTypes, []string{common.CandleStr})
+	}
+	if intervals := d.SupportedIntervals(); len(intervals) != 0 {
+		t.Errorf("received '%v' expecting '%v'", intervals, 0)
+	}
+}
+
 func TestSupportsSimultaneousProcessing(t *testing.T) {
 	t.Parallel()
 	s := Strategy{}
@@ -414,3 +425,20 @@ func TestOnSimultaneousSignals(t *testing.T) {
 		t.Errorf("received '%v' expected '%v", resp[0].GetDirection(), gctorder.DoNothing)
 	}
 }
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+	s := Strategy{}
+	params := s.Parameters()
+	if len(params) != 2 {
+		t.Fatalf("received '%v' expecting '%v'", len(params), 2)
+	}
+	settings := make(map[string]interface{}, len(params))
+	for i := range params {
+		settings[params[i].Name] = 1.0
+	}
+	err := s.SetCustomSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
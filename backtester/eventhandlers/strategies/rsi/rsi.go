@@ -147,6 +147,21 @@ func (s *Strategy) SetCustomSettings(customSettings map[string]interface{}) erro
 	return nil
 }
 
+// SupportsLiveSettingsUpdates returns true as the RSI levels and period can be
+// safely changed while a backtest is running
+func (s *Strategy) SupportsLiveSettingsUpdates() bool {
+	return true
+}
+
+// Parameters describes the custom settings of the strategy
+func (s *Strategy) Parameters() []base.Parameter {
+	return []base.Parameter{
+		{Name: rsiHighKey, Type: base.ParameterTypeNumber, Default: 70.0, Description: "RSI value at or above which a sell signal is raised", Positive: true},
+		{Name: rsiLowKey, Type: base.ParameterTypeNumber, Default: 30.0, Description: "RSI value at or below which a buy signal is raised", Positive: true},
+		{Name: rsiPeriodKey, Type: base.ParameterTypeNumber, Default: 14.0, Description: "number of candles used to calculate the RSI", Positive: true},
+	}
+}
+
 // SetDefaults sets the custom settings to their default values
 func (s *Strategy) SetDefaults() {
 	s.rsiHigh = decimal.NewFromInt(70)
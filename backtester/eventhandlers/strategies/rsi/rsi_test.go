@@ -36,6 +36,14 @@ func TestSupportsSimultaneousProcessing(t *testing.T) {
 	}
 }
 
+func TestSupportsLiveSettingsUpdates(t *testing.T) {
+	t.Parallel()
+	s := Strategy{}
+	if !s.SupportsLiveSettingsUpdates() {
+		t.Error("expected true")
+	}
+}
+
 func TestSetCustomSettings(t *testing.T) {
 	t.Parallel()
 	s := Strategy{}
@@ -219,3 +227,28 @@ func TestSetDefaults(t *testing.T) {
 		t.Error("expected 14")
 	}
 }
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+	s := Strategy{}
+	params := s.Parameters()
+	if len(params) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(params), 3)
+	}
+	settings := make(map[string]interface{}, len(params))
+	for i := range params {
+		settings[params[i].Name] = params[i].Default
+	}
+	err := s.SetCustomSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := Strategy{}
+	defaults.SetDefaults()
+	if !s.rsiHigh.Equal(defaults.rsiHigh) ||
+		!s.rsiLow.Equal(defaults.rsiLow) ||
+		!s.rsiPeriod.Equal(defaults.rsiPeriod) {
+		t.Errorf("received '%v %v %v' expecting parameter defaults to match '%v %v %v'",
+			s.rsiHigh, s.rsiLow, s.rsiPeriod, defaults.rsiHigh, defaults.rsiLow, defaults.rsiPeriod)
+	}
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 )
 
 // ErrStrategyAlreadyExists returned when a strategy matches the same name
@@ -27,3 +29,22 @@ type Handler interface {
 	SetCustomSettings(map[string]interface{}) error
 	SetDefaults()
 }
+
+// LiveSettingsUpdater is implemented by strategies which support having their
+// custom settings updated while a backtest is running
+type LiveSettingsUpdater interface {
+	SupportsLiveSettingsUpdates() bool
+}
+
+// ParameterDescriber is implemented by strategies which accept custom
+// settings, describing each setting so they can be configured
+type ParameterDescriber interface {
+	Parameters() []base.Parameter
+}
+
+// DataRequirementDescriber is implemented by strategies which can only operate
+// on some data. An empty list places no restriction on that requirement
+type DataRequirementDescriber interface {
+	RequiredDataTypes() []string
+	SupportedIntervals() []kline.Interval
+}
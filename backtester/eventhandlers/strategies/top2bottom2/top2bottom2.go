@@ -221,6 +221,15 @@ func (s *Strategy) SetCustomSettings(customSettings map[string]interface{}) erro
 	return nil
 }
 
+// Parameters describes the custom settings of the strategy
+func (s *Strategy) Parameters() []base.Parameter {
+	return []base.Parameter{
+		{Name: mfiHighKey, Type: base.ParameterTypeNumber, Default: 70.0, Description: "MFI value at or above which pairs are considered for selling", Positive: true},
+		{Name: mfiLowKey, Type: base.ParameterTypeNumber, Default: 30.0, Description: "MFI value at or below which pairs are considered for buying", Positive: true},
+		{Name: mfiPeriodKey, Type: base.ParameterTypeNumber, Default: 14.0, Description: "number of candles used to calculate the MFI", Positive: true},
+	}
+}
+
 // SetDefaults sets the custom settings to their default values
 func (s *Strategy) SetDefaults() {
 	s.mfiHigh = decimal.NewFromInt(70)
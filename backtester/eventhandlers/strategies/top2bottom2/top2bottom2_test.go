@@ -233,3 +233,28 @@ func TestSelectTopAndBottomPerformers(t *testing.T) {
 		}
 	}
 }
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+	s := Strategy{}
+	params := s.Parameters()
+	if len(params) != 3 {
+		t.Fatalf("received '%v' expecting '%v'", len(params), 3)
+	}
+	settings := make(map[string]interface{}, len(params))
+	for i := range params {
+		settings[params[i].Name] = params[i].Default
+	}
+	err := s.SetCustomSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := Strategy{}
+	defaults.SetDefaults()
+	if !s.mfiHigh.Equal(defaults.mfiHigh) ||
+		!s.mfiLow.Equal(defaults.mfiLow) ||
+		!s.mfiPeriod.Equal(defaults.mfiPeriod) {
+		t.Errorf("received '%v %v %v' expecting parameter defaults to match '%v %v %v'",
+			s.mfiHigh, s.mfiLow, s.mfiPeriod, defaults.mfiHigh, defaults.mfiLow, defaults.mfiPeriod)
+	}
+}
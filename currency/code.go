@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
@@ -25,6 +28,20 @@ var (
 	errItemIsNil   = errors.New("item is nil")
 	errItemIsEmpty = errors.New("item is empty")
 	errRoleUnset   = errors.New("role unset")
+
+	// homoglyphs maps common non-latin characters which are visually
+	// indistinguishable from latin characters to their ASCII equivalents
+	homoglyphs = map[rune]rune{
+		// Cyrillic
+		'А': 'A', 'В': 'B', 'С': 'C', 'Е': 'E', 'Н': 'H', 'І': 'I', 'Ј': 'J',
+		'К': 'K', 'М': 'M', 'О': 'O', 'Р': 'P', 'Ѕ': 'S', 'Т': 'T', 'Х': 'X',
+		'У': 'Y', 'а': 'a', 'с': 'c', 'е': 'e', 'і': 'i', 'ј': 'j', 'о': 'o',
+		'р': 'p', 'ѕ': 's', 'х': 'x', 'у': 'y',
+		// Greek
+		'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+		'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+		'ο': 'o', 'ν': 'v',
+	}
 )
 
 // String implements the stringer interface and returns a string representation
@@ -320,6 +337,83 @@ func (c Code) Equal(check Code) bool {
 	return c.Item == check.Item
 }
 
+// NormalizeUnicode returns the code with common homoglyphs, such as a Cyrillic
+// 'С', mapped to their ASCII equivalents and combining marks stripped so that
+// visually identical codes match. Compatibility characters such as fullwidth
+// letters are also mapped to their ASCII form.
+func (c Code) NormalizeUnicode() Code {
+	if c.Item == nil {
+		return c
+	}
+	isASCII := true
+	for x := 0; x < len(c.Item.Symbol); x++ {
+		if c.Item.Symbol[x] >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+	}
+	if isASCII {
+		return c
+	}
+	var sb strings.Builder
+	for _, r := range norm.NFKD.String(c.Item.Symbol) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if ascii, ok := homoglyphs[r]; ok {
+			r = ascii
+		}
+		sb.WriteRune(r)
+	}
+	normalized := NewCode(sb.String())
+	normalized.UpperCase = c.UpperCase
+	return normalized
+}
+
+// StripContractSuffix removes the first matching exchange specific contract
+// suffix e.g. ".P" or "-SWAP" from the currency code. Suffixes are matched
+// case insensitively. The returned bool reports whether a suffix was removed
+func (c Code) StripContractSuffix(suffixes []string) (Code, bool) {
+	if c.Item == nil {
+		return c, false
+	}
+	symbol := c.Item.Symbol
+	for x := range suffixes {
+		if suffixes[x] == "" || len(symbol) <= len(suffixes[x]) {
+			continue
+		}
+		cut := len(symbol) - len(suffixes[x])
+		if !strings.EqualFold(symbol[cut:], suffixes[x]) {
+			continue
+		}
+		stripped := NewCode(symbol[:cut])
+		stripped.UpperCase = c.UpperCase
+		return stripped, true
+	}
+	return c, false
+}
+
+// BaseAsset returns the underlying asset of a bridged or wrapped token by
+// removing a single letter chain suffix e.g. USDT.e returns USDT. Only one
+// trailing dot followed by a single ASCII letter is removed, any other code is
+// returned unchanged
+func (c Code) BaseAsset() Code {
+	if c.Item == nil {
+		return c
+	}
+	symbol := c.Item.Symbol
+	cut := len(symbol) - 2
+	if cut < 1 || symbol[cut] != '.' || strings.IndexByte(symbol[:cut], '.') != -1 {
+		return c
+	}
+	if suffix := symbol[cut+1]; (suffix < 'a' || suffix > 'z') && (suffix < 'A' || suffix > 'Z') {
+		return c
+	}
+	underlying := NewCode(symbol[:cut])
+	underlying.UpperCase = c.UpperCase
+	return underlying
+}
+
 // IsFiatCurrency checks if the currency passed is an enabled fiat currency
 func (c Code) IsFiatCurrency() bool {
 	return c.Item != nil && c.Item.Role == Fiat
@@ -635,6 +635,30 @@ func TestItemString(t *testing.T) {
 	}
 }
 
+func TestNormalizeUnicode(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		code     Code
+		expected Code
+	}{
+		{code: BTC, expected: BTC},
+		{code: NewCode("\u0421AKE"), expected: NewCode("CAKE")},                 // Cyrillic С
+		{code: NewCode("\u0395\u03A4\u0397"), expected: ETH},                    // Greek ΕΤΗ
+		{code: NewCode("U\u0301SDT"), expected: USDT},                           // combining acute accent
+		{code: NewCode("\u00DASDT"), expected: USDT},                            // precomposed Ú
+		{code: NewCode("\uFF22\uFF34\uFF23").Upper(), expected: BTC},            // fullwidth ＢＴＣ
+		{code: NewCode("\u0441ake").Lower(), expected: NewCode("cake").Lower()}, // Cyrillic с
+	} {
+		received := tc.code.NormalizeUnicode()
+		if !received.Equal(tc.expected) {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.code, received, tc.expected)
+		}
+		if received.String() != tc.expected.String() {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.code, received.String(), tc.expected.String())
+		}
+	}
+}
+
 // 28848025	        40.84 ns/op	       8 B/op	       1 allocs/op // Current
 //
 //	546290	      2192 ns/op	       8 B/op	       1 allocs/op // Previous
@@ -644,3 +668,54 @@ func BenchmarkNewCode(b *testing.B) {
 		_ = NewCode("someCode")
 	}
 }
+
+func TestStripContractSuffix(t *testing.T) {
+	t.Parallel()
+	suffixes := []string{".P", "-SWAP"}
+	for _, tc := range []struct {
+		code     Code
+		expected Code
+		stripped bool
+	}{
+		{code: NewCode("BTCUSDT.P"), expected: NewCode("BTCUSDT"), stripped: true},
+		{code: NewCode("BTC-USDT-SWAP"), expected: NewCode("BTC-USDT"), stripped: true},
+		{code: NewCode("btc-usdt-swap"), expected: NewCode("btc-usdt"), stripped: true},
+		{code: NewCode("BTCUSDT"), expected: NewCode("BTCUSDT"), stripped: false},
+		{code: NewCode("-SWAP"), expected: NewCode("-SWAP"), stripped: false},
+		{code: EMPTYCODE, expected: EMPTYCODE, stripped: false},
+	} {
+		received, stripped := tc.code.StripContractSuffix(suffixes)
+		if stripped != tc.stripped {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.code, stripped, tc.stripped)
+		}
+		if received.String() != tc.expected.String() {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.code, received, tc.expected)
+		}
+	}
+
+	received, stripped := NewCode("BTCUSDT.P").StripContractSuffix(nil)
+	if stripped || received.String() != "BTCUSDT.P" {
+		t.Errorf("received: '%v' but expected: '%v'", received, "BTCUSDT.P")
+	}
+}
+
+func TestBaseAsset(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		code     Code
+		expected string
+	}{
+		{code: NewCode("USDT.e"), expected: "USDT"},
+		{code: NewCode("usdc.e"), expected: "usdc"},
+		{code: NewCode("USDT"), expected: "USDT"},
+		{code: NewCode("USDT.EE"), expected: "USDT.EE"},
+		{code: NewCode("USDT.1"), expected: "USDT.1"},
+		{code: NewCode("A.B.E"), expected: "A.B.E"},
+		{code: NewCode(".e"), expected: ".e"},
+		{code: EMPTYCODE, expected: ""},
+	} {
+		if received := tc.code.BaseAsset(); received.String() != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.code, received, tc.expected)
+		}
+	}
+}
@@ -4,9 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
-var errCannotCreatePair = errors.New("cannot create currency pair")
+var (
+	errCannotCreatePair    = errors.New("cannot create currency pair")
+	errNoKnownQuote        = errors.New("symbol does not end in a known quote currency")
+	errBinaryFieldTooLong  = errors.New("pair field too long for binary encoding")
+	errInvalidBinaryLength = errors.New("invalid binary pair length")
+	errPairMismatch        = errors.New("pairs do not share the same currencies")
+	errInvalidPrice        = errors.New("invalid price")
+	errInvalidFiatCode     = errors.New("invalid ISO 4217 fiat code")
+	errDelimiterInCode     = errors.New("currency code contains the pair delimiter")
+	errFiatFiatPair        = errors.New("pair has fiat currencies on both legs")
+)
+
+var (
+	defaultQuotesMtx sync.RWMutex
+	// defaultQuotes are the quote currencies NewPairFromString matches
+	// against symbols without a delimiter, set via SetDefaultQuotes
+	defaultQuotes []string
+)
 
 // NewPairDelimiter splits the desired currency string at delimeter, the returns
 // a Pair struct
@@ -32,6 +50,30 @@ func NewPairDelimiter(currencyPair, delimiter string) (Pair, error) {
 	}, nil
 }
 
+// NewPairDelimiterSafe splits the currency string at the delimiter, returning an
+// error unless it splits into exactly two non-empty currencies
+func NewPairDelimiterSafe(currencyPair, delimiter string) (Pair, error) {
+	if delimiter == "" {
+		return EMPTYPAIR, fmt.Errorf("cannot create currency pair from %s: %w", currencyPair, errNoDelimiter)
+	}
+	result := strings.Split(currencyPair, delimiter)
+	if len(result) != 2 {
+		return EMPTYPAIR, fmt.Errorf("%w from %s: expected 2 currencies split by %s, found %d",
+			errCannotCreatePair,
+			currencyPair,
+			delimiter,
+			len(result))
+	}
+	if result[0] == "" || result[1] == "" {
+		return EMPTYPAIR, fmt.Errorf("%w from %s: empty currency", errCannotCreatePair, currencyPair)
+	}
+	return Pair{
+		Delimiter: delimiter,
+		Base:      NewCode(result[0]),
+		Quote:     NewCode(result[1]),
+	}, nil
+}
+
 // NewPairFromStrings returns a CurrencyPair without a delimiter
 func NewPairFromStrings(base, quote string) (Pair, error) {
 	if strings.Contains(base, " ") {
@@ -66,6 +108,16 @@ func NewPairWithDelimiter(base, quote, delimiter string) Pair {
 	}
 }
 
+// NewPairFromCodes returns a currency pair with a delimiter from existing
+// currency codes, avoiding a round trip through strings
+func NewPairFromCodes(base, quote Code, delimiter string) Pair {
+	return Pair{
+		Base:      base,
+		Quote:     quote,
+		Delimiter: delimiter,
+	}
+}
+
 // NewPairFromIndex returns a CurrencyPair via a currency string and specific
 // index
 func NewPairFromIndex(currencyPair, index string) (Pair, error) {
@@ -89,6 +141,14 @@ func NewPairFromString(currencyPair string) (Pair, error) {
 			return NewPairDelimiter(currencyPair, delimiters[x])
 		}
 	}
+	defaultQuotesMtx.RLock()
+	quotes := defaultQuotes
+	defaultQuotesMtx.RUnlock()
+	if len(quotes) > 0 {
+		if p, err := NewPairFromSymbol(currencyPair, quotes); err == nil {
+			return p, nil
+		}
+	}
 	if len(currencyPair) < 3 {
 		return EMPTYPAIR,
 			fmt.Errorf("%w from %s string too short to be a current pair",
@@ -98,6 +158,45 @@ func NewPairFromString(currencyPair string) (Pair, error) {
 	return NewPairFromStrings(currencyPair[0:3], currencyPair[3:])
 }
 
+// NewPairFromSymbol splits a symbol without a delimiter at the longest known
+// quote currency it ends with e.g. 1000SHIBUSDT becomes 1000SHIB and USDT
+func NewPairFromSymbol(symbol string, knownQuotes []string) (Pair, error) {
+	quoteLen, matches := matchQuoteSuffix(symbol, knownQuotes)
+	if matches == 0 {
+		return EMPTYPAIR, fmt.Errorf("%w from %s: %v", errCannotCreatePair, symbol, errNoKnownQuote)
+	}
+	split := len(symbol) - quoteLen
+	return NewPair(NewCode(symbol[:split]), NewCode(symbol[split:])), nil
+}
+
+// SetDefaultQuotes sets the known quote currencies NewPairFromString matches
+// against symbols without a delimiter
+func SetDefaultQuotes(quotes []string) {
+	defaultQuotesMtx.Lock()
+	defaultQuotes = append([]string(nil), quotes...)
+	defaultQuotesMtx.Unlock()
+}
+
+// matchQuoteSuffix returns the length of the longest quote the symbol ends
+// with, leaving at least one character for the base, along with the number of
+// distinct quote lengths which matched
+func matchQuoteSuffix(symbol string, quotes []string) (quoteLen, matches int) {
+	// matched holds the lengths of matching quotes so duplicate quotes are
+	// not counted as ambiguous
+	matched := make(map[int]struct{})
+	for i := range quotes {
+		if quotes[i] == "" || len(quotes[i]) >= len(symbol) ||
+			!strings.EqualFold(symbol[len(symbol)-len(quotes[i]):], quotes[i]) {
+			continue
+		}
+		matched[len(quotes[i])] = struct{}{}
+		if len(quotes[i]) > quoteLen {
+			quoteLen = len(quotes[i])
+		}
+	}
+	return quoteLen, len(matched)
+}
+
 // NewPairFromFormattedPairs matches a supplied currency pair to a list of pairs
 // with a specific format. This is helpful for exchanges which
 // provide currency pairs with no delimiter so we can match it with a list and
@@ -116,6 +215,62 @@ func (f PairFormat) Format(pair Pair) string {
 	return pair.Format(f).String()
 }
 
+// ToExchangeSymbols formats all pairs with an exchange's pair format, such as
+// its configured request format, returning the exchange native symbols
+func ToExchangeSymbols(pairs Pairs, pairFmt PairFormat) []string {
+	symbols := make([]string, len(pairs))
+	for i := range pairs {
+		symbols[i] = pairFmt.Format(pairs[i])
+	}
+	return symbols
+}
+
+// RoundTripStable returns whether a symbol parses to the same pair after being
+// formatted with the supplied format
+func RoundTripStable(symbol string, f PairFormat) bool {
+	p, err := NewPairFromString(symbol)
+	if err != nil {
+		return false
+	}
+	reparsed, err := NewPairFromString(f.Format(p))
+	if err != nil {
+		return false
+	}
+	return p.Equal(reparsed)
+}
+
+// ParseWithConfidence parses a symbol into a pair along with a confidence
+// between 0 and 1 that the split is correct
+func ParseWithConfidence(symbol string, quotes []string) (Pair, float64) {
+	symbol = strings.TrimSpace(symbol)
+	for x := range delimiters {
+		if !strings.Contains(symbol, delimiters[x]) {
+			continue
+		}
+		p, err := NewPairDelimiter(symbol, delimiters[x])
+		if err != nil || p.Base.IsEmpty() || p.Quote.IsEmpty() {
+			return EMPTYPAIR, 0
+		}
+		return p, 1
+	}
+	if quoteLen, matches := matchQuoteSuffix(symbol, quotes); matches > 0 {
+		split := len(symbol) - quoteLen
+		p := NewPair(NewCode(symbol[:split]), NewCode(symbol[split:]))
+		if matches == 1 {
+			return p, 1
+		}
+		return p, 0.75
+	}
+	if len(symbol) < 4 {
+		return EMPTYPAIR, 0
+	}
+	p := NewPair(NewCode(symbol[:3]), NewCode(symbol[3:]))
+	if len(symbol) == 6 {
+		return p, 0.5
+	}
+	return p, 0.25
+}
+
 // MatchPairsWithNoDelimiter will move along a predictable index on the provided currencyPair
 // it will then split on that index and verify whether that currencypair exists in the
 // supplied pairs
@@ -1,7 +1,15 @@
 package currency
 
 import (
+	"encoding/base32"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // EMPTYFORMAT defines an empty pair format
@@ -34,9 +42,14 @@ func (p *Pair) UnmarshalJSON(d []byte) error {
 		return err
 	}
 
+	if pair == "" {
+		*p = EMPTYPAIR
+		return nil
+	}
+
 	newPair, err := NewPairFromString(pair)
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot unmarshal pair %q: %w", pair, err)
 	}
 
 	*p = newPair
@@ -48,6 +61,50 @@ func (p Pair) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.String())
 }
 
+// MarshalBinary conforms type to the encoding.BinaryMarshaler interface
+func (p Pair) MarshalBinary() ([]byte, error) {
+	fields := [3]string{p.Base.String(), p.Quote.String(), p.Delimiter}
+	size := len(fields)
+	for x := range fields {
+		if len(fields[x]) > math.MaxUint8 {
+			return nil, fmt.Errorf("%w: %d bytes exceeds %d", errBinaryFieldTooLong, len(fields[x]), math.MaxUint8)
+		}
+		size += len(fields[x])
+	}
+	data := make([]byte, 0, size)
+	for x := range fields {
+		data = append(data, byte(len(fields[x])))
+		data = append(data, fields[x]...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary conforms type to the encoding.BinaryUnmarshaler interface
+func (p *Pair) UnmarshalBinary(data []byte) error {
+	var fields [3]string
+	for x := range fields {
+		if len(data) == 0 {
+			return errInvalidBinaryLength
+		}
+		length := int(data[0])
+		data = data[1:]
+		if len(data) < length {
+			return errInvalidBinaryLength
+		}
+		fields[x] = string(data[:length])
+		data = data[length:]
+	}
+	if len(data) != 0 {
+		return errInvalidBinaryLength
+	}
+	*p = Pair{
+		Base:      NewCode(fields[0]),
+		Quote:     NewCode(fields[1]),
+		Delimiter: fields[2],
+	}
+	return nil
+}
+
 // Format changes the currency based on user preferences overriding the default
 // String() display
 func (p Pair) Format(pf PairFormat) Pair {
@@ -70,6 +127,200 @@ func (p Pair) EqualIncludeReciprocal(cPair Pair) bool {
 		(p.Base.Equal(cPair.Quote) && p.Quote.Equal(cPair.Base))
 }
 
+// SharesBaseExposure returns whether both pairs have the same base currency
+// regardless of quote e.g. BTC-USD and BTC-USDT both give BTC exposure
+func (p Pair) SharesBaseExposure(cPair Pair) bool {
+	return !p.Base.IsEmpty() && p.Base.Equal(cPair.Base)
+}
+
+// IsLeveragedToken returns whether the base currency contains any of the
+// supplied indicators e.g. BTCUP with UP
+func (p Pair) IsLeveragedToken(indicators []string) bool {
+	base := strings.ToUpper(p.Base.String())
+	for i := range indicators {
+		if indicators[i] != "" && strings.Contains(base, strings.ToUpper(indicators[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesRegex returns whether the pattern matches the uppercase pair without
+// a delimiter e.g. BTC-usdt is matched as BTCUSDT
+func (p Pair) MatchesRegex(re *regexp.Regexp) bool {
+	if re == nil {
+		return false
+	}
+	return re.MatchString(p.Base.Upper().String() + p.Quote.Upper().String())
+}
+
+// PriceFor converts a price for the pair into the orientation of the target
+// pair e.g. 20000 for BTC-USD is 0.00005 for USD-BTC
+func (p Pair) PriceFor(target Pair, price float64) (float64, error) {
+	switch {
+	case p.Equal(target):
+		return price, nil
+	case p.Base.Equal(target.Quote) && p.Quote.Equal(target.Base):
+		if price <= 0 {
+			return 0, fmt.Errorf("%w %v cannot be inverted", errInvalidPrice, price)
+		}
+		return 1 / price, nil
+	}
+	return 0, fmt.Errorf("%w %v %v", errPairMismatch, p, target)
+}
+
+// ValidateFiatLegs checks the fiat legs of the pair against the supplied ISO
+// 4217 codes, including unclassified three letter codes when checkUnknown is set
+func (p Pair) ValidateFiatLegs(isoCodes map[string]bool, checkUnknown bool) error {
+	for _, leg := range [2]Code{p.Base, p.Quote} {
+		if !leg.IsFiatCurrency() && (!checkUnknown || !leg.isUnknownThreeLetter()) {
+			continue
+		}
+		if !isoCodes[leg.Upper().String()] {
+			return fmt.Errorf("%w %v in %v", errInvalidFiatCode, leg, p)
+		}
+	}
+	return nil
+}
+
+// isUnknownThreeLetter returns whether the code has no registered role and is
+// three letters, so could be a fiat currency
+func (c Code) isUnknownThreeLetter() bool {
+	if c.Item == nil || c.Item.Role != Unset || len(c.Item.Symbol) != 3 {
+		return false
+	}
+	for _, r := range c.Item.Symbol {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// OrdinalKey packs the first four bytes of the uppercase base and quote into an
+// integer which sorts by base then quote
+func (p Pair) OrdinalKey() uint64 {
+	return uint64(ordinalHalf(p.Base))<<32 | uint64(ordinalHalf(p.Quote))
+}
+
+// ordinalHalf packs the first four bytes of the uppercase code, zero padding
+// shorter codes so they sort before any code they prefix
+func ordinalHalf(c Code) uint32 {
+	code := c.Upper().String()
+	var key uint32
+	for i := 0; i < 4; i++ {
+		key <<= 8
+		if i < len(code) {
+			key |= uint32(code[i])
+		}
+	}
+	return key
+}
+
+// UnderlyingQuote returns the underlying asset of the quote currency,
+// removing any bridged token chain suffix e.g. AVAX-USDT.e returns USDT
+func (p Pair) UnderlyingQuote() Code {
+	return p.Quote.BaseAsset()
+}
+
+// WebSocketSymbol formats the pair with an exchange's websocket subscription
+// format, which can differ from its request format e.g. lowercase for Binance
+func (p Pair) WebSocketSymbol(pairFmt PairFormat) string {
+	return pairFmt.Format(p)
+}
+
+// ShortLabel returns the pair shortened to at most maxLen characters for display
+// e.g. DERIVATIVE-USDT becomes DERI…-USDT
+func (p Pair) ShortLabel(maxLen int) string {
+	label := p.String()
+	if utf8.RuneCountInString(label) <= maxLen {
+		return label
+	}
+	base, quote := []rune(p.Base.String()), []rune(p.Quote.String())
+	delimiter := utf8.RuneCountInString(p.Delimiter)
+	if baseLen := maxLen - delimiter - len(quote) - 1; baseLen >= 1 {
+		return truncateLabel(base, baseLen) + p.Delimiter + string(quote)
+	}
+	shortBase := truncateLabel(base, 1)
+	quoteLen := maxLen - delimiter - utf8.RuneCountInString(shortBase) - 1
+	return shortBase + p.Delimiter + truncateLabel(quote, quoteLen)
+}
+
+// truncateLabel shortens the characters to n followed by an ellipsis, never
+// removing the first character
+func truncateLabel(r []rune, n int) string {
+	if len(r) <= n {
+		return string(r)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return string(r[:n]) + "…"
+}
+
+// OrderByPriority returns the pair oriented so the currency listed earliest in
+// priority is the quote
+func (p Pair) OrderByPriority(priority []string) Pair {
+	rank := func(c Code) int {
+		for i := range priority {
+			if strings.EqualFold(c.String(), priority[i]) {
+				return i
+			}
+		}
+		return len(priority)
+	}
+	if rank(p.Base) < rank(p.Quote) {
+		return Pair{Base: p.Quote, Quote: p.Base, Delimiter: p.Delimiter}
+	}
+	return p
+}
+
+// IsBase returns true if the supplied currency code matches the pair's base
+// currency, ignoring case
+func (p Pair) IsBase(c string) bool {
+	return strings.EqualFold(p.Base.String(), c)
+}
+
+// IsQuote returns true if the supplied currency code matches the pair's quote
+// currency, ignoring case
+func (p Pair) IsQuote(c string) bool {
+	return strings.EqualFold(p.Quote.String(), c)
+}
+
+// Validate returns an error when either currency is empty or contains the
+// pair's delimiter
+func (p Pair) Validate() error {
+	for _, c := range []struct {
+		name string
+		code string
+	}{{"base", p.Base.String()}, {"quote", p.Quote.String()}} {
+		if strings.TrimSpace(c.code) == "" {
+			return fmt.Errorf("%s %w", c.name, ErrCurrencyCodeEmpty)
+		}
+		if p.Delimiter != "" && strings.Contains(c.code, p.Delimiter) {
+			return fmt.Errorf("%s %q %w %q", c.name, c.code, errDelimiterInCode, p.Delimiter)
+		}
+	}
+	return nil
+}
+
+// RejectFiatFiat returns an error when both currencies are in the supplied fiat
+// list
+func (p Pair) RejectFiatFiat(fiats []string) error {
+	isFiat := func(c Code) bool {
+		for i := range fiats {
+			if strings.EqualFold(c.String(), fiats[i]) {
+				return true
+			}
+		}
+		return false
+	}
+	if isFiat(p.Base) && isFiat(p.Quote) {
+		return fmt.Errorf("%w %v", errFiatFiatPair, p)
+	}
+	return nil
+}
+
 // IsCryptoPair checks to see if the pair is a crypto pair e.g. BTCLTC
 func (p Pair) IsCryptoPair() bool {
 	return p.Base.IsCryptocurrency() && p.Quote.IsCryptocurrency()
@@ -134,3 +385,88 @@ func (p Pair) Other(c Code) (Code, error) {
 	}
 	return EMPTYCODE, ErrCurrencyCodeEmpty
 }
+
+// CanJoinWithoutDelimiter returns whether the pair is unambiguous when joined
+// without a delimiter
+func (p Pair) CanJoinWithoutDelimiter() bool {
+	base := p.Base.String()
+	quote := p.Quote.String()
+	if base == "" || quote == "" {
+		return false
+	}
+	for x := range delimiters {
+		if strings.Contains(base, delimiters[x]) || strings.Contains(quote, delimiters[x]) {
+			return false
+		}
+	}
+	lastBase := rune(base[len(base)-1])
+	firstQuote := rune(quote[0])
+	return !unicode.IsDigit(lastBase) || !unicode.IsDigit(firstQuote)
+}
+
+// FeeCurrency returns the currency fees are charged in for the pair given an
+// exchange's fee convention of base, quote or a fee token
+func (p Pair) FeeCurrency(conv string) Code {
+	switch {
+	case conv == "", strings.EqualFold(conv, "quote"):
+		return p.Quote
+	case strings.EqualFold(conv, "base"):
+		return p.Base
+	default:
+		return NewCode(conv)
+	}
+}
+
+// NormalizeUnicode returns the pair with homoglyphs mapped to ASCII and
+// combining marks stripped
+func (p Pair) NormalizeUnicode() Pair {
+	p.Base = p.Base.NormalizeUnicode()
+	p.Quote = p.Quote.NormalizeUnicode()
+	return p
+}
+
+// MarketID returns an exchange agnostic identifier for the pair in the form
+// BASE-QUOTE
+func (p Pair) MarketID() string {
+	if p.IsEmpty() {
+		return ""
+	}
+	return p.Base.Upper().String() + DashDelimiter + p.Quote.Upper().String()
+}
+
+// Key returns the pair's canonical map key e.g. both BTC-USD and btc_usd produce
+// BTCUSD
+func (p Pair) Key() string {
+	return p.Base.Upper().String() + p.Quote.Upper().String()
+}
+
+// DirectionalKey returns the pair's Key, suitable for keying orderbook caches
+// where BTC-USD and USD-BTC must differ
+func (p Pair) DirectionalKey() string {
+	return p.Key()
+}
+
+// BothKeys returns the keys of the pair and its swap
+func (p Pair) BothKeys() (forward, reverse string) {
+	base, quote := p.Base.Upper().String(), p.Quote.Upper().String()
+	return base + quote, quote + base
+}
+
+// UnorderedKey returns a key which the pair shares with its swap
+func (p Pair) UnorderedKey() string {
+	base, quote := p.Base.Upper().String(), p.Quote.Upper().String()
+	if quote < base {
+		return quote + base
+	}
+	return base + quote
+}
+
+// ShortID returns an 8 character hash of the pair's Key and the config hash for
+// display
+func ShortID(pair Pair, configHash string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(pair.Key()))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(configHash))
+	return base32.StdEncoding.EncodeToString(h.Sum(nil))[:8]
+}
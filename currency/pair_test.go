@@ -1,8 +1,11 @@
 package currency
 
 import (
+	"encoding"
 	"encoding/json"
 	"errors"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -72,6 +75,43 @@ func TestPairUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestPairJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	var p Pair
+	err := json.Unmarshal([]byte(`"ETH_BTC"`), &p)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !p.Equal(NewPair(ETH, BTC)) || p.Delimiter != UnderscoreDelimiter {
+		t.Errorf("received: '%v' but expected: '%v'", p, "ETH_BTC")
+	}
+	encoded, err := json.Marshal(p)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if string(encoded) != `"ETH_BTC"` {
+		t.Errorf("received: '%s' but expected: '%v'", encoded, `"ETH_BTC"`)
+	}
+
+	p = NewPair(BTC, USD)
+	err = json.Unmarshal([]byte(`""`), &p)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if p != EMPTYPAIR {
+		t.Errorf("received: '%v' but expected: '%v'", p, EMPTYPAIR)
+	}
+
+	err = json.Unmarshal([]byte(`"BT"`), &p)
+	if !errors.Is(err, errCannotCreatePair) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errCannotCreatePair)
+	}
+	err = json.Unmarshal([]byte(`1337`), &p)
+	if err == nil {
+		t.Error("expected an error unmarshalling a non string pair")
+	}
+}
+
 func TestPairMarshalJSON(t *testing.T) {
 	quickstruct := struct {
 		Pair *Pair `json:"superPair"`
@@ -420,6 +460,25 @@ func TestNewPairDelimiter(t *testing.T) {
 	}
 }
 
+func TestNewPairDelimiterSafe(t *testing.T) {
+	t.Parallel()
+	for _, symbol := range []string{"BTC-PERP-USD", "BTCUSD", "-USD", "BTC-", ""} {
+		if _, err := NewPairDelimiterSafe(symbol, DashDelimiter); !errors.Is(err, errCannotCreatePair) {
+			t.Errorf("%v received: '%v' but expected: '%v'", symbol, err, errCannotCreatePair)
+		}
+	}
+	if _, err := NewPairDelimiterSafe("BTC-USD", ""); !errors.Is(err, errNoDelimiter) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoDelimiter)
+	}
+	p, err := NewPairDelimiterSafe("btc_usd", UnderscoreDelimiter)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if p.String() != "btc_usd" || !p.Equal(NewPair(BTC, USD)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, "btc_usd")
+	}
+}
+
 // TestNewPairFromIndex returns a CurrencyPair via a currency string and
 // specific index
 func TestNewPairFromIndex(t *testing.T) {
@@ -911,3 +970,628 @@ func TestOther(t *testing.T) {
 		t.Fatal("unexpected value")
 	}
 }
+
+func TestCanJoinWithoutDelimiter(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pair     Pair
+		expected bool
+	}{
+		{pair: NewPair(BTC, USDT), expected: true},
+		{pair: NewPairWithDelimiter("1INCH", "USDT", "-"), expected: true},
+		{pair: NewPairWithDelimiter("BTC", "1000", "-"), expected: true},
+		{pair: NewPairWithDelimiter("ETH2", "1000", "-"), expected: false},
+		{pair: NewPairWithDelimiter("BTC-PERP", "USD", "_"), expected: false},
+		{pair: NewPairWithDelimiter("BTC", "USD/T", "-"), expected: false},
+		{pair: NewPair(BTC, EMPTYCODE), expected: false},
+		{pair: EMPTYPAIR, expected: false},
+	} {
+		if received := tc.pair.CanJoinWithoutDelimiter(); received != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.pair, received, tc.expected)
+		}
+	}
+}
+
+func TestFeeCurrency(t *testing.T) {
+	t.Parallel()
+	pair := NewPair(BTC, USDT)
+	for _, tc := range []struct {
+		conv     string
+		expected Code
+	}{
+		{conv: "", expected: USDT},
+		{conv: "quote", expected: USDT},
+		{conv: "QUOTE", expected: USDT},
+		{conv: "base", expected: BTC},
+		{conv: "Base", expected: BTC},
+		{conv: "BNB", expected: BNB},
+		{conv: "ftt", expected: FTT},
+	} {
+		if received := pair.FeeCurrency(tc.conv); !received.Equal(tc.expected) {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.conv, received, tc.expected)
+		}
+	}
+}
+
+func TestPairNormalizeUnicode(t *testing.T) {
+	t.Parallel()
+	homoglyph := NewPairWithDelimiter("ВTС", "USDT", "-") // Cyrillic В and С
+	normalized := homoglyph.NormalizeUnicode()
+	expected := NewPairWithDelimiter("BTC", "USDT", "-")
+	if !normalized.Equal(expected) {
+		t.Errorf("received: '%v' but expected: '%v'", normalized, expected)
+	}
+	if normalized.String() != expected.String() {
+		t.Errorf("received: '%v' but expected: '%v'", normalized.String(), expected.String())
+	}
+	if homoglyph.Equal(expected) {
+		t.Error("expected homoglyph pair to differ before normalization")
+	}
+}
+
+func TestMarketID(t *testing.T) {
+	t.Parallel()
+	if id := EMPTYPAIR.MarketID(); id != "" {
+		t.Errorf("received: '%v' but expected: '%v'", id, "")
+	}
+	for _, symbol := range []string{"BTC-USDT", "btc_usdt", "BTC/usdt", "btc:USDT", "BTCUSDT", "btcusdt"} {
+		p, err := NewPairFromString(symbol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := p.MarketID(); id != "BTC-USDT" {
+			t.Errorf("%v received: '%v' but expected: '%v'", symbol, id, "BTC-USDT")
+		}
+	}
+	p := NewPairWithDelimiter("doge", "usd", "")
+	if id := p.MarketID(); id != "DOGE-USD" {
+		t.Errorf("received: '%v' but expected: '%v'", id, "DOGE-USD")
+	}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+	dash, err := NewPairFromString("BTC-USD")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	underscore, err := NewPairFromString("btc_usd")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if dash.Key() != "BTCUSD" || underscore.Key() != dash.Key() {
+		t.Errorf("received: '%v' '%v' but expected: '%v'", dash.Key(), underscore.Key(), "BTCUSD")
+	}
+	if key := dash.Swap().Key(); key != "USDBTC" {
+		t.Errorf("received: '%v' but expected: '%v'", key, "USDBTC")
+	}
+}
+
+func TestDirectionalKey(t *testing.T) {
+	t.Parallel()
+	p := NewPairWithDelimiter("btc", "usd", "_")
+	if key := p.DirectionalKey(); key != "BTCUSD" {
+		t.Errorf("received: '%v' but expected: '%v'", key, "BTCUSD")
+	}
+	if key := NewPair(BTC, USD).DirectionalKey(); key != p.DirectionalKey() {
+		t.Errorf("received: '%v' but expected: '%v'", key, p.DirectionalKey())
+	}
+	if key := p.Swap().DirectionalKey(); key != "USDBTC" {
+		t.Errorf("received: '%v' but expected: '%v'", key, "USDBTC")
+	}
+	if p.DirectionalKey() == p.Swap().DirectionalKey() {
+		t.Error("expected reciprocal pairs to produce different directional keys")
+	}
+}
+
+func TestUnorderedKey(t *testing.T) {
+	t.Parallel()
+	p := NewPairWithDelimiter("usd", "btc", "_")
+	if key := p.UnorderedKey(); key != "BTCUSD" {
+		t.Errorf("received: '%v' but expected: '%v'", key, "BTCUSD")
+	}
+	if p.UnorderedKey() != p.Swap().UnorderedKey() {
+		t.Errorf("received: '%v' but expected: '%v'", p.Swap().UnorderedKey(), p.UnorderedKey())
+	}
+	if p.DirectionalKey() == p.Swap().DirectionalKey() {
+		t.Errorf("received: '%v' but expected a different directional key", p.Swap().DirectionalKey())
+	}
+}
+
+func TestShortID(t *testing.T) {
+	t.Parallel()
+	id := ShortID(NewPairWithDelimiter("BTC", "USDT", "-"), "abc123")
+	if len(id) != 8 {
+		t.Errorf("received: '%v' but expected: '%v'", len(id), 8)
+	}
+	if again := ShortID(NewPairWithDelimiter("btc", "usdt", "_"), "abc123"); again != id {
+		t.Errorf("received: '%v' but expected: '%v'", again, id)
+	}
+	if other := ShortID(NewPairWithDelimiter("BTC", "USDT", "-"), "abc124"); other == id {
+		t.Errorf("received: '%v' but expected a different id", other)
+	}
+	if swapped := ShortID(NewPairWithDelimiter("USDT", "BTC", "-"), "abc123"); swapped == id {
+		t.Errorf("received: '%v' but expected a different id", swapped)
+	}
+	if empty := ShortID(EMPTYPAIR, ""); len(empty) != 8 {
+		t.Errorf("received: '%v' but expected: '%v'", len(empty), 8)
+	}
+}
+
+func TestBothKeys(t *testing.T) {
+	t.Parallel()
+	p := NewPairWithDelimiter("btc", "usdt", "-")
+	forward, reverse := p.BothKeys()
+	if forward != "BTCUSDT" {
+		t.Errorf("received: '%v' but expected: '%v'", forward, "BTCUSDT")
+	}
+	if reverse != "USDTBTC" {
+		t.Errorf("received: '%v' but expected: '%v'", reverse, "USDTBTC")
+	}
+	if forward != p.DirectionalKey() || reverse != p.Swap().DirectionalKey() {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", forward, reverse, p.DirectionalKey(), p.Swap().DirectionalKey())
+	}
+	if forward, reverse = EMPTYPAIR.BothKeys(); forward != "" || reverse != "" {
+		t.Errorf("received: '%v' '%v' but expected empty keys", forward, reverse)
+	}
+}
+
+func TestSharesBaseExposure(t *testing.T) {
+	t.Parallel()
+	p := NewPair(BTC, USD)
+	if !p.SharesBaseExposure(NewPair(BTC, USDT)) {
+		t.Error("expected BTC-USD and BTC-USDT to share base exposure")
+	}
+	if !p.SharesBaseExposure(NewPair(BTC.Lower(), USDT.Lower())) {
+		t.Error("expected base comparison to be case insensitive")
+	}
+	if p.SharesBaseExposure(NewPair(ETH, USD)) {
+		t.Error("expected BTC-USD and ETH-USD not to share base exposure")
+	}
+	if p.SharesBaseExposure(NewPair(USD, BTC)) {
+		t.Error("expected BTC-USD and USD-BTC not to share base exposure")
+	}
+	if EMPTYPAIR.SharesBaseExposure(EMPTYPAIR) {
+		t.Error("expected empty pairs not to share base exposure")
+	}
+}
+
+func TestIsLeveragedToken(t *testing.T) {
+	t.Parallel()
+	indicators := []string{"UP", "DOWN", "3L", "3S", "BULL", "BEAR"}
+	if !NewPair(NewCode("BTCUP"), USDT).IsLeveragedToken(indicators) {
+		t.Error("expected BTCUP-USDT to be a leveraged token")
+	}
+	if !NewPair(NewCode("eth3l"), USDT).IsLeveragedToken(indicators) {
+		t.Error("expected eth3l-USDT to be a leveraged token")
+	}
+	if NewPair(BTC, USDT).IsLeveragedToken(indicators) {
+		t.Error("expected BTC-USDT not to be a leveraged token")
+	}
+	if NewPair(BTC, USDT).IsLeveragedToken([]string{""}) {
+		t.Error("expected empty indicators to be ignored")
+	}
+	if NewPair(NewCode("BTCUP"), USDT).IsLeveragedToken(nil) {
+		t.Error("expected no match without indicators")
+	}
+}
+
+func TestMatchesRegex(t *testing.T) {
+	t.Parallel()
+	re := regexp.MustCompile(`^(BTC|ETH)USD[TC]?$`)
+	for _, tc := range []struct {
+		pair     Pair
+		expected bool
+	}{
+		{pair: NewPair(BTC, USD), expected: true},
+		{pair: NewPairWithDelimiter("eth", "usdt", "-"), expected: true},
+		{pair: NewPairWithDelimiter("BTC", "USDC", "/"), expected: true},
+		{pair: NewPair(LTC, USDT), expected: false},
+		{pair: NewPair(BTC, BUSD), expected: false},
+	} {
+		if received := tc.pair.MatchesRegex(re); received != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.pair, received, tc.expected)
+		}
+	}
+	if NewPair(BTC, USD).MatchesRegex(nil) {
+		t.Error("expected nil pattern not to match")
+	}
+}
+
+func TestPriceFor(t *testing.T) {
+	t.Parallel()
+	p := NewPair(BTC, USD)
+	price, err := p.PriceFor(NewPairWithDelimiter("btc", "usd", "-"), 20000)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if price != 20000 {
+		t.Errorf("received: '%v' but expected: '%v'", price, 20000)
+	}
+	price, err = p.PriceFor(NewPair(USD, BTC), 20000)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if price != 0.00005 {
+		t.Errorf("received: '%v' but expected: '%v'", price, 0.00005)
+	}
+	_, err = p.PriceFor(NewPair(USD, BTC), 0)
+	if !errors.Is(err, errInvalidPrice) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidPrice)
+	}
+	_, err = p.PriceFor(NewPair(BTC, USDT), 20000)
+	if !errors.Is(err, errPairMismatch) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errPairMismatch)
+	}
+}
+
+func TestValidateFiatLegs(t *testing.T) {
+	t.Parallel()
+	iso := map[string]bool{"USD": true, "EUR": true, "AUD": true}
+	usd := Code{Item: &Item{Symbol: "USD", Lower: "usd", Role: Fiat}, UpperCase: true}
+	usb := Code{Item: &Item{Symbol: "USB", Lower: "usb", Role: Fiat}, UpperCase: true}
+
+	err := NewPair(NewCode("ZZX"), usd).ValidateFiatLegs(iso, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = NewPair(NewCode("ZZX"), usb).ValidateFiatLegs(iso, false)
+	if !errors.Is(err, errInvalidFiatCode) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidFiatCode)
+	}
+	// an unregistered three letter cryptocurrency is only checked when unknown
+	// legs are opted in
+	err = NewPair(NewCode("ZZX"), usd).ValidateFiatLegs(iso, true)
+	if !errors.Is(err, errInvalidFiatCode) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidFiatCode)
+	}
+	err = NewPair(NewCode("ZZX1"), usd).ValidateFiatLegs(iso, true)
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestOrdinalKey(t *testing.T) {
+	t.Parallel()
+	ordered := []Pair{
+		NewPair(NewCode("BCH"), NewCode("USD")),
+		NewPair(NewCode("btc"), NewCode("eur")),
+		NewPair(NewCode("BTC"), NewCode("USD")),
+		NewPair(NewCode("BTC"), NewCode("USDT")),
+		NewPair(NewCode("BTCB"), NewCode("AUD")),
+		NewPair(NewCode("ETH"), NewCode("BTC")),
+	}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1].OrdinalKey() >= ordered[i].OrdinalKey() {
+			t.Errorf("received: '%v' key not below '%v' key", ordered[i-1], ordered[i])
+		}
+	}
+	if a, b := NewPair(NewCode("btc"), NewCode("usd")), NewPair(NewCode("BTC"), NewCode("USD")); a.OrdinalKey() != b.OrdinalKey() {
+		t.Errorf("received: '%v' but expected: '%v'", a.OrdinalKey(), b.OrdinalKey())
+	}
+}
+
+func TestUnderlyingQuote(t *testing.T) {
+	t.Parallel()
+	p := NewPair(AVAX, NewCode("USDT.e"))
+	if quote := p.UnderlyingQuote(); !quote.Equal(USDT) {
+		t.Errorf("received: '%v' but expected: '%v'", quote, USDT)
+	}
+	p = NewPair(BTC, USDT)
+	if quote := p.UnderlyingQuote(); !quote.Equal(USDT) {
+		t.Errorf("received: '%v' but expected: '%v'", quote, USDT)
+	}
+}
+
+func TestWebSocketSymbol(t *testing.T) {
+	t.Parallel()
+	p := NewPairWithDelimiter("BTC", "USDT", "/")
+	for _, tc := range []struct {
+		exchange string
+		pairFmt  PairFormat
+		expected string
+	}{
+		{"Binance", PairFormat{}, "btcusdt"},
+		{"CoinbasePro", PairFormat{Uppercase: true, Delimiter: DashDelimiter}, "BTC-USDT"},
+		{"Kraken", PairFormat{Uppercase: true, Delimiter: ForwardSlashDelimiter}, "BTC/USDT"},
+	} {
+		if symbol := p.WebSocketSymbol(tc.pairFmt); symbol != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.exchange, symbol, tc.expected)
+		}
+	}
+}
+
+func TestShortLabel(t *testing.T) {
+	t.Parallel()
+	p, err := NewPairDelimiter("DERIVATIVE-USDT", DashDelimiter)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for _, tc := range []struct {
+		maxLen   int
+		expected string
+	}{
+		{maxLen: 20, expected: "DERIVATIVE-USDT"},
+		{maxLen: 15, expected: "DERIVATIVE-USDT"},
+		{maxLen: 10, expected: "DERI…-USDT"},
+		{maxLen: 7, expected: "D…-USDT"},
+		{maxLen: 6, expected: "D…-US…"},
+		{maxLen: 0, expected: "D…-U…"},
+	} {
+		label := p.ShortLabel(tc.maxLen)
+		if label != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.maxLen, label, tc.expected)
+		}
+		if tc.maxLen >= 7 && !strings.HasSuffix(label, "-USDT") {
+			t.Errorf("%v received: '%v' but expected the quote to remain visible", tc.maxLen, label)
+		}
+	}
+}
+
+func TestParseWithConfidence(t *testing.T) {
+	t.Parallel()
+	quotes := []string{"USDT", "USD", "BTC", "usdt"}
+	for _, tc := range []struct {
+		symbol     string
+		expected   Pair
+		confidence float64
+	}{
+		{symbol: "BTC-USD", expected: NewPair(BTC, USD), confidence: 1},
+		{symbol: "doge_usdt", expected: NewPair(DOGE, USDT), confidence: 1},
+		{symbol: "DOGEUSD", expected: NewPair(DOGE, USD), confidence: 1},
+		{symbol: "dogeusdt", expected: NewPair(DOGE, USDT), confidence: 1},
+		{symbol: "ETHBTC", expected: NewPair(ETH, BTC), confidence: 1},
+		{symbol: "ETHBUSD", expected: NewPair(NewCode("ETHB"), USD), confidence: 1},
+		{symbol: "LTCEUR", expected: NewPair(LTC, EUR), confidence: 0.5},
+		{symbol: "DOGEEUR", expected: NewPair(NewCode("DOG"), NewCode("EEUR")), confidence: 0.25},
+		{symbol: "BTC-", expected: EMPTYPAIR, confidence: 0},
+		{symbol: "USD", expected: EMPTYPAIR, confidence: 0},
+		{symbol: "", expected: EMPTYPAIR, confidence: 0},
+	} {
+		p, confidence := ParseWithConfidence(tc.symbol, quotes)
+		if !p.Equal(tc.expected) {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.symbol, p, tc.expected)
+		}
+		if confidence != tc.confidence {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.symbol, confidence, tc.confidence)
+		}
+	}
+
+	p, confidence := ParseWithConfidence("ETHBUSD", []string{"BUSD", "USD"})
+	if !p.Equal(NewPair(ETH, BUSD)) || confidence != 0.75 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", p, confidence, NewPair(ETH, BUSD), 0.75)
+	}
+	_, delimited := ParseWithConfidence("ETH/EUR", nil)
+	_, guessed := ParseWithConfidence("ETHEUR", nil)
+	if delimited <= guessed {
+		t.Errorf("received: '%v' but expected delimited parse to score higher than '%v'", delimited, guessed)
+	}
+}
+
+func TestOrderByPriority(t *testing.T) {
+	t.Parallel()
+	priority := []string{"usd", "USDT", "BTC"}
+	swapped := NewPairWithDelimiter("USD", "btc", "-").OrderByPriority(priority)
+	if !swapped.Equal(NewPair(BTC, USD)) || swapped.Delimiter != DashDelimiter {
+		t.Errorf("received: '%v' but expected: '%v'", swapped, "BTC-USD")
+	}
+	if p := NewPair(BTC, USD).OrderByPriority(priority); !p.Equal(NewPair(BTC, USD)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(BTC, USD))
+	}
+	if p := NewPair(USDT, ETH).OrderByPriority(priority); !p.Equal(NewPair(ETH, USDT)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(ETH, USDT))
+	}
+	if p := NewPair(USD, USDT).OrderByPriority(priority); !p.Equal(NewPair(USDT, USD)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(USDT, USD))
+	}
+	if p := NewPair(XRP, LTC).OrderByPriority(priority); !p.Equal(NewPair(XRP, LTC)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(XRP, LTC))
+	}
+	if p := NewPair(BTC, ETH).OrderByPriority(nil); !p.Equal(NewPair(BTC, ETH)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(BTC, ETH))
+	}
+}
+
+func TestNewPairFromSymbol(t *testing.T) {
+	t.Parallel()
+	quotes := []string{"USD", "USDT", "BTC", "TRY"}
+	for symbol, expected := range map[string]Pair{
+		"USDTTRY":      NewPair(USDT, NewCode("TRY")),
+		"1000SHIBUSDT": NewPair(NewCode("1000SHIB"), USDT),
+		"DOGEBTC":      NewPair(DOGE, BTC),
+		"btcusd":       NewPair(BTC, USD),
+		"ETHUSDT":      NewPair(ETH, USDT),
+	} {
+		p, err := NewPairFromSymbol(symbol, quotes)
+		if !errors.Is(err, nil) {
+			t.Fatalf("%v received: '%v' but expected: '%v'", symbol, err, nil)
+		}
+		if !p.Equal(expected) {
+			t.Errorf("%v received: '%v' but expected: '%v'", symbol, p, expected)
+		}
+	}
+	for _, symbol := range []string{"ETHEUR", "USDT", ""} {
+		if _, err := NewPairFromSymbol(symbol, quotes); !errors.Is(err, errCannotCreatePair) {
+			t.Errorf("%v received: '%v' but expected: '%v'", symbol, err, errCannotCreatePair)
+		}
+	}
+}
+
+func TestSetDefaultQuotes(t *testing.T) {
+	p, err := NewPairFromString("DOGEBTC")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !p.Equal(NewPair(NewCode("DOG"), NewCode("EBTC"))) {
+		t.Errorf("received: '%v' but expected: '%v'", p, "DOG-EBTC")
+	}
+
+	SetDefaultQuotes([]string{"BTC", "USDT"})
+	defer SetDefaultQuotes(nil)
+	p, err = NewPairFromString("DOGEBTC")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !p.Equal(NewPair(DOGE, BTC)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(DOGE, BTC))
+	}
+	p, err = NewPairFromString("LTCEUR")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !p.Equal(NewPair(LTC, EUR)) {
+		t.Errorf("received: '%v' but expected: '%v'", p, NewPair(LTC, EUR))
+	}
+}
+
+func TestIsBaseIsQuote(t *testing.T) {
+	t.Parallel()
+	p := NewPair(BTC, USDT)
+	if !p.IsBase("btc") || !p.IsBase("BTC") {
+		t.Errorf("received: '%v' but expected: '%v'", false, true)
+	}
+	if p.IsBase("usdt") || p.IsBase("") {
+		t.Errorf("received: '%v' but expected: '%v'", true, false)
+	}
+	if !p.IsQuote("UsDt") {
+		t.Errorf("received: '%v' but expected: '%v'", false, true)
+	}
+	if p.IsQuote("btc") || p.IsQuote("usd") {
+		t.Errorf("received: '%v' but expected: '%v'", true, false)
+	}
+}
+
+func TestNewPairFromCodes(t *testing.T) {
+	t.Parallel()
+	existing := NewPairWithDelimiter("btc", "usdt", "-")
+	p := NewPairFromCodes(existing.Quote, existing.Base, "/")
+	if p.Base != existing.Quote {
+		t.Errorf("received: '%v' but expected: '%v'", p.Base, existing.Quote)
+	}
+	if p.Quote != existing.Base {
+		t.Errorf("received: '%v' but expected: '%v'", p.Quote, existing.Base)
+	}
+	if p.Delimiter != "/" {
+		t.Errorf("received: '%v' but expected: '%v'", p.Delimiter, "/")
+	}
+	if p.String() != "usdt/btc" {
+		t.Errorf("received: '%v' but expected: '%v'", p, "usdt/btc")
+	}
+}
+
+func TestPairValidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pair Pair
+		err  error
+	}{
+		{NewPairWithDelimiter("BTC", "USDT", "-"), nil},
+		{NewPairWithDelimiter("BTC", "USDT", ""), nil},
+		{NewPairWithDelimiter("", "USDT", "-"), ErrCurrencyCodeEmpty},
+		{NewPairWithDelimiter("BTC", " ", "-"), ErrCurrencyCodeEmpty},
+		{EMPTYPAIR, ErrCurrencyCodeEmpty},
+		{NewPairWithDelimiter("BTC-PERP", "USD", "-"), errDelimiterInCode},
+		{NewPairWithDelimiter("BTC", "US/D", "/"), errDelimiterInCode},
+	} {
+		if err := tc.pair.Validate(); !errors.Is(err, tc.err) {
+			t.Errorf("%+v received: '%v' but expected: '%v'", tc.pair, err, tc.err)
+		}
+	}
+}
+
+func TestRejectFiatFiat(t *testing.T) {
+	t.Parallel()
+	fiats := []string{"usd", "EUR", "JPY"}
+	if err := NewPair(EUR, USD).RejectFiatFiat(fiats); !errors.Is(err, errFiatFiatPair) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errFiatFiatPair)
+	}
+	if err := NewPair(BTC, USD).RejectFiatFiat(fiats); !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if err := NewPair(USD, BTC).RejectFiatFiat(fiats); !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if err := NewPair(EUR, USD).RejectFiatFiat(nil); !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestRoundTripStable(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		symbol   string
+		format   PairFormat
+		expected bool
+	}{
+		{symbol: "BTC-USDT", format: PairFormat{Delimiter: "/", Uppercase: true}, expected: true},
+		{symbol: "btc_usdt", format: PairFormat{Delimiter: DashDelimiter}, expected: true},
+		{symbol: "BTCUSD", format: PairFormat{Uppercase: true}, expected: true},
+		// without a delimiter DOGEUSD reparses as DOG-EUSD
+		{symbol: "DOGE-USD", format: PairFormat{Uppercase: true}, expected: false},
+		{symbol: "BT", format: PairFormat{Uppercase: true}, expected: false},
+	} {
+		if received := RoundTripStable(tc.symbol, tc.format); received != tc.expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", tc.symbol, received, tc.expected)
+		}
+	}
+}
+
+func TestToExchangeSymbols(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPairWithDelimiter("btc", "usdt", DashDelimiter),
+		NewPairWithDelimiter("ETH", "BTC", "/"),
+	}
+	// Binance requests use joined uppercase symbols
+	symbols := ToExchangeSymbols(pairs, PairFormat{Uppercase: true})
+	if len(symbols) != 2 || symbols[0] != "BTCUSDT" || symbols[1] != "ETHBTC" {
+		t.Errorf("received: '%v' but expected: '%v'", symbols, []string{"BTCUSDT", "ETHBTC"})
+	}
+	symbols = ToExchangeSymbols(pairs, PairFormat{Delimiter: UnderscoreDelimiter})
+	if len(symbols) != 2 || symbols[0] != "btc_usdt" || symbols[1] != "eth_btc" {
+		t.Errorf("received: '%v' but expected: '%v'", symbols, []string{"btc_usdt", "eth_btc"})
+	}
+}
+
+func TestPairMarshalBinary(t *testing.T) {
+	t.Parallel()
+	var _ encoding.BinaryMarshaler = Pair{}
+	var _ encoding.BinaryUnmarshaler = &Pair{}
+
+	longCode := strings.Repeat("X", 255)
+	for _, p := range []Pair{
+		NewPairWithDelimiter("BTC", "USDT", DashDelimiter),
+		NewPairWithDelimiter("btc", "usdt", ""),
+		NewPairWithDelimiter("1000SHIB", "BUSD", "/"),
+		NewPairWithDelimiter(longCode, "USD", "_"),
+		EMPTYPAIR,
+	} {
+		data, err := p.MarshalBinary()
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if expected := len(p.Base.String()) + len(p.Quote.String()) + len(p.Delimiter) + 3; len(data) != expected {
+			t.Errorf("received: '%v' but expected: '%v'", len(data), expected)
+		}
+		var decoded Pair
+		err = decoded.UnmarshalBinary(data)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if decoded.String() != p.String() || decoded.Delimiter != p.Delimiter || !decoded.Equal(p) {
+			t.Errorf("received: '%v' but expected: '%v'", decoded, p)
+		}
+	}
+
+	_, err := NewPairWithDelimiter(longCode+"X", "USD", "").MarshalBinary()
+	if !errors.Is(err, errBinaryFieldTooLong) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errBinaryFieldTooLong)
+	}
+
+	var p Pair
+	for _, data := range [][]byte{nil, {3, 'B', 'T'}, {3, 'B', 'T', 'C', 0}, {0, 0, 0, 1}} {
+		if err = p.UnmarshalBinary(data); !errors.Is(err, errInvalidBinaryLength) {
+			t.Errorf("%v received: '%v' but expected: '%v'", data, err, errInvalidBinaryLength)
+		}
+	}
+}
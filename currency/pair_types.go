@@ -17,3 +17,21 @@ type PairDifference struct {
 	Remove           Pairs
 	FormatDifference bool
 }
+
+// LintSeverity defines how problematic a pair lint issue is
+type LintSeverity string
+
+// Pair lint severities
+const (
+	// LintWarning is a pair which is usable but likely to be misinterpreted
+	LintWarning LintSeverity = "warning"
+	// LintError is a pair which is malformed
+	LintError LintSeverity = "error"
+)
+
+// PairLintIssue defines a problem found with a pair when linting
+type PairLintIssue struct {
+	Pair     Pair
+	Severity LintSeverity
+	Message  string
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -14,6 +19,8 @@ var (
 	errSymbolEmpty = errors.New("symbol is empty")
 	errPairsEmpty  = errors.New("pairs are empty")
 	errNoDelimiter = errors.New("no delimiter was supplied")
+	errShardCount  = errors.New("shard count must be greater than zero")
+	errColumnCount = errors.New("column lengths do not match")
 
 	// ErrPairDuplication defines an error when there is multiple of the same
 	// currency pairs found.
@@ -34,6 +41,62 @@ func NewPairsFromStrings(pairs []string) (Pairs, error) {
 	return allThePairs, nil
 }
 
+// ParsePairList parses a comma or whitespace separated list of pairs, detecting
+// the delimiter of each pair and reporting every invalid token
+func ParsePairList(s string) (Pairs, error) {
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	pairs := make(Pairs, 0, len(tokens))
+	var errs common.Errors
+	for i := range tokens {
+		p, err := NewPairFromString(tokens[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tokens[i], err))
+			continue
+		}
+		pairs = append(pairs, p)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return pairs, nil
+}
+
+// NewPairSet returns the pairs keyed by their Key, keeping the first occurrence
+// of each pair
+func NewPairSet(pairs Pairs) map[string]Pair {
+	set := make(map[string]Pair, len(pairs))
+	for i := range pairs {
+		key := pairs[i].Key()
+		if _, ok := set[key]; !ok {
+			set[key] = pairs[i]
+		}
+	}
+	return set
+}
+
+// LoadPairsDedup parses the symbols and collapses those describing the same
+// market, returning a warning for each duplicate or unparsable symbol
+func LoadPairsDedup(symbols []string) (pairs Pairs, warnings []string) {
+	first := make(map[string]string, len(symbols))
+	for i := range symbols {
+		p, err := NewPairFromString(symbols[i])
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipping symbol %q: %v", symbols[i], err))
+			continue
+		}
+		key := p.Key()
+		if original, ok := first[key]; ok {
+			warnings = append(warnings, fmt.Sprintf("symbol %q duplicates %q and has been removed", symbols[i], original))
+			continue
+		}
+		first[key] = symbols[i]
+		pairs = append(pairs, p)
+	}
+	return pairs, warnings
+}
+
 // NewPairsFromString takes in a delimiter string and returns a Pairs
 // type
 func NewPairsFromString(pairs, delimiter string) (Pairs, error) {
@@ -43,6 +106,20 @@ func NewPairsFromString(pairs, delimiter string) (Pairs, error) {
 	return NewPairsFromStrings(strings.Split(pairs, delimiter))
 }
 
+// NewPairsFromColumns returns pairs from parallel slices of base, quote and
+// delimiter strings, the reverse of Pairs.Columns
+func NewPairsFromColumns(bases, quotes, delimiters []string) (Pairs, error) {
+	if len(bases) != len(quotes) || len(bases) != len(delimiters) {
+		return nil, fmt.Errorf("%w bases: %d quotes: %d delimiters: %d",
+			errColumnCount, len(bases), len(quotes), len(delimiters))
+	}
+	pairs := make(Pairs, len(bases))
+	for x := range bases {
+		pairs[x] = NewPairWithDelimiter(bases[x], quotes[x], delimiters[x])
+	}
+	return pairs, nil
+}
+
 // Strings returns a slice of strings referring to each currency pair
 func (p Pairs) Strings() []string {
 	list := make([]string, len(p))
@@ -52,6 +129,20 @@ func (p Pairs) Strings() []string {
 	return list
 }
 
+// Columns returns the pairs as parallel slices of base, quote and delimiter
+// strings for use with columnar data stores
+func (p Pairs) Columns() (bases, quotes, delimiters []string) {
+	bases = make([]string, len(p))
+	quotes = make([]string, len(p))
+	delimiters = make([]string, len(p))
+	for x := range p {
+		bases[x] = p[x].Base.String()
+		quotes[x] = p[x].Quote.String()
+		delimiters[x] = p[x].Delimiter
+	}
+	return bases, quotes, delimiters
+}
+
 // Join returns a comma separated list of currency pairs
 func (p Pairs) Join() string {
 	return strings.Join(p.Strings(), ",")
@@ -185,6 +276,19 @@ func (p Pairs) RemovePairsByFilter(filter Code) Pairs {
 	return pairs
 }
 
+// RemoveDuplicates returns the pairs without duplicates, ignoring case and
+// delimiter and also matching reciprocal pairs when exact is false
+func (p Pairs) RemoveDuplicates(exact bool) Pairs {
+	pairs := make(Pairs, 0, len(p))
+	for i := range p {
+		if pairs.Contains(p[i], exact) {
+			continue
+		}
+		pairs = append(pairs, p[i])
+	}
+	return pairs
+}
+
 // GetPairsByFilter returns all pairs that have at least one match base or quote
 // to the filter code.
 func (p Pairs) GetPairsByFilter(filter Code) Pairs {
@@ -440,3 +544,551 @@ func (p Pairs) ValidateAndConform(pFmt PairFormat, bypassFormatting bool) (Pairs
 	}
 	return formatted, nil
 }
+
+// Table returns the pairs as a width aligned table sorted by base then quote
+func (p Pairs) Table() string {
+	sorted := make(Pairs, len(p))
+	copy(sorted, p)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Base.Upper().String()+sorted[i].Quote.Upper().String() <
+			sorted[j].Base.Upper().String()+sorted[j].Quote.Upper().String()
+	})
+
+	rows := make([][3]string, len(sorted)+1)
+	rows[0] = [3]string{"Base", "Quote", "Delimiter"}
+	for x := range sorted {
+		rows[x+1] = [3]string{sorted[x].Base.String(), sorted[x].Quote.String(), sorted[x].Delimiter}
+	}
+	var widths [3]int
+	for x := range rows {
+		for y := range rows[x] {
+			if len(rows[x][y]) > widths[y] {
+				widths[y] = len(rows[x][y])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for x := range rows {
+		line := fmt.Sprintf("%-*s  %-*s  %s", widths[0], rows[x][0], widths[1], rows[x][1], rows[x][2])
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Shard splits the pairs into n shards by a hash of each pair's Key
+func (p Pairs) Shard(n int) ([]Pairs, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w received %d", errShardCount, n)
+	}
+	shards := make([]Pairs, n)
+	for x := range p {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(p[x].Key()))
+		target := h.Sum32() % uint32(n)
+		shards[target] = append(shards[target], p[x])
+	}
+	return shards, nil
+}
+
+// MergePreferFormatted merges the lists without duplicates, keeping the entry
+// with a delimiter then the uppercase entry when pairs match
+func MergePreferFormatted(lists ...Pairs) Pairs {
+	var merged Pairs
+	seen := make(map[string]int)
+	for x := range lists {
+		for y := range lists[x] {
+			key := lists[x][y].Key()
+			target, ok := seen[key]
+			if !ok {
+				seen[key] = len(merged)
+				merged = append(merged, lists[x][y])
+				continue
+			}
+			if formatScore(lists[x][y]) > formatScore(merged[target]) {
+				merged[target] = lists[x][y]
+			}
+		}
+	}
+	return merged
+}
+
+// SymmetricDifference returns the pairs which are only found in a and the pairs
+// which are only found in b
+func SymmetricDifference(a, b Pairs, exact bool) (onlyA, onlyB Pairs) {
+	for i := range a {
+		if !b.Contains(a[i], exact) {
+			onlyA = append(onlyA, a[i])
+		}
+	}
+	for i := range b {
+		if !a.Contains(b[i], exact) {
+			onlyB = append(onlyB, b[i])
+		}
+	}
+	return onlyA, onlyB
+}
+
+// Coverage returns the fraction of the universe found in the configured pairs
+// and the universe pairs which are missing
+func Coverage(configured, universe Pairs, exact bool) (ratio float64, missing Pairs) {
+	if len(universe) == 0 {
+		return 0, nil
+	}
+	for i := range universe {
+		if !configured.Contains(universe[i], exact) {
+			missing = append(missing, universe[i])
+		}
+	}
+	return float64(len(universe)-len(missing)) / float64(len(universe)), missing
+}
+
+// JaccardSimilarity returns the size of the intersection of the pair lists
+// divided by the size of their union
+func JaccardSimilarity(a, b Pairs, exact bool) float64 {
+	var union Pairs
+	var intersection int
+	for i := range a {
+		if union.Contains(a[i], exact) {
+			continue
+		}
+		union = append(union, a[i])
+		if b.Contains(a[i], exact) {
+			intersection++
+		}
+	}
+	for i := range b {
+		if !union.Contains(b[i], exact) {
+			union = append(union, b[i])
+		}
+	}
+	if len(union) == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(len(union))
+}
+
+// PairsDifference returns the pairs added to and removed from oldPairs by
+// newPairs
+func PairsDifference(oldPairs, newPairs Pairs, exact bool) (added, removed Pairs) {
+	for i := range newPairs {
+		if !newPairs[i].IsEmpty() && !oldPairs.Contains(newPairs[i], exact) && !added.Contains(newPairs[i], exact) {
+			added = append(added, newPairs[i])
+		}
+	}
+	for i := range oldPairs {
+		if !oldPairs[i].IsEmpty() && !newPairs.Contains(oldPairs[i], exact) && !removed.Contains(oldPairs[i], exact) {
+			removed = append(removed, oldPairs[i])
+		}
+	}
+	return added, removed
+}
+
+// PairsIntersection returns the pairs of a which are also in b
+func PairsIntersection(a, b Pairs, exact bool) Pairs {
+	var intersection Pairs
+	for i := range a {
+		if !a[i].IsEmpty() && b.Contains(a[i], exact) && !intersection.Contains(a[i], exact) {
+			intersection = append(intersection, a[i])
+		}
+	}
+	return intersection
+}
+
+// PairsUnion returns the pairs of a followed by the pairs of b which are not in a
+func PairsUnion(a, b Pairs, exact bool) Pairs {
+	var union Pairs
+	for _, list := range []Pairs{a, b} {
+		for i := range list {
+			if !list[i].IsEmpty() && !union.Contains(list[i], exact) {
+				union = append(union, list[i])
+			}
+		}
+	}
+	return union
+}
+
+// OrphanCurrencies returns the sorted uppercase currencies which appear in
+// exactly one distinct pair
+func OrphanCurrencies(pairs Pairs) []string {
+	seen := make(map[string]bool, len(pairs))
+	counts := make(map[string]int)
+	for i := range pairs {
+		base, quote := pairs[i].Base.Upper().String(), pairs[i].Quote.Upper().String()
+		key := base + "-" + quote
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		counts[base]++
+		if quote != base {
+			counts[quote]++
+		}
+	}
+	var orphans []string
+	for c, count := range counts {
+		if count == 1 {
+			orphans = append(orphans, c)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// BaseMarketCounts returns the number of distinct pairs each uppercase base
+// currency appears in
+func BaseMarketCounts(pairs Pairs) map[string]int {
+	seen := make(map[string]bool, len(pairs))
+	counts := make(map[string]int)
+	for i := range pairs {
+		base := pairs[i].Base.Upper().String()
+		key := base + "-" + pairs[i].Quote.Upper().String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		counts[base]++
+	}
+	return counts
+}
+
+// MinimalQuoteCover greedily picks a small set of uppercase quote currencies
+// covering every pair
+func MinimalQuoteCover(pairs Pairs) []string {
+	uncovered := make([]string, 0, len(pairs))
+	for i := range pairs {
+		quote := pairs[i].Quote.Upper().String()
+		if quote == "" {
+			continue
+		}
+		uncovered = append(uncovered, quote)
+	}
+	var cover []string
+	for len(uncovered) > 0 {
+		counts := make(map[string]int)
+		for i := range uncovered {
+			counts[uncovered[i]]++
+		}
+		var best string
+		for c, n := range counts {
+			if best == "" || n > counts[best] || (n == counts[best] && c < best) {
+				best = c
+			}
+		}
+		cover = append(cover, best)
+		remaining := uncovered[:0]
+		for i := range uncovered {
+			if uncovered[i] != best {
+				remaining = append(remaining, uncovered[i])
+			}
+		}
+		uncovered = remaining
+	}
+	return cover
+}
+
+// IsConnectedGraph reports whether every currency can reach every other through
+// the pairs, returning the connected components when it cannot
+func IsConnectedGraph(pairs Pairs) (connected bool, components [][]string) {
+	parent := make(map[string]string)
+	var find func(c string) string
+	find = func(c string) string {
+		if parent[c] != c {
+			parent[c] = find(parent[c])
+		}
+		return parent[c]
+	}
+	add := func(c string) {
+		if _, ok := parent[c]; !ok {
+			parent[c] = c
+		}
+	}
+	for i := range pairs {
+		base, quote := pairs[i].Base.Upper().String(), pairs[i].Quote.Upper().String()
+		switch {
+		case base == "" && quote == "":
+			continue
+		case base == "":
+			add(quote)
+		case quote == "":
+			add(base)
+		default:
+			add(base)
+			add(quote)
+			parent[find(base)] = find(quote)
+		}
+	}
+	groups := make(map[string][]string)
+	for c := range parent {
+		root := find(c)
+		groups[root] = append(groups[root], c)
+	}
+	if len(groups) <= 1 {
+		return true, nil
+	}
+	components = make([][]string, 0, len(groups))
+	for _, group := range groups {
+		sort.Strings(group)
+		components = append(components, group)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return false, components
+}
+
+// ShufflePairs returns a copy of the pairs shuffled deterministically by seed
+func ShufflePairs(pairs Pairs, seed int64) Pairs {
+	if pairs == nil {
+		return nil
+	}
+	shuffled := make(Pairs, len(pairs))
+	copy(shuffled, pairs)
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec // reproducible shuffling required, no need for crypto/rand
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// formatScore ranks how well formatted a pair is, preferring a delimiter over
+// uppercase codes
+func formatScore(p Pair) int {
+	var score int
+	if p.Delimiter != "" {
+		score += 2
+	}
+	if p.Base.UpperCase && p.Quote.UpperCase {
+		score++
+	}
+	return score
+}
+
+// SortPreferCrypto returns a sorted copy of the pairs with pairs quoted in the
+// supplied fiat currencies last
+func (p Pairs) SortPreferCrypto(fiats Currencies) Pairs {
+	sorted := make(Pairs, len(p))
+	copy(sorted, p)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iFiat := fiats.Contains(sorted[i].Quote)
+		jFiat := fiats.Contains(sorted[j].Quote)
+		if iFiat != jFiat {
+			return jFiat
+		}
+		return lessBaseQuote(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// SortPairs stably sorts the pairs in place by uppercase base then quote
+func SortPairs(pairs Pairs) {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return lessBaseQuote(pairs[i], pairs[j])
+	})
+}
+
+// lessBaseQuote reports whether a sorts before b by uppercase base currency,
+// breaking ties on uppercase quote currency
+func lessBaseQuote(a, b Pair) bool {
+	aBase, bBase := a.Base.Upper().String(), b.Base.Upper().String()
+	if aBase != bBase {
+		return aBase < bBase
+	}
+	return a.Quote.Upper().String() < b.Quote.Upper().String()
+}
+
+// Lint returns an issue for every pair with a missing or malformed delimiter
+func (p Pairs) Lint() []PairLintIssue {
+	var issues []PairLintIssue
+	for x := range p {
+		base, quote := p[x].Base.String(), p[x].Quote.String()
+		if base == "" || quote == "" {
+			issues = append(issues, PairLintIssue{
+				Pair:     p[x],
+				Severity: LintError,
+				Message:  "pair is missing a base or quote currency",
+			})
+			continue
+		}
+		malformed := false
+		for y := range delimiters {
+			if strings.Contains(base, delimiters[y]) || strings.Contains(quote, delimiters[y]) {
+				issues = append(issues, PairLintIssue{
+					Pair:     p[x],
+					Severity: LintError,
+					Message:  fmt.Sprintf("currency contains delimiter %q, pair delimiter may be malformed", delimiters[y]),
+				})
+				malformed = true
+				break
+			}
+		}
+		if malformed {
+			continue
+		}
+		if p[x].Delimiter != "" {
+			known := false
+			for y := range delimiters {
+				if p[x].Delimiter == delimiters[y] {
+					known = true
+					break
+				}
+			}
+			if !known {
+				issues = append(issues, PairLintIssue{
+					Pair:     p[x],
+					Severity: LintWarning,
+					Message:  fmt.Sprintf("unrecognised delimiter %q", p[x].Delimiter),
+				})
+			}
+			continue
+		}
+		joined := base + quote
+		split, err := NewPairFromString(joined)
+		if err != nil || !split.Base.Equal(p[x].Base) || !split.Quote.Equal(p[x].Quote) {
+			issues = append(issues, PairLintIssue{
+				Pair:     p[x],
+				Severity: LintWarning,
+				Message:  fmt.Sprintf("joined symbol %s cannot be safely split, a delimiter is required", joined),
+			})
+		}
+	}
+	return issues
+}
+
+// TriangularCandidates returns every set of three pairs whose currencies chain
+// back to the starting currency e.g. BTC-USD, ETH-BTC and ETH-USD
+func (p Pairs) TriangularCandidates() [][3]Pair {
+	var edges Pairs
+	seen := make(map[[2]*Item]bool)
+	adjacent := make(map[*Item]map[*Item]int)
+	for x := range p {
+		a, b := p[x].Base.Item, p[x].Quote.Item
+		if a == nil || b == nil || a == b || seen[[2]*Item{a, b}] {
+			continue
+		}
+		seen[[2]*Item{a, b}] = true
+		seen[[2]*Item{b, a}] = true
+		if adjacent[a] == nil {
+			adjacent[a] = make(map[*Item]int)
+		}
+		if adjacent[b] == nil {
+			adjacent[b] = make(map[*Item]int)
+		}
+		adjacent[a][b] = len(edges)
+		adjacent[b][a] = len(edges)
+		edges = append(edges, p[x])
+	}
+
+	var triangles [][3]int
+	for i := range edges {
+		a, b := edges[i].Base.Item, edges[i].Quote.Item
+		for c, j := range adjacent[a] {
+			k, ok := adjacent[b][c]
+			if !ok || j <= i || k <= i {
+				// only the lowest indexed pair produces the triangle
+				continue
+			}
+			if j > k {
+				j, k = k, j
+			}
+			triangles = append(triangles, [3]int{i, j, k})
+		}
+	}
+	sort.Slice(triangles, func(x, y int) bool {
+		for z := range triangles[x] {
+			if triangles[x][z] != triangles[y][z] {
+				return triangles[x][z] < triangles[y][z]
+			}
+		}
+		return false
+	})
+	resp := make([][3]Pair, len(triangles))
+	for x := range triangles {
+		resp[x] = [3]Pair{edges[triangles[x][0]], edges[triangles[x][1]], edges[triangles[x][2]]}
+	}
+	return resp
+}
+
+// AllShareQuote returns the quote currency shared by every pair in the list
+func (p Pairs) AllShareQuote() (quote Code, ok bool) {
+	if len(p) == 0 || p[0].Quote.IsEmpty() {
+		return EMPTYCODE, false
+	}
+	for x := 1; x < len(p); x++ {
+		if !p[x].Quote.Equal(p[0].Quote) {
+			return EMPTYCODE, false
+		}
+	}
+	return p[0].Quote.Upper(), true
+}
+
+// BucketByInitial groups the pairs by the uppercase first letter of their base,
+// using '#' for bases which do not start with a letter
+func (p Pairs) BucketByInitial() map[rune]Pairs {
+	buckets := make(map[rune]Pairs)
+	for x := range p {
+		base := p[x].Base.Upper().String()
+		if base == "" {
+			continue
+		}
+		initial, _ := utf8.DecodeRuneInString(base)
+		if !unicode.IsLetter(initial) {
+			initial = '#'
+		}
+		buckets[initial] = append(buckets[initial], p[x])
+	}
+	for _, bucket := range buckets {
+		SortPairs(bucket)
+	}
+	return buckets
+}
+
+// ReachableCurrencies returns the uppercase currencies reachable from start
+// within maxHops pairs, mapped to the fewest hops required
+func (p Pairs) ReachableCurrencies(start Code, maxHops int) map[string]int {
+	reachable := make(map[string]int)
+	if start.Item == nil || maxHops <= 0 {
+		return reachable
+	}
+	adjacent := make(map[*Item][]*Item)
+	for x := range p {
+		a, b := p[x].Base.Item, p[x].Quote.Item
+		if a == nil || b == nil || a == b {
+			continue
+		}
+		adjacent[a] = append(adjacent[a], b)
+		adjacent[b] = append(adjacent[b], a)
+	}
+	hops := map[*Item]int{start.Item: 0}
+	queue := []*Item{start.Item}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if hops[current] == maxHops {
+			continue
+		}
+		for _, next := range adjacent[current] {
+			if _, ok := hops[next]; ok {
+				continue
+			}
+			hops[next] = hops[current] + 1
+			reachable[Code{Item: next, UpperCase: true}.String()] = hops[next]
+			queue = append(queue, next)
+		}
+	}
+	return reachable
+}
+
+// ConsistentDelimiter returns the delimiter shared by every pair in the list
+func (p Pairs) ConsistentDelimiter() (delimiter string, consistent bool) {
+	if len(p) == 0 {
+		return "", true
+	}
+	for x := 1; x < len(p); x++ {
+		if p[x].Delimiter != p[0].Delimiter {
+			return "", false
+		}
+	}
+	return p[0].Delimiter, true
+}
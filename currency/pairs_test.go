@@ -3,6 +3,7 @@ package currency
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -216,9 +217,20 @@ func TestGetPairsByFilter(t *testing.T) {
 	}
 
 	filtered := pairs.GetPairsByFilter(LTC)
-	if !filtered.Contains(NewPair(LTC, USDT), true) &&
-		!filtered.Contains(NewPair(LTC, USD), true) {
-		t.Error("TestRemovePairsByFilter unexpected result")
+	if !filtered.Contains(NewPair(LTC, USDT), true) ||
+		!filtered.Contains(NewPair(LTC, USD), true) ||
+		len(filtered) != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", filtered.Join(), "LTCUSD,LTCUSDT")
+	}
+
+	filtered = pairs.GetPairsByFilter(NewCode("usd"))
+	if filtered.Join() != "BTCUSD,LTCUSD" {
+		t.Errorf("received: '%v' but expected: '%v'", filtered.Join(), "BTCUSD,LTCUSD")
+	}
+
+	filtered = pairs.GetPairsByFilter(XRP)
+	if filtered == nil || len(filtered) != 0 {
+		t.Errorf("received: '%v' but expected an empty non-nil list", filtered)
 	}
 }
 
@@ -783,3 +795,827 @@ func TestValidateAndConform(t *testing.T) {
 		t.Fatalf("received: '%v' but expected '%v'", formatted.Join(), expected)
 	}
 }
+
+func TestPairsTable(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPairWithDelimiter("LINK", "USDT", "-"),
+		NewPairWithDelimiter("BTC", "USD", "_"),
+		NewPair(NewCode("ETH"), NewCode("BTC")),
+	}
+	expected := "Base  Quote  Delimiter\n" +
+		"BTC   USD    _\n" +
+		"ETH   BTC\n" +
+		"LINK  USDT   -\n"
+	if table := pairs.Table(); table != expected {
+		t.Errorf("received: '%v' but expected: '%v'", table, expected)
+	}
+
+	expected = "Base  Quote  Delimiter\n"
+	if table := (Pairs{}).Table(); table != expected {
+		t.Errorf("received: '%v' but expected: '%v'", table, expected)
+	}
+}
+
+func TestPairsShard(t *testing.T) {
+	t.Parallel()
+	_, err := Pairs{}.Shard(0)
+	if !errors.Is(err, errShardCount) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShardCount)
+	}
+
+	var pairs Pairs
+	for _, base := range []string{"BTC", "ETH", "LTC", "XRP", "DOGE", "LINK", "SOL", "ADA", "DOT", "UNI"} {
+		for _, quote := range []string{"USD", "USDT", "AUD", "EUR", "JPY", "GBP", "KRW", "USDC", "BUSD", "DAI"} {
+			pairs = append(pairs, NewPairWithDelimiter(base, quote, "-"))
+		}
+	}
+	const n = 4
+	shards, err := pairs.Shard(n)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(shards) != n {
+		t.Fatalf("received: '%v' but expected: '%v'", len(shards), n)
+	}
+	var total int
+	for x := range shards {
+		total += len(shards[x])
+		// expect each shard to be within half of a perfectly balanced spread
+		if len(shards[x]) < len(pairs)/n/2 || len(shards[x]) > len(pairs)/n*3/2 {
+			t.Errorf("received: shard size '%v' which is not roughly balanced", len(shards[x]))
+		}
+	}
+	if total != len(pairs) {
+		t.Errorf("received: '%v' but expected: '%v'", total, len(pairs))
+	}
+
+	reversed := make(Pairs, len(pairs))
+	for x := range pairs {
+		reversed[len(pairs)-1-x] = pairs[x].Format(PairFormat{Delimiter: "_"})
+	}
+	reshards, err := reversed.Shard(n)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for x := range shards {
+		if len(shards[x]) != len(reshards[x]) {
+			t.Fatalf("received: '%v' but expected: '%v'", len(reshards[x]), len(shards[x]))
+		}
+		for y := range shards[x] {
+			if !reshards[x].Contains(shards[x][y], true) {
+				t.Errorf("expected %v to be assigned to shard %v", shards[x][y], x)
+			}
+		}
+	}
+}
+
+func TestMergePreferFormatted(t *testing.T) {
+	t.Parallel()
+	if merged := MergePreferFormatted(); len(merged) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(merged), 0)
+	}
+
+	joinedLower := NewPair(BTC.Lower(), USDT.Lower())
+	delimitedUpper := NewPairWithDelimiter("BTC", "USDT", "-")
+	delimitedLower := NewPairWithDelimiter("btc", "usdt", "_")
+	joinedUpper := NewPair(ETH, USD)
+
+	merged := MergePreferFormatted(
+		Pairs{joinedLower, joinedUpper},
+		Pairs{delimitedLower},
+		Pairs{delimitedUpper, NewPair(LTC, BTC)},
+	)
+	if len(merged) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(merged), 3)
+	}
+	if merged[0].String() != delimitedUpper.String() {
+		t.Errorf("received: '%v' but expected: '%v'", merged[0], delimitedUpper)
+	}
+	if merged[1].String() != joinedUpper.String() {
+		t.Errorf("received: '%v' but expected: '%v'", merged[1], joinedUpper)
+	}
+	if merged[2].String() != "LTCBTC" {
+		t.Errorf("received: '%v' but expected: '%v'", merged[2], "LTCBTC")
+	}
+
+	// a delimiter is preferred over uppercase codes
+	merged = MergePreferFormatted(Pairs{NewPair(BTC, USDT)}, Pairs{delimitedLower})
+	if len(merged) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(merged), 1)
+	}
+	if merged[0].String() != delimitedLower.String() {
+		t.Errorf("received: '%v' but expected: '%v'", merged[0], delimitedLower)
+	}
+}
+
+func TestSortPreferCrypto(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPair(ETH, USD),
+		NewPair(BTC, EUR),
+		NewPair(ETH, BTC),
+		NewPair(BTC, USDT),
+		NewPair(BTC, USD),
+		NewPair(LTC, BTC),
+	}
+	sorted := pairs.SortPreferCrypto(Currencies{USD, EUR})
+	expected := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, BTC),
+		NewPair(LTC, BTC),
+		NewPair(BTC, EUR),
+		NewPair(BTC, USD),
+		NewPair(ETH, USD),
+	}
+	if len(sorted) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(sorted), len(expected))
+	}
+	for x := range expected {
+		if !sorted[x].Equal(expected[x]) {
+			t.Errorf("received: '%v' but expected: '%v' at index %v", sorted[x], expected[x], x)
+		}
+	}
+	if pairs[0] != NewPair(ETH, USD) {
+		t.Error("expected original pairs to be unmodified")
+	}
+
+	sorted = pairs.SortPreferCrypto(nil)
+	if !sorted[0].Equal(NewPair(BTC, EUR)) {
+		t.Errorf("received: '%v' but expected: '%v'", sorted[0], NewPair(BTC, EUR))
+	}
+}
+
+func TestPairsLint(t *testing.T) {
+	t.Parallel()
+	if issues := (Pairs{}).Lint(); len(issues) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(issues), 0)
+	}
+	pairs := Pairs{
+		NewPair(BTC, USDT),
+		NewPairWithDelimiter("DOGE", "USDT", "-"),
+		NewPairWithDelimiter("DOGE", "USDT", ""),
+		NewPairWithDelimiter("BTC-PERP", "USD", "_"),
+		NewPairWithDelimiter("BTC", "USD", "|"),
+		NewPair(BTC, EMPTYCODE),
+	}
+	issues := pairs.Lint()
+	if len(issues) != 4 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(issues), 4)
+	}
+	for x, tc := range []struct {
+		pair     Pair
+		severity LintSeverity
+	}{
+		{pair: pairs[2], severity: LintWarning},
+		{pair: pairs[3], severity: LintError},
+		{pair: pairs[4], severity: LintWarning},
+		{pair: pairs[5], severity: LintError},
+	} {
+		if issues[x].Pair != tc.pair {
+			t.Errorf("received: '%v' but expected: '%v'", issues[x].Pair, tc.pair)
+		}
+		if issues[x].Severity != tc.severity {
+			t.Errorf("received: '%v' but expected: '%v'", issues[x].Severity, tc.severity)
+		}
+		if issues[x].Message == "" {
+			t.Error("expected a lint message")
+		}
+	}
+}
+
+func TestTriangularCandidates(t *testing.T) {
+	t.Parallel()
+	if triangles := (Pairs{}).TriangularCandidates(); len(triangles) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(triangles), 0)
+	}
+	pairs := Pairs{
+		NewPair(BTC, USD),
+		NewPair(ETH, BTC),
+		NewPairWithDelimiter("btc", "usd", "_"),
+		NewPair(XRP, EUR),
+		NewPair(USD, LTC),
+		NewPair(ETH, USD),
+		NewPair(LTC, BTC),
+		NewPair(ETH, ETH),
+	}
+	triangles := pairs.TriangularCandidates()
+	expected := [][3]Pair{
+		{NewPair(BTC, USD), NewPair(ETH, BTC), NewPair(ETH, USD)},
+		{NewPair(BTC, USD), NewPair(USD, LTC), NewPair(LTC, BTC)},
+	}
+	if len(triangles) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", triangles, expected)
+	}
+	for x := range expected {
+		for y := range expected[x] {
+			if !triangles[x][y].Equal(expected[x][y]) {
+				t.Errorf("received: '%v' but expected: '%v'", triangles[x][y], expected[x][y])
+			}
+		}
+	}
+}
+
+func TestPairsColumns(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPairWithDelimiter("BTC", "USDT", "-"),
+		NewPairWithDelimiter("eth", "btc", "_"),
+		NewPair(LTC, USD),
+	}
+	bases, quotes, delimiters := pairs.Columns()
+	if len(bases) != 3 || len(quotes) != 3 || len(delimiters) != 3 {
+		t.Fatalf("received: '%v %v %v' but expected: '%v'", len(bases), len(quotes), len(delimiters), 3)
+	}
+	if bases[1] != "eth" || quotes[1] != "btc" || delimiters[1] != "_" {
+		t.Errorf("received: '%v %v %v' but expected: '%v %v %v'", bases[1], quotes[1], delimiters[1], "eth", "btc", "_")
+	}
+
+	roundTrip, err := NewPairsFromColumns(bases, quotes, delimiters)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(roundTrip) != len(pairs) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(roundTrip), len(pairs))
+	}
+	for x := range pairs {
+		if roundTrip[x] != pairs[x] {
+			t.Errorf("received: '%v' but expected: '%v'", roundTrip[x], pairs[x])
+		}
+	}
+
+	_, err = NewPairsFromColumns(bases, quotes[:2], delimiters)
+	if !errors.Is(err, errColumnCount) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errColumnCount)
+	}
+	_, err = NewPairsFromColumns(bases, quotes, nil)
+	if !errors.Is(err, errColumnCount) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errColumnCount)
+	}
+}
+
+func TestPairsAllShareQuote(t *testing.T) {
+	t.Parallel()
+	quote, ok := Pairs{}.AllShareQuote()
+	if ok || !quote.IsEmpty() {
+		t.Errorf("received: '%v' but expected: '%v'", ok, false)
+	}
+
+	uniform := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT.Lower()),
+		NewPair(XRP, USDT),
+	}
+	quote, ok = uniform.AllShareQuote()
+	if !ok {
+		t.Errorf("received: '%v' but expected: '%v'", ok, true)
+	}
+	if quote.String() != "USDT" {
+		t.Errorf("received: '%v' but expected: '%v'", quote, "USDT")
+	}
+
+	mixed := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USD),
+	}
+	quote, ok = mixed.AllShareQuote()
+	if ok || !quote.IsEmpty() {
+		t.Errorf("received: '%v' but expected: '%v'", ok, false)
+	}
+}
+
+func TestPairsBucketByInitial(t *testing.T) {
+	t.Parallel()
+	if buckets := (Pairs{}).BucketByInitial(); len(buckets) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(buckets), 0)
+	}
+	pairs := Pairs{
+		NewPair(NewCode("1INCH"), USDT),
+		NewPair(ETH, USDT),
+		NewPair(BTC, USDT),
+		NewPair(BTC.Lower(), ETH),
+		NewPair(ADA, BTC),
+		NewPair(EMPTYCODE, USDT),
+		NewPair(NewCode("bnb"), USDT),
+	}
+	buckets := pairs.BucketByInitial()
+	expected := map[rune][]string{
+		'#': {"1INCH-USDT"},
+		'A': {"ADA-BTC"},
+		'B': {"BNB-USDT", "BTC-ETH", "BTC-USDT"},
+		'E': {"ETH-USDT"},
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(buckets), len(expected))
+	}
+	for initial, ids := range expected {
+		bucket := buckets[initial]
+		if len(bucket) != len(ids) {
+			t.Fatalf("%c received: '%v' but expected: '%v'", initial, bucket, ids)
+		}
+		for i := range ids {
+			if bucket[i].MarketID() != ids[i] {
+				t.Errorf("%c received: '%v' but expected: '%v'", initial, bucket[i].MarketID(), ids[i])
+			}
+		}
+	}
+}
+
+func TestPairsReachableCurrencies(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, BTC),
+		NewPair(LTC, ETH),
+		NewPair(XRP, LTC),
+		NewPair(DOGE, USD),
+	}
+	if reachable := pairs.ReachableCurrencies(BTC, 0); len(reachable) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(reachable), 0)
+	}
+	if reachable := pairs.ReachableCurrencies(EMPTYCODE, 2); len(reachable) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(reachable), 0)
+	}
+
+	reachable := pairs.ReachableCurrencies(NewCode("usdt"), 3)
+	expected := map[string]int{
+		"BTC": 1,
+		"ETH": 2,
+		"LTC": 3,
+	}
+	if len(reachable) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", reachable, expected)
+	}
+	for code, hops := range expected {
+		if received, ok := reachable[code]; !ok || received != hops {
+			t.Errorf("%v received: '%v' but expected: '%v'", code, received, hops)
+		}
+	}
+	if received := reachable[NewCode("btc").Upper().String()]; received != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", received, 1)
+	}
+	if _, ok := reachable["XRP"]; ok {
+		t.Error("expected XRP to be beyond the maximum hops")
+	}
+	if _, ok := reachable["DOGE"]; ok {
+		t.Error("expected DOGE to be unreachable")
+	}
+}
+
+func TestPairsConsistentDelimiter(t *testing.T) {
+	t.Parallel()
+	delimiter, consistent := Pairs{}.ConsistentDelimiter()
+	if !consistent || delimiter != "" {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", delimiter, consistent, "", true)
+	}
+
+	delimiter, consistent = Pairs{
+		NewPairWithDelimiter("BTC", "USDT", DashDelimiter),
+		NewPairWithDelimiter("ETH", "USDT", DashDelimiter),
+	}.ConsistentDelimiter()
+	if !consistent || delimiter != DashDelimiter {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", delimiter, consistent, DashDelimiter, true)
+	}
+
+	delimiter, consistent = Pairs{
+		NewPairWithDelimiter("BTC", "USDT", ""),
+		NewPairWithDelimiter("ETH", "USDT", ""),
+	}.ConsistentDelimiter()
+	if !consistent || delimiter != "" {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", delimiter, consistent, "", true)
+	}
+
+	for _, mixed := range []Pairs{
+		{NewPairWithDelimiter("BTC", "USDT", DashDelimiter), NewPairWithDelimiter("ETH", "USDT", "_")},
+		{NewPairWithDelimiter("BTC", "USDT", DashDelimiter), NewPairWithDelimiter("ETH", "USDT", "")},
+		{NewPairWithDelimiter("BTC", "USDT", ""), NewPairWithDelimiter("ETH", "USDT", "/")},
+	} {
+		delimiter, consistent = mixed.ConsistentDelimiter()
+		if consistent || delimiter != "" {
+			t.Errorf("%v received: '%v' '%v' but expected: '%v' '%v'", mixed, delimiter, consistent, "", false)
+		}
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	t.Parallel()
+	a := Pairs{
+		NewPairWithDelimiter("btc", "usdt", "-"),
+		NewPairWithDelimiter("ETH", "USDT", "-"),
+		NewPairWithDelimiter("LTC", "BTC", "/"),
+	}
+	b := Pairs{
+		NewPairWithDelimiter("BTC", "USDT", "_"),
+		NewPairWithDelimiter("BTC", "LTC", "_"),
+		NewPairWithDelimiter("XRP", "USDT", "_"),
+	}
+	onlyA, onlyB := SymmetricDifference(a, b, true)
+	if len(onlyA) != 2 || onlyA[0].String() != "ETH-USDT" || onlyA[1].String() != "LTC/BTC" {
+		t.Errorf("received: '%v' but expected: '%v'", onlyA, "[ETH-USDT LTC/BTC]")
+	}
+	if len(onlyB) != 2 || onlyB[0].String() != "BTC_LTC" || onlyB[1].String() != "XRP_USDT" {
+		t.Errorf("received: '%v' but expected: '%v'", onlyB, "[BTC_LTC XRP_USDT]")
+	}
+
+	onlyA, onlyB = SymmetricDifference(a, b, false)
+	if len(onlyA) != 1 || onlyA[0].String() != "ETH-USDT" {
+		t.Errorf("received: '%v' but expected: '%v'", onlyA, "[ETH-USDT]")
+	}
+	if len(onlyB) != 1 || onlyB[0].String() != "XRP_USDT" {
+		t.Errorf("received: '%v' but expected: '%v'", onlyB, "[XRP_USDT]")
+	}
+
+	onlyA, onlyB = SymmetricDifference(nil, b, true)
+	if len(onlyA) != 0 || len(onlyB) != 3 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", len(onlyA), len(onlyB), 0, 3)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+	universe := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(LTC, BTC),
+		NewPair(XRP, USDT),
+		NewPair(DOGE, USDT),
+	}
+	configured := Pairs{
+		NewPairWithDelimiter("btc", "usdt", "-"),
+		NewPair(ETH, USDT),
+		NewPair(BTC, LTC),
+		NewPair(BNB, USDT),
+	}
+	ratio, missing := Coverage(configured, universe, true)
+	if ratio != 0.4 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0.4)
+	}
+	if len(missing) != 3 || !missing[0].Equal(NewPair(LTC, BTC)) {
+		t.Errorf("received: '%v' but expected: '%v'", missing, "[LTCBTC XRPUSDT DOGEUSDT]")
+	}
+
+	ratio, missing = Coverage(configured, universe, false)
+	if ratio != 0.6 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0.6)
+	}
+	if len(missing) != 2 || !missing[0].Equal(NewPair(XRP, USDT)) || !missing[1].Equal(NewPair(DOGE, USDT)) {
+		t.Errorf("received: '%v' but expected: '%v'", missing, "[XRPUSDT DOGEUSDT]")
+	}
+
+	ratio, missing = Coverage(configured, nil, true)
+	if ratio != 0 || missing != nil {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", ratio, missing, 0, nil)
+	}
+	ratio, _ = Coverage(universe, universe, true)
+	if ratio != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 1)
+	}
+}
+
+func TestOrphanCurrencies(t *testing.T) {
+	t.Parallel()
+	universe := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(ETH, BTC),
+		NewPairWithDelimiter("btc", "usdt", "-"),
+		NewPair(XRP, USDT),
+	}
+	orphans := OrphanCurrencies(universe)
+	if len(orphans) != 1 || orphans[0] != "XRP" {
+		t.Errorf("received: '%v' but expected: '%v'", orphans, "[XRP]")
+	}
+	orphans = OrphanCurrencies(Pairs{NewPair(LTC, BTC)})
+	if len(orphans) != 2 || orphans[0] != "BTC" || orphans[1] != "LTC" {
+		t.Errorf("received: '%v' but expected: '%v'", orphans, "[BTC LTC]")
+	}
+	if orphans = OrphanCurrencies(nil); orphans != nil {
+		t.Errorf("received: '%v' but expected: '%v'", orphans, nil)
+	}
+}
+
+func TestShufflePairs(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPair(BTC, USDT), NewPair(ETH, USDT), NewPair(LTC, BTC), NewPair(XRP, USDT),
+		NewPair(DOGE, USDT), NewPair(BNB, BTC), NewPair(ETH, BTC), NewPair(ADA, USDT),
+	}
+	original := make(Pairs, len(pairs))
+	copy(original, pairs)
+
+	first, second := ShufflePairs(pairs, 1337), ShufflePairs(pairs, 1337)
+	if len(first) != len(pairs) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(first), len(pairs))
+	}
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Errorf("received: '%v' but expected: '%v'", second, first)
+			break
+		}
+	}
+	for i := range pairs {
+		if !pairs[i].Equal(original[i]) {
+			t.Errorf("received: '%v' but expected input to be untouched: '%v'", pairs, original)
+			break
+		}
+	}
+	for i := range original {
+		if !first.Contains(original[i], true) {
+			t.Errorf("received: '%v' but expected to contain: '%v'", first, original[i])
+		}
+	}
+	if ShufflePairs(nil, 1) != nil {
+		t.Error("expected nil for nil pairs")
+	}
+}
+
+func TestBaseMarketCounts(t *testing.T) {
+	t.Parallel()
+	counts := BaseMarketCounts(Pairs{
+		NewPair(BTC, USDT),
+		NewPair(BTC, USD),
+		NewPair(BTC, EUR),
+		NewPairWithDelimiter("btc", "usdt", "-"),
+		NewPair(ETH, USDT),
+		NewPair(ETH, BTC),
+		NewPair(XRP, USDT),
+	})
+	expected := map[string]int{"BTC": 3, "ETH": 2, "XRP": 1}
+	if len(counts) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", counts, expected)
+	}
+	for base, count := range expected {
+		if counts[base] != count {
+			t.Errorf("%v received: '%v' but expected: '%v'", base, counts[base], count)
+		}
+	}
+	if counts = BaseMarketCounts(nil); len(counts) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(counts), 0)
+	}
+}
+
+func TestIsConnectedGraph(t *testing.T) {
+	t.Parallel()
+	connected, components := IsConnectedGraph(Pairs{
+		NewPair(BTC, USDT),
+		NewPairWithDelimiter("eth", "btc", "-"),
+		NewPair(XRP, ETH),
+	})
+	if !connected || components != nil {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", connected, components, true, nil)
+	}
+
+	connected, components = IsConnectedGraph(Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(XRP, EUR),
+		NewPair(DOGE, LTC),
+		NewPair(LTC, EUR),
+	})
+	if connected {
+		t.Errorf("received: '%v' but expected: '%v'", connected, false)
+	}
+	if len(components) != 2 ||
+		strings.Join(components[0], ",") != "BTC,ETH,USDT" ||
+		strings.Join(components[1], ",") != "DOGE,EUR,LTC,XRP" {
+		t.Errorf("received: '%v' but expected: '%v'", components, "[[BTC ETH USDT] [DOGE EUR LTC XRP]]")
+	}
+
+	if connected, components = IsConnectedGraph(nil); !connected || components != nil {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", connected, components, true, nil)
+	}
+}
+
+func TestParsePairList(t *testing.T) {
+	t.Parallel()
+	pairs, err := ParsePairList(" BTC-USD,ETH-USD ETH-BTC,,\tltc_usdt\n")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	expected := Pairs{NewPair(BTC, USD), NewPair(ETH, USD), NewPair(ETH, BTC), NewPair(LTC, USDT)}
+	if len(pairs) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", pairs, expected)
+	}
+	for i := range expected {
+		if !pairs[i].Equal(expected[i]) {
+			t.Errorf("received: '%v' but expected: '%v'", pairs[i], expected[i])
+		}
+	}
+	if pairs[3].Delimiter != UnderscoreDelimiter {
+		t.Errorf("received: '%v' but expected: '%v'", pairs[3].Delimiter, UnderscoreDelimiter)
+	}
+
+	_, err = ParsePairList("BTC-USD, BT,ETH-USD X")
+	if !errors.Is(err, errCannotCreatePair) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errCannotCreatePair)
+	}
+	if !strings.Contains(err.Error(), "BT:") || !strings.Contains(err.Error(), "X:") {
+		t.Errorf("received: '%v' but expected both invalid tokens to be reported", err)
+	}
+
+	pairs, err = ParsePairList(" , ")
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(pairs) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(pairs), 0)
+	}
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	t.Parallel()
+	a := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(LTC, BTC),
+		NewPairWithDelimiter("btc", "usdt", "-"),
+	}
+	b := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(BTC, LTC),
+		NewPair(XRP, USDT),
+	}
+	if ratio := JaccardSimilarity(a, b, true); ratio != 0.4 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0.4)
+	}
+	if ratio := JaccardSimilarity(a, b, false); ratio != 0.75 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0.75)
+	}
+	if ratio := JaccardSimilarity(a, a, true); ratio != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 1)
+	}
+	if ratio := JaccardSimilarity(a, nil, true); ratio != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0)
+	}
+	if ratio := JaccardSimilarity(nil, nil, false); ratio != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", ratio, 0)
+	}
+}
+
+func TestLoadPairsDedup(t *testing.T) {
+	t.Parallel()
+	pairs, warnings := LoadPairsDedup([]string{"BTCUSD", "BTC-USD"})
+	if len(pairs) != 1 || !pairs[0].Equal(NewPair(BTC, USD)) || pairs[0].Delimiter != "" {
+		t.Errorf("received: '%v' but expected: '%v'", pairs, "[BTCUSD]")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"BTC-USD"`) || !strings.Contains(warnings[0], `"BTCUSD"`) {
+		t.Errorf("received: '%v' but expected a warning naming both symbols", warnings)
+	}
+
+	pairs, warnings = LoadPairsDedup([]string{"eth_btc", "ETH/BTC", "BTC-ETH", "ab", "ethbtc"})
+	if len(pairs) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(pairs), 2)
+	}
+	if pairs[0].String() != "eth_btc" || pairs[1].String() != "BTC-ETH" {
+		t.Errorf("received: '%v' but expected: '%v'", pairs, "[eth_btc BTC-ETH]")
+	}
+	if len(warnings) != 3 || !strings.Contains(warnings[1], `"ab"`) {
+		t.Errorf("received: '%v' but expected: '%v' warnings", warnings, 3)
+	}
+
+	if pairs, warnings = LoadPairsDedup(nil); pairs != nil || warnings != nil {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", pairs, warnings, nil, nil)
+	}
+}
+
+func TestMinimalQuoteCover(t *testing.T) {
+	t.Parallel()
+	if cover := MinimalQuoteCover(nil); len(cover) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", cover, "[]")
+	}
+	pairs := Pairs{
+		NewPair(BTC, USDT),
+		NewPair(ETH, USDT),
+		NewPair(LTC, USDT),
+		NewPair(ETH, BTC),
+		NewPair(LTC, BTC),
+		NewPair(XRP, EUR),
+		NewPair(BTC, EUR),
+		NewPairWithDelimiter("doge", "usd", "-"),
+	}
+	// USDT quotes three pairs, BTC and EUR then tie on two pairs each and are
+	// taken alphabetically, leaving USD to cover DOGE-USD. Base currencies never
+	// count towards the cover
+	cover := strings.Join(MinimalQuoteCover(pairs), ",")
+	if cover != "USDT,BTC,EUR,USD" {
+		t.Errorf("received: '%v' but expected: '%v'", cover, "USDT,BTC,EUR,USD")
+	}
+	cover = strings.Join(MinimalQuoteCover(Pairs{NewPair(BTC, USDT), NewPair(ETH, USDT), NewPair(USDT, EMPTYCODE), EMPTYPAIR}), ",")
+	if cover != "USDT" {
+		t.Errorf("received: '%v' but expected: '%v'", cover, "USDT")
+	}
+}
+
+func TestNewPairSet(t *testing.T) {
+	t.Parallel()
+	set := NewPairSet(Pairs{
+		NewPairWithDelimiter("BTC", "USD", "-"),
+		NewPairWithDelimiter("btc", "usd", "_"),
+		NewPairWithDelimiter("USD", "BTC", "-"),
+		NewPairWithDelimiter("ETH", "USD", ""),
+	})
+	if len(set) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(set), 3)
+	}
+	if p := set["BTCUSD"]; p.String() != "BTC-USD" {
+		t.Errorf("received: '%v' but expected: '%v'", p, "BTC-USD")
+	}
+	if _, ok := set["USDBTC"]; !ok {
+		t.Error("expected the swapped pair to be kept")
+	}
+	if _, ok := set[NewPairWithDelimiter("eth", "usd", "/").Key()]; !ok {
+		t.Error("expected lookup by key to ignore case and delimiter")
+	}
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPairWithDelimiter("BTC", "USD", "-"),
+		NewPairWithDelimiter("eth", "usd", "_"),
+		NewPairWithDelimiter("btc", "usd", "_"),
+		NewPairWithDelimiter("USD", "BTC", "/"),
+		NewPairWithDelimiter("ETH", "USD", ""),
+		NewPairWithDelimiter("LTC", "BTC", "-"),
+	}
+	if deduped := pairs.RemoveDuplicates(true).Join(); deduped != "BTC-USD,eth_usd,USD/BTC,LTC-BTC" {
+		t.Errorf("received: '%v' but expected: '%v'", deduped, "BTC-USD,eth_usd,USD/BTC,LTC-BTC")
+	}
+	if deduped := pairs.RemoveDuplicates(false).Join(); deduped != "BTC-USD,eth_usd,LTC-BTC" {
+		t.Errorf("received: '%v' but expected: '%v'", deduped, "BTC-USD,eth_usd,LTC-BTC")
+	}
+	if len(pairs) != 6 {
+		t.Errorf("received: '%v' but expected: '%v'", len(pairs), 6)
+	}
+	if deduped := Pairs(nil).RemoveDuplicates(false); len(deduped) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", deduped, "[]")
+	}
+}
+
+func TestPairsSetOperations(t *testing.T) {
+	t.Parallel()
+	oldPairs := Pairs{
+		NewPairWithDelimiter("BTC", "USD", "-"),
+		NewPairWithDelimiter("ETH", "USD", "-"),
+		NewPairWithDelimiter("LTC", "BTC", "-"),
+		EMPTYPAIR,
+	}
+	newPairs := Pairs{
+		NewPairWithDelimiter("btc", "usd", "_"),
+		NewPairWithDelimiter("USD", "ETH", "/"),
+		NewPairWithDelimiter("XRP", "USD", "/"),
+		NewPairWithDelimiter("xrp", "usd", "_"),
+		EMPTYPAIR,
+	}
+
+	added, removed := PairsDifference(oldPairs, newPairs, true)
+	if added.Join() != "USD/ETH,XRP/USD" {
+		t.Errorf("received: '%v' but expected: '%v'", added.Join(), "USD/ETH,XRP/USD")
+	}
+	if removed.Join() != "ETH-USD,LTC-BTC" {
+		t.Errorf("received: '%v' but expected: '%v'", removed.Join(), "ETH-USD,LTC-BTC")
+	}
+	added, removed = PairsDifference(oldPairs, newPairs, false)
+	if added.Join() != "XRP/USD" {
+		t.Errorf("received: '%v' but expected: '%v'", added.Join(), "XRP/USD")
+	}
+	if removed.Join() != "LTC-BTC" {
+		t.Errorf("received: '%v' but expected: '%v'", removed.Join(), "LTC-BTC")
+	}
+
+	if intersection := PairsIntersection(oldPairs, newPairs, true).Join(); intersection != "BTC-USD" {
+		t.Errorf("received: '%v' but expected: '%v'", intersection, "BTC-USD")
+	}
+	if intersection := PairsIntersection(newPairs, oldPairs, false).Join(); intersection != "btc_usd,USD/ETH" {
+		t.Errorf("received: '%v' but expected: '%v'", intersection, "btc_usd,USD/ETH")
+	}
+
+	if union := PairsUnion(oldPairs, newPairs, true).Join(); union != "BTC-USD,ETH-USD,LTC-BTC,USD/ETH,XRP/USD" {
+		t.Errorf("received: '%v' but expected: '%v'", union, "BTC-USD,ETH-USD,LTC-BTC,USD/ETH,XRP/USD")
+	}
+	if union := PairsUnion(oldPairs, newPairs, false).Join(); union != "BTC-USD,ETH-USD,LTC-BTC,XRP/USD" {
+		t.Errorf("received: '%v' but expected: '%v'", union, "BTC-USD,ETH-USD,LTC-BTC,XRP/USD")
+	}
+	if union := PairsUnion(nil, Pairs{EMPTYPAIR}, true); len(union) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", union, "[]")
+	}
+}
+
+func TestSortPairs(t *testing.T) {
+	t.Parallel()
+	pairs := Pairs{
+		NewPairWithDelimiter("eth", "usd", "_"),
+		NewPairWithDelimiter("BTC", "USDT", "-"),
+		NewPairWithDelimiter("btc", "eth", "/"),
+		NewPairWithDelimiter("BTC", "usdt", ""),
+		NewPairWithDelimiter("ada", "BTC", "-"),
+		NewPairWithDelimiter("btc", "USDT", "_"),
+	}
+	SortPairs(pairs)
+	expected := "ada-BTC,btc/eth,BTC-USDT,BTCusdt,btc_USDT,eth_usd"
+	if sorted := pairs.Join(); sorted != expected {
+		t.Errorf("received: '%v' but expected: '%v'", sorted, expected)
+	}
+	SortPairs(nil)
+}
@@ -638,3 +638,16 @@ func TestOpenNew(t *testing.T) {
 		t.Fatalf("received: %v but expected: %v", err, nil)
 	}
 }
+
+func TestSubLoggerGetOutput(t *testing.T) {
+	t.Parallel()
+	sl := SubLogger{}
+	if output := sl.GetOutput(); output != nil {
+		t.Fatalf("received: %v but expected: %v", output, nil)
+	}
+	var buf bytes.Buffer
+	sl.SetOutput(&buf)
+	if output := sl.GetOutput(); output != &buf {
+		t.Fatalf("received: %v but expected: %v", output, &buf)
+	}
+}
@@ -28,6 +28,13 @@ func (sl *SubLogger) SetOutput(o io.Writer) {
 	sl.mtx.Unlock()
 }
 
+// GetOutput returns the current output writer
+func (sl *SubLogger) GetOutput() io.Writer {
+	sl.mtx.RLock()
+	defer sl.mtx.RUnlock()
+	return sl.output
+}
+
 // SetLevels overrides the default levels with new levels; levelception
 func (sl *SubLogger) SetLevels(newLevels Levels) {
 	sl.mtx.Lock()